@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
@@ -11,6 +12,7 @@ import (
 	"github.com/apono-io/apono-cli/pkg/build"
 	"github.com/apono-io/apono-cli/pkg/commands/apono"
 	"github.com/apono-io/apono-cli/pkg/urihandler"
+	"github.com/apono-io/apono-cli/pkg/utils"
 )
 
 func main() {
@@ -26,11 +28,18 @@ func main() {
 		os.Exit(1)
 	}
 
-	if regErr := urihandler.EnsureRegistered(os.Stdin, os.Stdout); regErr != nil {
-		fmt.Fprintf(os.Stderr, "warning: apono:// URL handler not installed: %v\n", regErr)
+	// `apono prompt` runs on every shell prompt and must stay fast and silent.
+	if !runner.IsPromptCommand(os.Args[1:]) {
+		if regErr := urihandler.EnsureRegistered(os.Stdin, os.Stdout); regErr != nil {
+			fmt.Fprintf(os.Stderr, "warning: apono:// URL handler not installed: %v\n", regErr)
+		}
 	}
 
 	err = execute(runner)
+	var exitCodeErr *utils.ExitCodeError
+	if errors.As(err, &exitCodeErr) {
+		os.Exit(exitCodeErr.Code)
+	}
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err.Error())
 		fmt.Fprintln(os.Stderr, "Use '--help' to see usage.")
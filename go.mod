@@ -18,9 +18,9 @@ require (
 	github.com/pkg/browser v0.0.0-20210911075715-681adbf594b8
 	github.com/spf13/cobra v1.6.1
 	github.com/spf13/pflag v1.0.5
-	github.com/thediveo/enumflag v0.10.1
 	golang.org/x/oauth2 v0.6.0
 	golang.org/x/sync v0.11.0
+	golang.org/x/sys v0.36.0
 	golang.org/x/text v0.8.0
 	gopkg.in/yaml.v3 v3.0.1
 )
@@ -56,7 +56,6 @@ require (
 	github.com/sahilm/fuzzy v0.1.1 // indirect
 	github.com/xo/terminfo v0.0.0-20220910002029-abceb7e1c41e // indirect
 	golang.org/x/net v0.8.0 // indirect
-	golang.org/x/sys v0.36.0 // indirect
 	golang.org/x/time v0.0.0-20220922220347-f3bd1da661af // indirect
 	google.golang.org/appengine v1.6.7 // indirect
 	google.golang.org/protobuf v1.30.0 // indirect
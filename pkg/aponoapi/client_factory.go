@@ -42,48 +42,80 @@ func CreateClient(ctx context.Context, profileName string) (*AponoClient, error)
 		return nil, err
 	}
 
-	oauthToken := &sessionCfg.Token
 	personalToken := sessionCfg.PersonalToken
 	var httpClient *http.Client
 
 	if personalToken == "" {
-		ts := NewRefreshableTokenSource(ctx, sessionCfg.GetOAuth2Config(), oauthToken, func(t *oauth2.Token) error {
-			return saveOAuthToken(profileName, t)
-		})
-		httpClient = oauth2.NewClient(ctx, ts)
+		httpClient = oauth2.NewClient(ctx, newOAuthTokenSource(ctx, profileName, sessionCfg))
 	} else {
 		httpClient = HTTPClientWithPersonalToken(personalToken)
 	}
 
-	endpointURL, err := url.Parse(sessionCfg.ApiURL)
+	return newAponoClient(sessionCfg.ApiURL, httpClient, &Session{
+		AccountID: sessionCfg.AccountID,
+		UserID:    sessionCfg.UserID,
+	})
+}
+
+// GetAccessToken returns a valid token of the profile, refreshing the OAuth
+// token when it expired. Personal tokens are returned as is, without expiry.
+func GetAccessToken(ctx context.Context, profileName string) (*oauth2.Token, error) {
+	sessionCfg, err := config.GetProfileByName(config.ProfileName(profileName))
 	if err != nil {
-		return nil, fmt.Errorf("failed parsing url %s with error: %w", sessionCfg.ApiURL, err)
+		return nil, err
 	}
 
-	adminAPIClientCfg := apono.NewConfiguration()
-	adminAPIClientCfg.Scheme = endpointURL.Scheme
-	adminAPIClientCfg.Host = endpointURL.Host
-	adminAPIClientCfg.UserAgent = fmt.Sprintf("apono-cli/%s (%s; %s)", build.Version, build.Commit, build.Date)
-	adminAPIClientCfg.HTTPClient = httpClient
+	if sessionCfg.PersonalToken != "" {
+		return &oauth2.Token{AccessToken: sessionCfg.PersonalToken, TokenType: "Bearer"}, nil
+	}
 
-	client := apono.NewAPIClient(adminAPIClientCfg)
+	token, err := newOAuthTokenSource(ctx, profileName, sessionCfg).Token()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create apono client: %w", err)
+		if IsInvalidGrant(err) {
+			return nil, ErrSessionExpired
+		}
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func newOAuthTokenSource(ctx context.Context, profileName string, sessionCfg *config.SessionConfig) oauth2.TokenSource {
+	return NewRefreshableTokenSource(ctx, sessionCfg.GetOAuth2Config(), &sessionCfg.Token, config.WithLock,
+		func() (*oauth2.Token, error) {
+			return loadOAuthToken(profileName)
+		},
+		func(t *oauth2.Token) error {
+			return saveOAuthToken(profileName, t)
+		})
+}
+
+// CreateClientWithPersonalToken creates a client that authenticates with the
+// personal token, without reading the CLI config.
+func CreateClientWithPersonalToken(apiURL, personalToken string) (*AponoClient, error) {
+	if apiURL == "" {
+		apiURL = config.APIDefaultURL
 	}
 
+	return newAponoClient(apiURL, HTTPClientWithPersonalToken(personalToken), &Session{})
+}
+
+func newAponoClient(apiURL string, httpClient *http.Client, session *Session) (*AponoClient, error) {
+	endpointURL, err := url.Parse(apiURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create clientapi client: %w", err)
+		return nil, fmt.Errorf("failed parsing url %s with error: %w", apiURL, err)
 	}
 
-	clientAPI := CreateClientAPI(endpointURL, httpClient)
+	adminAPIClientCfg := apono.NewConfiguration()
+	adminAPIClientCfg.Scheme = endpointURL.Scheme
+	adminAPIClientCfg.Host = endpointURL.Host
+	adminAPIClientCfg.UserAgent = fmt.Sprintf("apono-cli/%s (%s; %s)", build.Version, build.Commit, build.Date)
+	adminAPIClientCfg.HTTPClient = httpClient
 
 	return &AponoClient{
-		APIClient: client,
-		ClientAPI: clientAPI,
-		Session: &Session{
-			AccountID: sessionCfg.AccountID,
-			UserID:    sessionCfg.UserID,
-		},
+		APIClient: apono.NewAPIClient(adminAPIClientCfg),
+		ClientAPI: CreateClientAPI(endpointURL, httpClient),
+		Session:   session,
 	}, nil
 }
 
@@ -97,15 +129,37 @@ func CreateClientAPI(endpointURL *url.URL, httpClient *http.Client) *clientapi.A
 	return clientAPI
 }
 
+func loadOAuthToken(profileName string) (*oauth2.Token, error) {
+	sessionCfg, err := config.GetProfileByName(config.ProfileName(profileName))
+	if err != nil {
+		return nil, err
+	}
+	return &sessionCfg.Token, nil
+}
+
+// saveOAuthToken is called by the token source under the config lock, so it
+// uses Get and Save rather than Update.
 func saveOAuthToken(profileName string, t *oauth2.Token) error {
 	cfg, err := config.Get()
 	if err != nil {
 		return err
 	}
 
-	sessionCfg := cfg.Auth.Profiles[config.ProfileName(profileName)]
+	pn := config.ResolveProfileName(config.ProfileName(profileName))
+	if pn == "" {
+		pn = cfg.Auth.ActiveProfile
+	}
+	sessionCfg := cfg.Auth.Profiles[pn]
+	if err = config.LoadProfileSecrets(pn, &sessionCfg); err != nil {
+		return err
+	}
 	sessionCfg.Token = *t
-	cfg.Auth.Profiles[config.ProfileName(profileName)] = sessionCfg
+	if sessionCfg.SecretsBackend != "" {
+		if err = config.StoreProfileSecrets(pn, &sessionCfg, sessionCfg.SecretsBackend); err != nil {
+			return err
+		}
+	}
+	cfg.Auth.Profiles[pn] = sessionCfg
 	return config.Save(cfg)
 }
 
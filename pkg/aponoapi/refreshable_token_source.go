@@ -24,25 +24,43 @@ func IsInvalidGrant(err error) bool {
 // returns an error if it should not be used.
 type TokenUpdateFunc func(*oauth2.Token) error
 
+// TokenLoadFunc returns the latest persisted token, which another process may
+// have refreshed since this one started.
+type TokenLoadFunc func() (*oauth2.Token, error)
+
+// TokenLockFunc runs fn while no other process can refresh the token.
+type TokenLockFunc func(fn func() error) error
+
 func NewRefreshableTokenSource(
 	ctx context.Context,
 	cfg oauth2.Config,
 	token *oauth2.Token,
+	lock TokenLockFunc,
+	load TokenLoadFunc,
 	f TokenUpdateFunc,
 ) oauth2.TokenSource {
 	return &refreshableTokenSource{
-		new: cfg.TokenSource(ctx, token),
-		t:   token,
-		f:   f,
+		ctx:  ctx,
+		cfg:  cfg,
+		t:    token,
+		lock: lock,
+		load: load,
+		f:    f,
 	}
 }
 
 // refreshableTokenSource is essentially `oauth2.reuseTokenSource` with `TokenUpdateFunc` added.
+// Refresh tokens rotate, so refreshing is serialized across processes: under
+// the lock the latest token is loaded first, and only refreshed when no other
+// process already did.
 type refreshableTokenSource struct {
-	new oauth2.TokenSource
-	mu  sync.Mutex // guards t
-	t   *oauth2.Token
-	f   TokenUpdateFunc // called when token refreshed so new refresh token can be persisted
+	ctx  context.Context
+	cfg  oauth2.Config
+	mu   sync.Mutex // guards t
+	t    *oauth2.Token
+	lock TokenLockFunc
+	load TokenLoadFunc
+	f    TokenUpdateFunc // called when token refreshed so new refresh token can be persisted
 }
 
 // Token returns the current token if it's still valid, else will
@@ -54,11 +72,27 @@ func (s *refreshableTokenSource) Token() (*oauth2.Token, error) {
 		return s.t, nil
 	}
 
-	t, err := s.new.Token()
-	if err != nil {
+	var refreshed *oauth2.Token
+	err := s.lock(func() error {
+		if latest, err := s.load(); err == nil && latest != nil {
+			s.t = latest
+			if latest.Valid() {
+				return nil
+			}
+		}
+
+		t, err := s.cfg.TokenSource(s.ctx, s.t).Token()
+		if err != nil {
+			return err
+		}
+
+		refreshed = t
+		s.t = t
+		return s.f(t)
+	})
+	if err != nil && refreshed == nil {
 		return nil, err
 	}
 
-	s.t = t
-	return t, s.f(t)
+	return s.t, err
 }
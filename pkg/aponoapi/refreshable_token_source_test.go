@@ -1,10 +1,14 @@
 package aponoapi
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"golang.org/x/oauth2"
 )
@@ -52,3 +56,52 @@ func TestIsInvalidGrant(t *testing.T) {
 		})
 	}
 }
+
+func TestRefreshableTokenSourceUsesTokenRefreshedByAnotherProcess(t *testing.T) {
+	refreshes := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		refreshes++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"refreshed","refresh_token":"rotated","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	cfg := oauth2.Config{Endpoint: oauth2.Endpoint{TokenURL: server.URL}}
+	expired := &oauth2.Token{AccessToken: "expired", RefreshToken: "old", Expiry: time.Now().Add(-time.Minute)}
+	latest := &oauth2.Token{AccessToken: "latest", RefreshToken: "new", Expiry: time.Now().Add(time.Hour)}
+
+	locked, updates := 0, 0
+	lock := func(fn func() error) error {
+		locked++
+		return fn()
+	}
+	load := func() (*oauth2.Token, error) { return latest, nil }
+	update := func(*oauth2.Token) error {
+		updates++
+		return nil
+	}
+
+	token, err := NewRefreshableTokenSource(context.Background(), cfg, expired, lock, load, update).Token()
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if token.AccessToken != "latest" {
+		t.Errorf("Token() = %q, want the token loaded under the lock", token.AccessToken)
+	}
+	if locked != 1 || refreshes != 0 || updates != 0 {
+		t.Errorf("locked %d times, refreshed %d times, updated %d times, want 1, 0 and 0", locked, refreshes, updates)
+	}
+
+	// The loaded token expired as well, so this process refreshes it.
+	latest = &oauth2.Token{AccessToken: "stale", RefreshToken: "new", Expiry: time.Now().Add(-time.Minute)}
+	token, err = NewRefreshableTokenSource(context.Background(), cfg, expired, lock, load, update).Token()
+	if err != nil {
+		t.Fatalf("Token() error = %v", err)
+	}
+	if token.AccessToken != "refreshed" || token.RefreshToken != "rotated" {
+		t.Errorf("Token() = %q/%q, want the refreshed token", token.AccessToken, token.RefreshToken)
+	}
+	if refreshes != 1 || updates != 1 {
+		t.Errorf("refreshed %d times, updated %d times, want 1 and 1", refreshes, updates)
+	}
+}
@@ -0,0 +1,7 @@
+// Package api holds the OpenAPI spec the client API is generated from.
+package api
+
+import _ "embed"
+
+//go:embed openapi.json
+var OpenAPISpec []byte
@@ -0,0 +1,85 @@
+package actions
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/services"
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+func AccessGroup() *cobra.Command {
+	return &cobra.Command{
+		Use:     "group",
+		Short:   "Manage access groups, the units of access granted together by a request",
+		Aliases: []string{"groups"},
+	}
+}
+
+func AccessGroupDescribe() *cobra.Command {
+	format := new(utils.Format)
+
+	cmd := &cobra.Command{
+		Use:   "describe <id|integration>",
+		Short: "Show an access group with its access units",
+		Long:  "Show an access group with its access units. The group is given by its ID, or by its integration as <type>/<name> or <name> when one of your active requests has it",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := aponoapi.GetClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			accessGroupID, err := services.ResolveAccessGroupID(cmd.Context(), client, args[0])
+			if err != nil {
+				return err
+			}
+
+			details, err := services.GetAccessGroupDetails(cmd.Context(), client, accessGroupID)
+			if err != nil {
+				return err
+			}
+
+			return services.PrintAccessGroupDetails(cmd, details, *format)
+		},
+	}
+
+	utils.AddFormatFlag(cmd.Flags(), format)
+
+	return cmd
+}
+
+func AccessGroupReset() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reset-credentials <id|integration>",
+		Short: "Reset the credentials of every access session in an access group",
+		Long:  "Reset the credentials of every access session in an access group. The group is given by its ID, or by its integration as <type>/<name> or <name> when one of your active requests has it",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := aponoapi.GetClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			accessGroupID, err := services.ResolveAccessGroupID(cmd.Context(), client, args[0])
+			if err != nil {
+				return err
+			}
+
+			message, err := services.ResetAccessGroupCredentials(cmd.Context(), client, accessGroupID)
+			if err != nil {
+				return err
+			}
+			if message == "" {
+				message = "credentials reset request has been submitted"
+			}
+
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), message)
+			return err
+		},
+	}
+
+	return cmd
+}
@@ -10,12 +10,16 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/clientapi"
 )
 
 const (
 	integrationFilterFlagName = "integration"
 	bundleFilterFlagName      = "bundle"
 	requestIDFlagName         = "request"
+	groupByFlagName           = "group-by"
+
+	groupByIntegration = "integration"
 )
 
 func AccessList() *cobra.Command {
@@ -23,17 +27,38 @@ func AccessList() *cobra.Command {
 	var integrationFilter string
 	var bundleFilter string
 	var requestFilter string
+	var groupBy string
+	var allProfiles bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all access sessions",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if allProfiles {
+				if groupBy != "" {
+					return fmt.Errorf("--%s cannot be used with --%s", groupByFlagName, utils.AllProfilesFlagName)
+				}
+
+				return listAccessSessionsFromAllProfiles(cmd, integrationFilter, bundleFilter, requestFilter, format)
+			}
+
 			client, err := aponoapi.GetClient(cmd.Context())
 			if err != nil {
 				return err
 			}
 
 			integrationIDs := resolveIntegrationNameOrIDFlag(cmd.Context(), client, integrationFilter)
+			if groupBy != "" {
+				if groupBy != groupByIntegration {
+					return fmt.Errorf("invalid --%s value %q, the only supported value is '%s'", groupByFlagName, groupBy, groupByIntegration)
+				}
+				if bundleFilter != "" || requestFilter != "" {
+					return fmt.Errorf("--%s cannot be used with --%s or --%s", groupByFlagName, bundleFilterFlagName, requestIDFlagName)
+				}
+
+				return listAccessSessionsGroups(cmd, client, integrationIDs, format)
+			}
+
 			bundleIDsFilter := resolveBundleNameOrIDFlag(cmd.Context(), client, bundleFilter)
 			requestIDsFilter := resolveRequestIDFlag(requestFilter)
 
@@ -57,13 +82,47 @@ func AccessList() *cobra.Command {
 
 	flags := cmd.Flags()
 	utils.AddFormatFlag(flags, format)
+	utils.AddAllProfilesFlag(flags, &allProfiles)
 	flags.StringVarP(&integrationFilter, integrationFilterFlagName, "i", "", "The integration id or type/name, for example: \"aws-account/My AWS integration\"")
 	flags.StringVarP(&bundleFilter, bundleFilterFlagName, "b", "", "filter by bundle name or id")
 	flags.StringVarP(&requestFilter, requestIDFlagName, "r", "", "filter by request id")
+	flags.StringVar(&groupBy, groupByFlagName, "", "group the sessions, the only supported value is 'integration'")
 
 	return cmd
 }
 
+func listAccessSessionsGroups(cmd *cobra.Command, client *aponoapi.AponoClient, integrationIDs []string, format *utils.Format) error {
+	groups, err := services.ListAccessSessionsGroups(cmd.Context(), client, integrationIDs)
+	if err != nil {
+		return err
+	}
+
+	if len(groups) == 0 {
+		return fmt.Errorf("no active access found, create a new request by running this command: apono request create")
+	}
+
+	return services.PrintAccessSessionsGroups(cmd, groups, format)
+}
+
+// listAccessSessionsFromAllProfiles resolves the filters in every account
+// separately, as names map to different ids in each of them.
+func listAccessSessionsFromAllProfiles(cmd *cobra.Command, integrationFilter, bundleFilter, requestFilter string, format *utils.Format) error {
+	results, err := services.ListFromAllProfiles(cmd.Context(), cmd.ErrOrStderr(), func(ctx context.Context, client *aponoapi.AponoClient) ([]clientapi.AccessSessionClientModel, error) {
+		integrationIDs := resolveIntegrationNameOrIDFlag(ctx, client, integrationFilter)
+		bundleIDsFilter := resolveBundleNameOrIDFlag(ctx, client, bundleFilter)
+		return services.ListAccessSessions(ctx, client, integrationIDs, bundleIDsFilter, resolveRequestIDFlag(requestFilter))
+	})
+	if err != nil {
+		return err
+	}
+
+	if services.CountProfileResults(results) == 0 {
+		return fmt.Errorf("no active access found, create a new request by running this command: apono request create")
+	}
+
+	return services.PrintProfilesAccessSessions(cmd, results, *format)
+}
+
 func resolveBundleNameOrIDFlag(ctx context.Context, client *aponoapi.AponoClient, bundleIDOrName string) []string {
 	if bundleIDOrName == "" {
 		return nil
@@ -0,0 +1,62 @@
+package actions
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/services"
+)
+
+func AccessSync() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sync",
+		Short: "Refresh the available access sessions",
+		Long:  "Refresh the available access sessions right away, for example after a request was granted, instead of waiting for the next periodic sync",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := aponoapi.GetClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			before, err := services.ListAccessSessions(cmd.Context(), client, nil, nil, nil)
+			if err != nil {
+				return err
+			}
+
+			message, err := services.SyncAccessSessions(cmd.Context(), client)
+			if err != nil {
+				return err
+			}
+			if message != "" {
+				if _, err = fmt.Fprintln(cmd.OutOrStdout(), message); err != nil {
+					return err
+				}
+			}
+
+			after, err := services.ListAccessSessions(cmd.Context(), client, nil, nil, nil)
+			if err != nil {
+				return err
+			}
+
+			added, removed := services.DiffAccessSessions(before, after)
+			for _, session := range added {
+				if _, err = fmt.Fprintf(cmd.OutOrStdout(), "+ %s/%s\n", session.Type.Name, session.Name); err != nil {
+					return err
+				}
+			}
+			for _, session := range removed {
+				if _, err = fmt.Fprintf(cmd.OutOrStdout(), "- %s/%s\n", session.Type.Name, session.Name); err != nil {
+					return err
+				}
+			}
+
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "%d access sessions available (%d new, %d removed), run 'apono access list' to view them\n", len(after), len(added), len(removed))
+			return err
+		},
+	}
+
+	return cmd
+}
@@ -3,16 +3,17 @@ package actions
 import (
 	"fmt"
 	"os"
-	"runtime"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/apono-io/apono-cli/pkg/analytics"
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/clientapi"
 	"github.com/apono-io/apono-cli/pkg/config"
 	"github.com/apono-io/apono-cli/pkg/connect"
 	"github.com/apono-io/apono-cli/pkg/interactive/flows"
+	"github.com/apono-io/apono-cli/pkg/sdk"
 	"github.com/apono-io/apono-cli/pkg/services"
 	"github.com/apono-io/apono-cli/pkg/utils"
 )
@@ -22,6 +23,8 @@ const (
 	runFlagName     = "run"
 	clientFlagName  = "client"
 	profileFlagName = "profile"
+	windowFlagName  = "window"
+	paneFlagName    = "pane"
 
 	accountIDEnvVar = "_APONO_ACCOUNT_ID_"
 )
@@ -30,6 +33,8 @@ type accessUseCommandFlags struct {
 	outputFormat               string
 	shouldExecuteAccessCommand bool
 	clientID                   string
+	openInWindow               bool
+	openInPane                 bool
 }
 
 func AccessDetails() *cobra.Command {
@@ -47,8 +52,8 @@ func AccessDetails() *cobra.Command {
 				if cmdFlags.clientID == "" {
 					return fmt.Errorf("--client requires a client name (e.g. --client dbeaver)")
 				}
-				if runtime.GOOS != "darwin" {
-					return fmt.Errorf("--client is only supported on macOS; use --run to launch in your current terminal")
+				if !utils.SupportsClientLaunchers() {
+					return fmt.Errorf("--client is only supported on macOS and Linux; use --run to launch in your current terminal")
 				}
 			}
 			if accountID := os.Getenv(accountIDEnvVar); accountID != "" {
@@ -72,8 +77,15 @@ func AccessDetails() *cobra.Command {
 				return fmt.Errorf("access session with id %s not found", args[0])
 			}
 
+			muxLaunch, err := resolveMultiplexerLaunch(cmd, cmdFlags, session)
+			if err != nil {
+				return err
+			}
+
 			if cmd.Flags().Changed(clientFlagName) {
-				err = connect.NewClientStarter().Start(cmd, client, session.Id, cmdFlags.clientID, nil)
+				starter := connect.NewClientStarter()
+				starter.Multiplexer = muxLaunch
+				err = starter.Start(cmd, client, session.Id, cmdFlags.clientID, nil)
 				if err != nil {
 					return err
 				}
@@ -98,6 +110,10 @@ func AccessDetails() *cobra.Command {
 					return err
 				}
 
+				if muxLaunch != nil {
+					return executeInMultiplexer(cmd, client, session, muxLaunch)
+				}
+
 				return services.ExecuteAccessDetails(cmd, client, session)
 			}
 
@@ -105,7 +121,12 @@ func AccessDetails() *cobra.Command {
 				return fmt.Errorf("unsupported output format: %s. use one of: %s", connectionDetailsOutputFormat, strings.Join(session.ConnectionMethods, ", "))
 			}
 
-			accessDetails, customInstructionMessage, err := services.GetSessionDetails(cmd.Context(), client, session.Id, connectionDetailsOutputFormat)
+			details, err := sdk.NewClientFromAPI(client).AccessDetails(cmd.Context(), session.Id)
+			if err != nil {
+				return err
+			}
+
+			accessDetails, customInstructionMessage, err := services.RenderAccessDetails(details, connectionDetailsOutputFormat)
 			if err != nil {
 				return err
 			}
@@ -129,13 +150,53 @@ func AccessDetails() *cobra.Command {
 	flags := cmd.Flags()
 	flags.StringVarP(&cmdFlags.outputFormat, outputFlagName, "o", "instructions", fmt.Sprintf("output format: %s | %s | %s | %s", services.CliOutputFormat, services.LinkOutputFormat, services.InstructionsOutputFormat, services.JSONOutputFormat))
 	flags.BoolVarP(&cmdFlags.shouldExecuteAccessCommand, runFlagName, "r", false, "execute the cli command")
-	flags.StringVarP(&cmdFlags.clientID, clientFlagName, "c", "", "Launch the session in a supported local `client`. Supported on macOS only.\nSupported clients: dbeaver, tableplus, k9s")
+	flags.StringVarP(&cmdFlags.clientID, clientFlagName, "c", "", "Launch the session in a supported local `client`. Supported on macOS and Linux.\nSupported clients: dbeaver, tableplus, k9s, or a local launcher added with 'apono config launchers add'")
+
+	flags.BoolVar(&cmdFlags.openInWindow, windowFlagName, false, "When running inside tmux or screen, open the session in a new window")
+	flags.BoolVar(&cmdFlags.openInPane, paneFlagName, false, "When running inside tmux or screen, open the session in a new split pane")
 
 	cmd.MarkFlagsMutuallyExclusive(runFlagName, clientFlagName)
+	cmd.MarkFlagsMutuallyExclusive(windowFlagName, paneFlagName)
 
 	return cmd
 }
 
+// resolveMultiplexerLaunch decides whether --run and --client open the
+// session in a new tmux or screen window. --window and --pane override the
+// multiplexer.mode config.
+func resolveMultiplexerLaunch(cmd *cobra.Command, cmdFlags *accessUseCommandFlags, session *clientapi.AccessSessionClientModel) (*connect.MultiplexerLaunch, error) {
+	var modeOverride string
+	switch {
+	case cmdFlags.openInWindow:
+		modeOverride = connect.MultiplexerModeWindow
+	case cmdFlags.openInPane:
+		modeOverride = connect.MultiplexerModeSplit
+	}
+
+	if !cmdFlags.shouldExecuteAccessCommand && !cmd.Flags().Changed(clientFlagName) {
+		if modeOverride != "" {
+			return nil, fmt.Errorf("--%s and --%s can only be used with --%s or --%s", windowFlagName, paneFlagName, runFlagName, clientFlagName)
+		}
+		return nil, nil
+	}
+
+	return connect.ResolveMultiplexerLaunch(cmd, modeOverride, session.Id, session.Name)
+}
+
+func executeInMultiplexer(cmd *cobra.Command, client *aponoapi.AponoClient, session *clientapi.AccessSessionClientModel, muxLaunch *connect.MultiplexerLaunch) error {
+	details, err := connect.FetchAccessDetails(cmd.Context(), client, session.Id)
+	if err != nil {
+		return fmt.Errorf("error getting access details for session id %s: %w", session.Id, err)
+	}
+
+	command, err := muxLaunch.BuildCommand(details.GetCli())
+	if err != nil {
+		return err
+	}
+
+	return services.ExecuteCliCommand(cmd, session, command)
+}
+
 func overrideProfileClientByAccountID(cmd *cobra.Command, accountID string) error {
 	profileName, err := config.GetProfileByAccountID(accountID)
 	if err != nil {
@@ -0,0 +1,38 @@
+package actions
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/services"
+)
+
+const defaultWatchInterval = time.Minute
+
+// AccessWatch waits for a session to expire. It runs in the background of
+// tmux and screen windows opened for a session, which are closed or marked
+// once it exits.
+func AccessWatch() *cobra.Command {
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:    "watch <session_id>",
+		Short:  "Wait until an access session expires",
+		Hidden: true,
+		Args:   cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := aponoapi.GetClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			return services.WaitForAccessSessionExpiry(cmd.Context(), client, args[0], interval)
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", defaultWatchInterval, "How often to check the session")
+
+	return cmd
+}
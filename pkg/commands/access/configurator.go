@@ -15,5 +15,13 @@ func (c *Configurator) ConfigureCommands(rootCmd *cobra.Command) error {
 	accessCmd.AddCommand(actions.AccessList())
 	accessCmd.AddCommand(actions.AccessDetails())
 	accessCmd.AddCommand(actions.AccessReset())
+	accessCmd.AddCommand(actions.AccessSync())
+	accessCmd.AddCommand(actions.AccessWatch())
+
+	accessGroupCmd := actions.AccessGroup()
+	accessCmd.AddCommand(accessGroupCmd)
+	accessGroupCmd.AddCommand(actions.AccessGroupDescribe())
+	accessGroupCmd.AddCommand(actions.AccessGroupReset())
+
 	return nil
 }
@@ -9,7 +9,7 @@ import (
 func AccessHandler() *cobra.Command {
 	return &cobra.Command{
 		Use:     "access-handler",
-		Short:   "Manage the apono:// URL handler (macOS)",
+		Short:   "Manage the apono:// URL handler (macOS and Linux)",
 		Hidden:  true,
 		GroupID: groups.OtherCommandsGroup.ID,
 	}
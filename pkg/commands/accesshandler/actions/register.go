@@ -9,7 +9,7 @@ import (
 func Register() *cobra.Command {
 	return &cobra.Command{
 		Use:   "register",
-		Short: "Register the apono:// URL handler with macOS",
+		Short: "Register the apono:// URL handler with LaunchServices or xdg-mime",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return urihandler.Register(cmd.OutOrStdout())
 		},
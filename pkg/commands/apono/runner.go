@@ -13,11 +13,16 @@ import (
 	"github.com/apono-io/apono-cli/pkg/commands/accesshandler"
 	"github.com/apono-io/apono-cli/pkg/commands/auth"
 	"github.com/apono-io/apono-cli/pkg/commands/cliconfig"
+	"github.com/apono-io/apono-cli/pkg/commands/daemon"
+	"github.com/apono-io/apono-cli/pkg/commands/dev"
 	"github.com/apono-io/apono-cli/pkg/commands/integrations"
 	"github.com/apono-io/apono-cli/pkg/commands/mcp"
+	"github.com/apono-io/apono-cli/pkg/commands/plugin"
+	"github.com/apono-io/apono-cli/pkg/commands/prompt"
 	"github.com/apono-io/apono-cli/pkg/commands/requests"
 	"github.com/apono-io/apono-cli/pkg/commands/vault"
 	"github.com/apono-io/apono-cli/pkg/groups"
+	"github.com/apono-io/apono-cli/pkg/utils"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/cobra/doc"
@@ -25,6 +30,8 @@ import (
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
 )
 
+const promptCommandName = "prompt"
+
 func NewRunner(opts *RunnerOptions) (*Runner, error) {
 	r := &Runner{
 		rootCmd: createRootCommand(opts.VersionInfo),
@@ -38,6 +45,10 @@ func NewRunner(opts *RunnerOptions) (*Runner, error) {
 			&mcp.Configurator{},
 			&cliconfig.Configurator{},
 			&accesshandler.Configurator{},
+			&prompt.Configurator{},
+			&daemon.Configurator{},
+			&dev.Configurator{},
+			&plugin.Configurator{},
 		},
 	}
 	err := r.init()
@@ -58,7 +69,13 @@ type Runner struct {
 	configurators []Configurator
 }
 
+// Run executes the command of args. Plugins on PATH are added first, except
+// for `apono prompt`, which runs on every shell prompt and must stay fast.
 func (r *Runner) Run(ctx context.Context, args []string) error {
+	if !r.IsPromptCommand(args) {
+		plugin.AddPluginCommands(r.rootCmd)
+	}
+
 	r.rootCmd.SetArgs(args)
 	if err := r.rootCmd.ExecuteContext(ctx); err != nil {
 		if aponoapi.IsInvalidGrant(err) {
@@ -86,6 +103,22 @@ func (r *Runner) init() error {
 	return nil
 }
 
+// IsPromptCommand reports whether args run `apono prompt` or one of its
+// subcommands, also when root flags such as --profile come first.
+func (r *Runner) IsPromptCommand(args []string) bool {
+	cmd, _, err := r.rootCmd.Find(args)
+	if err != nil {
+		return false
+	}
+
+	for ; cmd.HasParent(); cmd = cmd.Parent() {
+		if cmd.Parent() == r.rootCmd {
+			return cmd.Name() == promptCommandName
+		}
+	}
+	return false
+}
+
 func (r *Runner) GenBashCompletionFile(filename string) error {
 	return r.rootCmd.GenBashCompletionFile(filename)
 }
@@ -132,15 +165,18 @@ func createRootCommand(versionInfo version.VersionInfo) *cobra.Command {
 	c.PersistentFlags().String("profile", "", "profile name")
 	c.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
 		profileName, _ := cmd.Flags().GetString("profile")
-		client, err := aponoapi.CreateClient(cmd.Context(), profileName)
-		if err != nil {
-			return err
+		// --all-profiles commands create a client for every profile themselves.
+		if allProfiles, _ := cmd.Flags().GetBool(utils.AllProfilesFlagName); !allProfiles {
+			client, err := aponoapi.CreateClient(cmd.Context(), profileName)
+			if err != nil {
+				return err
+			}
+			cmd.SetContext(aponoapi.CreateClientContext(cmd.Context(), client))
 		}
 
 		commandStartTime := time.Now()
 		commandID := analytics.GenerateCommandID()
 
-		cmd.SetContext(aponoapi.CreateClientContext(cmd.Context(), client))
 		cmd.SetContext(version.CreateVersionContext(cmd.Context(), &versionInfo))
 		cmd.SetContext(analytics.CreateStartTimeContext(cmd.Context(), &commandStartTime))
 		cmd.SetContext(analytics.CreateCommandIDContext(cmd.Context(), commandID))
@@ -0,0 +1,31 @@
+package apono
+
+import (
+	"testing"
+)
+
+func TestIsPromptCommand(t *testing.T) {
+	runner, err := NewRunner(&RunnerOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{args: []string{"prompt"}, want: true},
+		{args: []string{"prompt", "init", "starship"}, want: true},
+		{args: []string{"--profile", "prod", "prompt"}, want: true},
+		{args: []string{"--profile=prod", "prompt"}, want: true},
+		{args: nil, want: false},
+		{args: []string{"login"}, want: false},
+		{args: []string{"--profile", "prompt", "login"}, want: false},
+		{args: []string{"unknown-plugin", "prompt"}, want: false},
+	}
+	for _, tt := range tests {
+		if got := runner.IsPromptCommand(tt.args); got != tt.want {
+			t.Errorf("IsPromptCommand(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package actions
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/config"
+)
+
+func CopyProfile() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "copy NAME NEW_NAME",
+		Short:             "Copy a profile, including its tokens",
+		Aliases:           []string{"cp"},
+		Args:              cobra.ExactArgs(2),
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error { return nil },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.CopyProfile(config.ProfileName(args[0]), config.ProfileName(args[1])); err != nil {
+				return err
+			}
+
+			_, err := fmt.Fprintf(cmd.OutOrStdout(), "Profile %s copied to %s\n", args[0], args[1])
+			return err
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,29 @@
+package actions
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/config"
+)
+
+func DeleteProfile() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "delete NAME",
+		Short:             "Delete a profile and its tokens",
+		Aliases:           []string{"rm"},
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error { return nil },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.DeleteProfile(config.ProfileName(args[0])); err != nil {
+				return err
+			}
+
+			_, err := fmt.Fprintln(cmd.OutOrStdout(), "Deleted profile:", args[0])
+			return err
+		},
+	}
+
+	return cmd
+}
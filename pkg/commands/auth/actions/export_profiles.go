@@ -0,0 +1,67 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/config"
+)
+
+const exportFilePerm os.FileMode = 0o600
+
+func ExportProfiles() *cobra.Command {
+	var outputFile string
+	var redact string
+
+	cmd := &cobra.Command{
+		Use:   "export [NAME...]",
+		Short: "Export profiles to a portable file",
+		Long: `Export profiles, all of them when no name is given, to a JSON file that 'apono profiles import' reads on another machine.
+Tokens are left out by default. Use --redact none to include them, and keep the file private in that case.`,
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error { return nil },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			redaction, err := config.ParseTokenRedaction(redact)
+			if err != nil {
+				return err
+			}
+
+			names := make([]config.ProfileName, 0, len(args))
+			for _, arg := range args {
+				names = append(names, config.ProfileName(arg))
+			}
+
+			export, err := config.ExportProfiles(names, redaction)
+			if err != nil {
+				return err
+			}
+
+			data, err := json.MarshalIndent(export, "", "  ")
+			if err != nil {
+				return err
+			}
+			data = append(data, '\n')
+
+			if outputFile == "" {
+				_, err = cmd.OutOrStdout().Write(data)
+				return err
+			}
+
+			if err = os.WriteFile(filepath.Clean(outputFile), data, exportFilePerm); err != nil {
+				return fmt.Errorf("failed to write %s: %w", outputFile, err)
+			}
+			_, err = fmt.Fprintf(cmd.ErrOrStderr(), "Exported %d profiles to %s\n", len(export.Profiles), outputFile)
+			return err
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&outputFile, "file", "f", "", "The file to write, defaults to stdout")
+	flags.StringVar(&redact, "redact", config.RedactAllTokens, fmt.Sprintf("The tokens to leave out: '%s', '%s' (OAuth login tokens), '%s' (personal tokens) or '%s'",
+		config.RedactAllTokens, config.RedactOAuthTokens, config.RedactPersonalTokens, config.RedactNoTokens))
+
+	return cmd
+}
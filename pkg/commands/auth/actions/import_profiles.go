@@ -0,0 +1,62 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/config"
+)
+
+func ImportProfiles() *cobra.Command {
+	var overwrite bool
+
+	cmd := &cobra.Command{
+		Use:               "import FILE",
+		Short:             "Import profiles exported with 'apono profiles export', use - to read stdin",
+		Args:              cobra.ExactArgs(1),
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error { return nil },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var data []byte
+			var err error
+			if args[0] == "-" {
+				data, err = io.ReadAll(cmd.InOrStdin())
+			} else {
+				data, err = os.ReadFile(filepath.Clean(args[0]))
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", args[0], err)
+			}
+
+			export := new(config.ProfilesExport)
+			if err = json.Unmarshal(data, export); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", args[0], err)
+			}
+
+			names, err := config.ImportProfiles(export, overwrite)
+			if err != nil {
+				return err
+			}
+
+			for _, name := range names {
+				session := export.Profiles[name]
+				note := ""
+				if session.PersonalToken == "" && session.Token.RefreshToken == "" && session.Token.AccessToken == "" {
+					note = fmt.Sprintf(" (no token, run `apono login --profile %s`)", name)
+				}
+				if _, err = fmt.Fprintf(cmd.OutOrStdout(), "Imported profile %s%s\n", name, note); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&overwrite, "overwrite", false, "Replace existing profiles with the same name")
+
+	return cmd
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"net/url"
+	"os"
 	"strings"
 	"time"
 
@@ -30,8 +31,13 @@ const (
 	portalURLFlagName     = "portal-url"
 	tokenURLFlagName      = "token-url"
 	personalTokenFlagName = "personal-token"
+	deviceFlagName        = "device"
+	noBrowserFlagName     = "no-browser"
+	deviceAuthURLFlagName = "device-auth-url"
 )
 
+var loginCallbackAddresses = []string{"localhost:64131", "localhost:64132", "localhost:64133", "localhost:64134"}
+
 type loginCommandFlags struct {
 	profileName   string
 	verbose       bool
@@ -41,6 +47,9 @@ type loginCommandFlags struct {
 	portalURL     string
 	tokenURL      string
 	personalToken string
+	device        bool
+	noBrowser     bool
+	deviceAuthURL string
 }
 
 func Login() *cobra.Command {
@@ -91,52 +100,72 @@ func Login() *cobra.Command {
 				AuthCodeOptions:        pkce.AuthCodeOptions(),
 				TokenRequestOptions:    pkce.TokenRequestOptions(),
 				LocalServerReadyChan:   ready,
-				LocalServerBindAddress: []string{"localhost:64131", "localhost:64132", "localhost:64133", "localhost:64134"},
+				LocalServerBindAddress: loginCallbackAddresses,
 			}
 
 			if cmdFlags.verbose {
 				cfg.Logf = log.Printf
 			}
 
-			eg, ctx := errgroup.WithContext(cmd.Context())
-			if personalToken == "" {
-				eg.Go(func() error {
-					return loginViaBrowser(ready, cmdFlags, ctx)
-				})
-				eg.Go(func() error {
-					oauthToken, err := oauth2cli.GetToken(ctx, cfg)
-					if err != nil {
-						return fmt.Errorf("could not get a oauthToken: %w", err)
-					}
-					return storeAndLogProfileToken(cmdFlags.profileName, cmdFlags.clientID, apiURL, appURL, portalURL, oauthToken, "", ctx)
-				})
-				if err := eg.Wait(); err != nil {
-					return fmt.Errorf("authorization error: %s", err)
+			switch {
+			case personalToken != "":
+				return storeAndLogProfileToken(cmdFlags.profileName, cmdFlags.clientID, apiURL, appURL, portalURL, nil, personalToken, cmd.Context())
+			case cmdFlags.device:
+				deviceAuthURL := cmdFlags.deviceAuthURL
+				if deviceAuthURL == "" {
+					deviceAuthURL = config.GetOAuthDeviceAuthURL(oauthTokenURL)
 				}
-			} else {
-				if err := storeAndLogProfileToken(cmdFlags.profileName, cmdFlags.clientID, apiURL, appURL, portalURL, nil, personalToken, ctx); err != nil {
+				oauthToken, err := loginViaDevice(cmd.Context(), cmd.OutOrStdout(), cfg.OAuth2Config, deviceAuthURL)
+				if err != nil {
 					return err
 				}
+				return storeAndLogProfileToken(cmdFlags.profileName, cmdFlags.clientID, apiURL, appURL, portalURL, oauthToken, "", cmd.Context())
+			case cmdFlags.noBrowser:
+				oauthToken, err := loginViaPastedRedirect(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout(), cfg.OAuth2Config, pkce)
+				if err != nil {
+					return err
+				}
+				return storeAndLogProfileToken(cmdFlags.profileName, cmdFlags.clientID, apiURL, appURL, portalURL, oauthToken, "", cmd.Context())
+			}
+
+			eg, ctx := errgroup.WithContext(cmd.Context())
+			eg.Go(func() error {
+				return loginViaBrowser(ready, cmdFlags, ctx)
+			})
+			eg.Go(func() error {
+				oauthToken, err := oauth2cli.GetToken(ctx, cfg)
+				if err != nil {
+					return fmt.Errorf("could not get a oauthToken: %w", err)
+				}
+				return storeAndLogProfileToken(cmdFlags.profileName, cmdFlags.clientID, apiURL, appURL, portalURL, oauthToken, "", ctx)
+			})
+			if err := eg.Wait(); err != nil {
+				return fmt.Errorf("authorization error: %s", err)
 			}
 			return nil
 		},
 	}
 
 	flags := cmd.Flags()
-	flags.StringVarP(&cmdFlags.profileName, "profile", "p", "default", "profile name")
+	flags.StringVarP(&cmdFlags.profileName, "profile", "p", config.EnvOrDefault(config.ProfileEnvVar, "default"), "profile name")
 	flags.BoolVarP(&cmdFlags.verbose, "verbose", "v", false, "verbose output")
 	flags.StringVarP(&cmdFlags.clientID, clientIDFlagName, "", "3afae9ff-48e6-45f3-b0e8-37658b7271b7", "oauth client id")
-	flags.StringVarP(&cmdFlags.apiURL, apiURLFlagName, "", config.APIDefaultURL, "apono api url")
-	flags.StringVarP(&cmdFlags.appURL, appURLFlagName, "", config.AppDefaultURL, "apono app url")
-	flags.StringVarP(&cmdFlags.portalURL, portalURLFlagName, "", config.PortalDefaultURL, "apono portal url")
+	flags.StringVarP(&cmdFlags.apiURL, apiURLFlagName, "", config.EnvOrDefault(config.APIURLEnvVar, config.APIDefaultURL), "apono api url")
+	flags.StringVarP(&cmdFlags.appURL, appURLFlagName, "", config.EnvOrDefault(config.AppURLEnvVar, config.AppDefaultURL), "apono app url")
+	flags.StringVarP(&cmdFlags.portalURL, portalURLFlagName, "", config.EnvOrDefault(config.PortalURLEnvVar, config.PortalDefaultURL), "apono portal url")
 	flags.StringVarP(&cmdFlags.tokenURL, tokenURLFlagName, "", "", "apono token api url")
 	flags.StringVarP(&cmdFlags.personalToken, personalTokenFlagName, "", "", "Log in to Apono with user personal token")
+	flags.BoolVar(&cmdFlags.device, deviceFlagName, false, "Log in with a code entered in a browser on any device, for SSH hosts and containers")
+	flags.BoolVar(&cmdFlags.noBrowser, noBrowserFlagName, false, "Print the login URL instead of opening a browser, and paste back the URL the browser is redirected to")
+	flags.StringVar(&cmdFlags.deviceAuthURL, deviceAuthURLFlagName, "", "apono device authorization url")
+	cmd.MarkFlagsMutuallyExclusive(personalTokenFlagName, deviceFlagName, noBrowserFlagName)
 
 	_ = flags.MarkHidden(clientIDFlagName)
 	_ = flags.MarkHidden(apiURLFlagName)
 	_ = flags.MarkHidden(appURLFlagName)
 	_ = flags.MarkHidden(portalURLFlagName)
 	_ = flags.MarkHidden(tokenURLFlagName)
+	_ = flags.MarkHidden(deviceAuthURLFlagName)
 	return cmd
 }
 
@@ -161,6 +190,10 @@ func storeAndLogProfileToken(profileName, clientID, apiURL, appURL, portalURL st
 		return fmt.Errorf("could not store access oauthToken: %w", err)
 	}
 
+	if session.SecretsBackend == "" && config.ConfiguredSecretsBackend() == "" {
+		fmt.Fprintf(os.Stderr, "Warning: no OS keyring is available, so your token is stored unencrypted in the CLI config. Set %s or secrets.key_file to encrypt it, or run `apono config set secrets.backend plaintext` to keep it as is.\n", config.SecretsPassphraseEnvVar)
+	}
+
 	if session.AccountName != "" && session.UserEmail != "" {
 		fmt.Printf("You successfully logged in to %s as %s (%s)\n",
 			session.AccountName, session.UserName, session.UserEmail)
@@ -172,15 +205,8 @@ func storeAndLogProfileToken(profileName, clientID, apiURL, appURL, portalURL st
 }
 
 func storeProfileToken(profileName, clientID, apiURL, appURL, portalURL string, oauthToken *oauth2.Token, personalToken string, ctx context.Context) (*config.SessionConfig, error) {
-	cfg, err := config.Get()
-	if err != nil {
-		return nil, err
-	}
-
 	pn := config.ProfileName(profileName)
-	if cfg.Auth.ActiveProfile == "" {
-		cfg.Auth.ActiveProfile = pn
-	}
+	var err error
 
 	type aponoClaims struct {
 		AuthorizationID string   `json:"authorization_id"`
@@ -235,10 +261,6 @@ func storeProfileToken(profileName, clientID, apiURL, appURL, portalURL string,
 		userEmail = userSession.User.Email
 	}
 
-	if cfg.Auth.Profiles == nil {
-		cfg.Auth.Profiles = make(map[config.ProfileName]config.SessionConfig)
-	}
-
 	session := config.SessionConfig{
 		ClientID:      clientID,
 		ApiURL:        apiURL,
@@ -255,9 +277,25 @@ func storeProfileToken(profileName, clientID, apiURL, appURL, portalURL string,
 	if oauthToken != nil {
 		session.Token = *oauthToken
 	}
-	cfg.Auth.Profiles[pn] = session
 
-	err = config.Save(cfg)
+	err = config.Update(func(cfg *config.Config) error {
+		if cfg.Auth.ActiveProfile == "" {
+			cfg.Auth.ActiveProfile = pn
+		}
+		if cfg.Auth.Profiles == nil {
+			cfg.Auth.Profiles = make(map[config.ProfileName]config.SessionConfig)
+		}
+
+		if existing, exists := cfg.Auth.Profiles[pn]; exists {
+			_ = config.DeleteProfileSecrets(pn, existing)
+		}
+		backend := config.SecretsBackend()
+		if storeErr := config.StoreProfileSecrets(pn, &session, backend); storeErr != nil {
+			return fmt.Errorf("failed to store the profile secrets in the %s backend: %w", backend, storeErr)
+		}
+		cfg.Auth.Profiles[pn] = session
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,156 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	deviceCodeGrantType         = "urn:ietf:params:oauth:grant-type:device_code"
+	defaultDevicePollInterval   = 5 * time.Second
+	devicePollSlowDownIncrement = 5 * time.Second
+)
+
+// deviceAuthorization is the response of the device authorization endpoint,
+// as defined by RFC 8628.
+type deviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+type deviceTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type"`
+	RefreshToken     string `json:"refresh_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// loginViaDevice runs the OAuth device authorization grant: the user opens the
+// verification URL on any device and enters the code, while the CLI polls the
+// token endpoint. It needs neither a browser nor a local callback server.
+func loginViaDevice(ctx context.Context, out io.Writer, cfg oauth2.Config, deviceAuthURL string) (*oauth2.Token, error) {
+	auth, err := requestDeviceAuthorization(ctx, cfg, deviceAuthURL)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _ = fmt.Fprintf(out, "To log in, open %s in a browser on any device and enter the code: %s\n", auth.VerificationURI, auth.UserCode)
+	if auth.VerificationURIComplete != "" {
+		_, _ = fmt.Fprintln(out, "Or open this URL, which already includes the code:", auth.VerificationURIComplete)
+	}
+	_, _ = fmt.Fprintln(out, "Waiting for the login to complete...")
+
+	interval := time.Duration(auth.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultDevicePollInterval
+	}
+	deadline := time.Now().Add(time.Duration(auth.ExpiresIn) * time.Second)
+
+	return pollDeviceToken(ctx, cfg, auth.DeviceCode, interval, deadline)
+}
+
+func requestDeviceAuthorization(ctx context.Context, cfg oauth2.Config, deviceAuthURL string) (*deviceAuthorization, error) {
+	form := url.Values{
+		"client_id": {cfg.ClientID},
+		"scope":     {strings.Join(cfg.Scopes, " ")},
+	}
+
+	auth := new(deviceAuthorization)
+	status, err := postForm(ctx, deviceAuthURL, form, auth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start device login: %w", err)
+	}
+	if status != http.StatusOK || auth.DeviceCode == "" || auth.UserCode == "" || auth.VerificationURI == "" {
+		return nil, fmt.Errorf("failed to start device login: unexpected response from %s (HTTP %d)", deviceAuthURL, status)
+	}
+
+	return auth, nil
+}
+
+func pollDeviceToken(ctx context.Context, cfg oauth2.Config, deviceCode string, interval time.Duration, deadline time.Time) (*oauth2.Token, error) {
+	form := url.Values{
+		"grant_type":  {deviceCodeGrantType},
+		"device_code": {deviceCode},
+		"client_id":   {cfg.ClientID},
+	}
+
+	for {
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("the device code expired before the login was completed, run `apono login --device` again")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		resp := new(deviceTokenResponse)
+		status, err := postForm(ctx, cfg.Endpoint.TokenURL, form, resp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to poll for the login token: %w", err)
+		}
+
+		switch resp.Error {
+		case "":
+			if status != http.StatusOK || resp.AccessToken == "" {
+				return nil, fmt.Errorf("unexpected token response (HTTP %d)", status)
+			}
+			token := &oauth2.Token{
+				AccessToken:  resp.AccessToken,
+				TokenType:    resp.TokenType,
+				RefreshToken: resp.RefreshToken,
+			}
+			if resp.ExpiresIn > 0 {
+				token.Expiry = time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+			}
+			return token, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += devicePollSlowDownIncrement
+		case "access_denied":
+			return nil, fmt.Errorf("the login was denied")
+		case "expired_token":
+			return nil, fmt.Errorf("the device code expired before the login was completed, run `apono login --device` again")
+		default:
+			if resp.ErrorDescription != "" {
+				return nil, fmt.Errorf("login failed: %s: %s", resp.Error, resp.ErrorDescription)
+			}
+			return nil, fmt.Errorf("login failed: %s", resp.Error)
+		}
+	}
+}
+
+func postForm(ctx context.Context, endpoint string, form url.Values, result interface{}) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err = json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to parse response of %s (HTTP %d): %w", endpoint, resp.StatusCode, err)
+	}
+	return resp.StatusCode, nil
+}
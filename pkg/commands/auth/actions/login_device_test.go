@@ -0,0 +1,76 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+func TestLoginViaDevice(t *testing.T) {
+	polls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/device/code", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.Form.Get("client_id") != "cli" || r.Form.Get("scope") != "a b" {
+			t.Errorf("device code request form = %v", r.Form)
+		}
+		_ = json.NewEncoder(w).Encode(deviceAuthorization{
+			DeviceCode:      "device-code",
+			UserCode:        "ABCD-EFGH",
+			VerificationURI: "https://app.example.com/device",
+			ExpiresIn:       60,
+		})
+	})
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.Form.Get("grant_type") != deviceCodeGrantType || r.Form.Get("device_code") != "device-code" {
+			t.Errorf("token request form = %v", r.Form)
+		}
+		polls++
+		if polls == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(deviceTokenResponse{Error: "authorization_pending"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(deviceTokenResponse{AccessToken: "access", RefreshToken: "refresh", TokenType: "Bearer", ExpiresIn: 3600})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cfg := oauth2.Config{ClientID: "cli", Scopes: []string{"a", "b"}, Endpoint: oauth2.Endpoint{TokenURL: server.URL + "/oauth/token"}}
+	auth, err := requestDeviceAuthorization(context.Background(), cfg, server.URL+"/oauth/device/code")
+	if err != nil {
+		t.Fatalf("requestDeviceAuthorization() error = %v", err)
+	}
+
+	token, err := pollDeviceToken(context.Background(), cfg, auth.DeviceCode, time.Millisecond, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("pollDeviceToken() error = %v", err)
+	}
+	if token.AccessToken != "access" || token.RefreshToken != "refresh" || token.Expiry.IsZero() {
+		t.Errorf("pollDeviceToken() = %+v, want the issued token", token)
+	}
+	if polls != 2 {
+		t.Errorf("polled %d times, want 2", polls)
+	}
+}
+
+func TestPollDeviceTokenDenied(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(deviceTokenResponse{Error: "access_denied"})
+	}))
+	defer server.Close()
+
+	cfg := oauth2.Config{Endpoint: oauth2.Endpoint{TokenURL: server.URL}}
+	_, err := pollDeviceToken(context.Background(), cfg, "device-code", time.Millisecond, time.Now().Add(time.Minute))
+	if err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Errorf("pollDeviceToken() error = %v, want a denied error", err)
+	}
+}
@@ -0,0 +1,70 @@
+package actions
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/int128/oauth2cli/oauth2params"
+	"golang.org/x/oauth2"
+)
+
+// loginViaPastedRedirect runs the authorization code flow without a local
+// callback server. The user opens the printed URL in any browser, and pastes
+// back the localhost URL the browser is redirected to, which fails to load
+// when the CLI runs on another machine.
+func loginViaPastedRedirect(ctx context.Context, in io.Reader, out io.Writer, cfg oauth2.Config, pkce *oauth2params.PKCE) (*oauth2.Token, error) {
+	state, err := oauth2params.NewState()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create state: %w", err)
+	}
+
+	cfg.RedirectURL = "http://" + loginCallbackAddresses[0]
+	_, _ = fmt.Fprintln(out, "Open this URL in a browser on any machine to log in:")
+	_, _ = fmt.Fprintln(out, cfg.AuthCodeURL(state, pkce.AuthCodeOptions()...))
+	_, _ = fmt.Fprintln(out)
+	_, _ = fmt.Fprintf(out, "After logging in, the browser is redirected to %s, which may fail to load. Paste the full URL from the address bar here: ", cfg.RedirectURL)
+
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && line == "" {
+		return nil, fmt.Errorf("failed to read the redirect URL: %w", err)
+	}
+
+	code, err := parseLoginRedirectURL(strings.TrimSpace(line), state)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := cfg.Exchange(ctx, code, pkce.TokenRequestOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get a oauthToken: %w", err)
+	}
+	return token, nil
+}
+
+func parseLoginRedirectURL(redirectURL, state string) (string, error) {
+	parsed, err := url.Parse(redirectURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid redirect URL: %w", err)
+	}
+
+	query := parsed.Query()
+	if errCode := query.Get("error"); errCode != "" {
+		if description := query.Get("error_description"); description != "" {
+			return "", fmt.Errorf("authorization error: %s: %s", errCode, description)
+		}
+		return "", fmt.Errorf("authorization error: %s", errCode)
+	}
+	if query.Get("state") != state {
+		return "", fmt.Errorf("the redirect URL does not belong to this login, paste the URL of the latest login attempt")
+	}
+
+	code := query.Get("code")
+	if code == "" {
+		return "", fmt.Errorf("the redirect URL has no authorization code")
+	}
+	return code, nil
+}
@@ -0,0 +1,26 @@
+package actions
+
+import "testing"
+
+func TestParseLoginRedirectURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		wantCode string
+		wantErr  bool
+	}{
+		{name: "code", url: "http://localhost:64131/?code=abc&state=s1", wantCode: "abc"},
+		{name: "other state", url: "http://localhost:64131/?code=abc&state=s2", wantErr: true},
+		{name: "error", url: "http://localhost:64131/?error=access_denied&state=s1", wantErr: true},
+		{name: "no code", url: "http://localhost:64131/?state=s1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := parseLoginRedirectURL(tt.url, "s1")
+			if (err != nil) != tt.wantErr || code != tt.wantCode {
+				t.Errorf("parseLoginRedirectURL() = %q, %v, want %q, error %v", code, err, tt.wantCode, tt.wantErr)
+			}
+		})
+	}
+}
@@ -5,8 +5,6 @@ import (
 
 	"github.com/apono-io/apono-cli/pkg/groups"
 
-	"github.com/apono-io/apono-cli/pkg/aponoapi"
-
 	"github.com/spf13/cobra"
 
 	"github.com/apono-io/apono-cli/pkg/config"
@@ -20,33 +18,26 @@ func Logout() *cobra.Command {
 		Args:              cobra.MaximumNArgs(1),
 		PersistentPreRunE: func(_ *cobra.Command, args []string) error { return nil },
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Get()
-			if err != nil {
-				return err
-			}
-
-			authConfig := &cfg.Auth
-			profileName := authConfig.ActiveProfile
+			var profileName config.ProfileName
 			if len(args) > 0 {
 				profileName = config.ProfileName(args[0])
+			} else {
+				cfg, err := config.Get()
+				if err != nil {
+					return err
+				}
+				if cfg.Auth.ActiveProfile == "" {
+					return config.ErrNoProfiles
+				}
+				profileName = cfg.Auth.ActiveProfile
 			}
 
-			if authConfig.Profiles == nil {
-				return aponoapi.ErrProfileNotExists
-			}
-
-			if _, exists := authConfig.Profiles[profileName]; !exists {
-				return aponoapi.ErrProfileNotExists
-			}
-
-			delete(authConfig.Profiles, profileName)
-
-			_, err = fmt.Fprintln(cmd.OutOrStdout(), "Logging out profile:", profileName)
-			if err != nil {
+			if err := config.DeleteProfile(profileName); err != nil {
 				return err
 			}
 
-			return config.Save(cfg)
+			_, err := fmt.Fprintln(cmd.OutOrStdout(), "Logging out profile:", profileName)
+			return err
 		},
 	}
 
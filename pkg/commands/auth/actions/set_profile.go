@@ -17,28 +17,26 @@ func SetProfile() *cobra.Command {
 		Args:              cobra.ExactArgs(1),
 		PersistentPreRunE: func(_ *cobra.Command, _ []string) error { return nil },
 		RunE: func(cmd *cobra.Command, args []string) error {
-			cfg, err := config.Get()
-			if err != nil {
-				return err
-			}
-
 			pn := config.ProfileName(args[0])
-			authConfig := &cfg.Auth
-			if authConfig.Profiles == nil {
-				return aponoapi.ErrProfileNotExists
-			}
-
-			if _, exists := authConfig.Profiles[pn]; !exists {
-				return aponoapi.ErrProfileNotExists
-			}
-
-			authConfig.ActiveProfile = pn
-			_, err = fmt.Fprintln(cmd.OutOrStdout(), "Setting active profile to:", pn)
+			err := config.Update(func(cfg *config.Config) error {
+				authConfig := &cfg.Auth
+				if authConfig.Profiles == nil {
+					return aponoapi.ErrProfileNotExists
+				}
+
+				if _, exists := authConfig.Profiles[pn]; !exists {
+					return aponoapi.ErrProfileNotExists
+				}
+
+				authConfig.ActiveProfile = pn
+				return nil
+			})
 			if err != nil {
 				return err
 			}
 
-			return config.Save(cfg)
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), "Setting active profile to:", pn)
+			return err
 		},
 	}
 
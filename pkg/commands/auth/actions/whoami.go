@@ -0,0 +1,99 @@
+package actions
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/groups"
+	"github.com/apono-io/apono-cli/pkg/services"
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+func WhoAmI() *cobra.Command {
+	format := new(utils.Format)
+
+	cmd := &cobra.Command{
+		Use:     "whoami",
+		Aliases: []string{"status"},
+		Short:   "Show the profile, account and user the CLI is authenticated as",
+		Long: `Show the profile, account and user the CLI is authenticated as, the token type, expiry and scopes.
+The token is verified with Apono, and the command exits with status 1 when it is not valid, for use in scripts.`,
+		GroupID:           groups.AuthCommandsGroup.ID,
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error { return nil },
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			profileName, _ := cmd.Flags().GetString("profile")
+			status := services.GetAuthStatus(cmd.Context(), profileName)
+
+			table := utils.NewTable(
+				utils.Column("PROFILE"),
+				utils.Column("ACCOUNT"),
+				utils.Column("USER"),
+				utils.Column("AUTH"),
+				utils.Column("EXPIRES"),
+				utils.Column("STATUS"),
+				utils.WideColumn("SCOPES"),
+				utils.WideColumn("API URL"),
+			)
+			table.AddRow(
+				authStatusProfile(status),
+				firstNonEmpty(status.AccountName, status.AccountID),
+				firstNonEmpty(status.UserEmail, status.UserID),
+				status.AuthType,
+				authStatusExpiry(status),
+				authStatusState(status),
+				strings.Join(status.Scopes, ","),
+				status.APIURL,
+			)
+
+			if err := utils.PrintObjects(cmd.OutOrStdout(), *format, status, table); err != nil {
+				return err
+			}
+
+			if !status.Authenticated {
+				return &utils.ExitCodeError{Code: 1}
+			}
+			return nil
+		},
+	}
+
+	utils.AddFormatFlag(cmd.Flags(), format)
+
+	return cmd
+}
+
+func authStatusProfile(status *services.AuthStatus) string {
+	if status.Profile == "" && status.FromEnvironment {
+		return "(environment)"
+	}
+	return status.Profile
+}
+
+func authStatusExpiry(status *services.AuthStatus) string {
+	if status.ExpiresAt == nil {
+		return ""
+	}
+	expiry := status.ExpiresAt.Local().Format(time.DateTime)
+	if status.Refreshable {
+		expiry += " (auto refresh)"
+	}
+	return expiry
+}
+
+func authStatusState(status *services.AuthStatus) string {
+	if status.Authenticated {
+		return "Authenticated"
+	}
+	return "Not authenticated: " + status.Error
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -16,9 +16,15 @@ func (c *Configurator) ConfigureCommands(rootCmd *cobra.Command) error {
 
 	rootCmd.AddCommand(actions.Login())
 	rootCmd.AddCommand(actions.Logout())
+	rootCmd.AddCommand(actions.WhoAmI())
 	rootCmd.AddCommand(profilesCommand)
 
 	profilesCommand.AddCommand(actions.GetProfiles())
 	profilesCommand.AddCommand(actions.SetProfile())
+	profilesCommand.AddCommand(actions.RenameProfile())
+	profilesCommand.AddCommand(actions.CopyProfile())
+	profilesCommand.AddCommand(actions.DeleteProfile())
+	profilesCommand.AddCommand(actions.ExportProfiles())
+	profilesCommand.AddCommand(actions.ImportProfiles())
 	return nil
 }
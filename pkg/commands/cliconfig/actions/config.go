@@ -2,16 +2,29 @@ package actions
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/apono-io/apono-cli/pkg/config"
+	"github.com/apono-io/apono-cli/pkg/connect"
+	"github.com/apono-io/apono-cli/pkg/daemon"
 	"github.com/apono-io/apono-cli/pkg/groups"
+	"github.com/apono-io/apono-cli/pkg/secrets"
 )
 
 const (
 	keyNotificationsFeatureAnnouncements = "notifications.feature_announcements"
+	keyMultiplexerMode                   = "multiplexer.mode"
+	keyMultiplexerOnExpiry               = "multiplexer.on_expiry"
+	keyDaemonExpiryWarning               = "daemon.expiry_warning"
+	keyDaemonDesktopNotifications        = "daemon.desktop_notifications"
+	keySecretsBackend                    = "secrets.backend"
+	keySecretsKeyFile                    = "secrets.key_file"
 )
 
 func Config() *cobra.Command {
@@ -44,6 +57,108 @@ var configHandlers = map[string]configHandler{
 			return fmt.Sprintf("%t", config.IsFeatureAnnouncementNotificationsEnabled()), nil
 		},
 	},
+	keyMultiplexerMode: {
+		description: "inline/window/split - how sessions open inside tmux or screen",
+		apply: func(value string) error {
+			mode, err := connect.ParseMultiplexerMode(value)
+			if err != nil {
+				return err
+			}
+			multiplexer := config.GetMultiplexerConfig()
+			multiplexer.Mode = mode
+			return config.SetMultiplexerConfig(multiplexer)
+		},
+		get: func() (string, error) {
+			if mode := config.GetMultiplexerConfig().Mode; mode != "" {
+				return mode, nil
+			}
+			return connect.MultiplexerModeInline, nil
+		},
+	},
+	keyMultiplexerOnExpiry: {
+		description: "mark/close - what happens to the tmux or screen window when the session expires",
+		apply: func(value string) error {
+			onExpiry, err := connect.ParseMultiplexerOnExpiry(value)
+			if err != nil {
+				return err
+			}
+			multiplexer := config.GetMultiplexerConfig()
+			multiplexer.OnExpiry = onExpiry
+			return config.SetMultiplexerConfig(multiplexer)
+		},
+		get: func() (string, error) {
+			if onExpiry := config.GetMultiplexerConfig().OnExpiry; onExpiry != "" {
+				return onExpiry, nil
+			}
+			return connect.MultiplexerOnExpiryMark, nil
+		},
+	},
+	keyDaemonExpiryWarning: {
+		description: "duration, e.g. 15m - how long before access expires `apono daemon` warns",
+		apply: func(value string) error {
+			warning, err := time.ParseDuration(value)
+			if err != nil || warning <= 0 {
+				return fmt.Errorf("invalid value for %s: %s (expected a positive duration such as 15m)", keyDaemonExpiryWarning, value)
+			}
+			daemonConfig := config.GetDaemonConfig()
+			daemonConfig.ExpiryWarning = warning.String()
+			return config.SetDaemonConfig(daemonConfig)
+		},
+		get: func() (string, error) {
+			if warning := config.GetDaemonConfig().ExpiryWarning; warning != "" {
+				return warning, nil
+			}
+			return daemon.DefaultExpiryWarning.String(), nil
+		},
+	},
+	keyDaemonDesktopNotifications: {
+		description: "true/false - enable or disable the desktop notifications of `apono daemon`",
+		apply: func(value string) error {
+			if value != "true" && value != "false" {
+				return fmt.Errorf("invalid value for %s: %s (expected true or false)", keyDaemonDesktopNotifications, value)
+			}
+			enabled := value == "true"
+			daemonConfig := config.GetDaemonConfig()
+			daemonConfig.DesktopNotifications = &enabled
+			return config.SetDaemonConfig(daemonConfig)
+		},
+		get: func() (string, error) {
+			return fmt.Sprintf("%t", config.IsDaemonDesktopNotificationsEnabled()), nil
+		},
+	},
+	keySecretsBackend: {
+		description: "keyring/file/plaintext - where new tokens and cached credentials are stored, move existing ones with `apono config secrets migrate`",
+		apply: func(value string) error {
+			backend, err := secrets.ParseBackend(value)
+			if err != nil {
+				return err
+			}
+			secretsConfig := config.GetSecretsConfig()
+			secretsConfig.Backend = backend
+			return config.SetSecretsConfig(secretsConfig)
+		},
+		get: func() (string, error) {
+			return config.SecretsBackend(), nil
+		},
+	},
+	keySecretsKeyFile: {
+		description: "path - the key file that unlocks the file secrets backend instead of a passphrase",
+		apply: func(value string) error {
+			keyFile, err := filepath.Abs(value)
+			if err != nil {
+				return err
+			}
+			if _, err = os.Stat(keyFile); err != nil {
+				return fmt.Errorf("invalid value for %s: %w", keySecretsKeyFile, err)
+			}
+			secretsConfig := config.GetSecretsConfig()
+			secretsConfig.KeyFile = keyFile
+			return config.SetSecretsConfig(secretsConfig)
+		},
+		get: func() (string, error) {
+			return config.GetSecretsConfig().KeyFile, nil
+		},
+	},
 }
 
 func supportedKeysDescription() string {
@@ -51,5 +166,6 @@ func supportedKeysDescription() string {
 	for key, handler := range configHandlers {
 		parts = append(parts, fmt.Sprintf("  %s (%s)", key, handler.description))
 	}
+	sort.Strings(parts)
 	return strings.Join(parts, "\n")
 }
@@ -0,0 +1,104 @@
+package actions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/config"
+	"github.com/apono-io/apono-cli/pkg/daemon"
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+func Hooks() *cobra.Command {
+	return &cobra.Command{
+		Use:     "hooks",
+		Aliases: []string{"hook"},
+		Short:   "Manage the hooks `apono daemon` runs on access events",
+		Long: fmt.Sprintf(`Manage the hooks 'apono daemon' runs on access events.
+Hooks run with sh and receive the event as JSON on stdin, and its type and request id in APONO_EVENT and APONO_REQUEST_ID. Events: %s.
+
+  apono config hooks add log-expiry --event expiring,expired --command 'jq -r .message | logger -t apono'`, strings.Join(daemon.EventTypes, ", ")),
+	}
+}
+
+func HooksList() *cobra.Command {
+	format := new(utils.Format)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the hooks",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			hooks := config.GetDaemonConfig().Hooks
+
+			table := utils.NewTable(
+				utils.Column("NAME"),
+				utils.Column("EVENTS"),
+				utils.Column("COMMAND"),
+			)
+			for _, hook := range hooks {
+				table.AddRow(hook.Name, strings.Join(hook.Events, ", "), hook.Command)
+			}
+
+			return utils.PrintObjects(cmd.OutOrStdout(), *format, hooks, table)
+		},
+	}
+
+	utils.AddFormatFlag(cmd.Flags(), format)
+
+	return cmd
+}
+
+func HooksAdd() *cobra.Command {
+	var hook config.HookConfig
+
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Add or replace a hook",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			hook.Name = args[0]
+			for _, event := range hook.Events {
+				if _, err := daemon.ParseEventType(event); err != nil {
+					return err
+				}
+			}
+			if strings.TrimSpace(hook.Command) == "" {
+				return fmt.Errorf("hook command is required")
+			}
+
+			if err := config.SaveHook(hook); err != nil {
+				return err
+			}
+
+			_, err := fmt.Fprintf(cmd.OutOrStdout(), "Hook %q saved\n", hook.Name)
+			return err
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVar(&hook.Events, "event", nil, fmt.Sprintf("The events that trigger the hook: %s", strings.Join(daemon.EventTypes, ", ")))
+	flags.StringVar(&hook.Command, "command", "", "The shell command to run, the event JSON is passed on stdin")
+	_ = cmd.MarkFlagRequired("event")
+	_ = cmd.MarkFlagRequired("command")
+
+	return cmd
+}
+
+func HooksRemove() *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove <name>",
+		Aliases: []string{"rm"},
+		Short:   "Remove a hook",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.RemoveHook(args[0]); err != nil {
+				return err
+			}
+
+			_, err := fmt.Fprintf(cmd.OutOrStdout(), "Hook %q removed\n", args[0])
+			return err
+		},
+	}
+}
@@ -0,0 +1,108 @@
+package actions
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/config"
+	"github.com/apono-io/apono-cli/pkg/connect"
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+func Launchers() *cobra.Command {
+	return &cobra.Command{
+		Use:     "launchers",
+		Aliases: []string{"launcher"},
+		Short:   "Manage local launchers for `apono access use --client`",
+		Long: `Manage local launchers for 'apono access use --client'.
+Local launchers are offered next to the launchers provided by Apono, and replace a provided launcher with the same id.
+Commands are Go templates over the JSON access details of the session, for example:
+
+  apono config launchers add pgcli --kind TUI --command 'pgcli postgres://{{.username}}:__APONO_PASSWORD__@{{.host}}:{{.port}}/{{.database}}' --password-encoding url
+
+The __APONO_PASSWORD__ placeholder is replaced with the session password, URL encoded when --password-encoding is url.
+Use {{shellquote .field}} to quote a value for the shell.`,
+	}
+}
+
+func LaunchersList() *cobra.Command {
+	format := new(utils.Format)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the local launchers",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			launchers := config.GetLaunchers()
+
+			table := utils.NewTable(
+				utils.Column("ID"),
+				utils.Column("NAME"),
+				utils.Column("KIND"),
+				utils.Column("COMMAND"),
+				utils.WideColumn("AUTH COMMAND"),
+				utils.WideColumn("PASSWORD ENCODING"),
+			)
+			for _, launcher := range launchers {
+				table.AddRow(launcher.ID, launcher.DisplayName, launcher.Kind, launcher.Command, launcher.AuthCommand, launcher.PasswordEncoding)
+			}
+
+			return utils.PrintObjects(cmd.OutOrStdout(), *format, launchers, table)
+		},
+	}
+
+	utils.AddFormatFlag(cmd.Flags(), format)
+
+	return cmd
+}
+
+func LaunchersAdd() *cobra.Command {
+	var launcher config.LauncherConfig
+
+	cmd := &cobra.Command{
+		Use:   "add <id>",
+		Short: "Add or replace a local launcher",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			launcher.ID = args[0]
+			if err := connect.ValidateLocalLauncher(launcher); err != nil {
+				return err
+			}
+
+			if err := config.SaveLauncher(launcher); err != nil {
+				return err
+			}
+
+			_, err := fmt.Fprintf(cmd.OutOrStdout(), "Launcher %q saved\n", launcher.ID)
+			return err
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&launcher.Kind, "kind", "", fmt.Sprintf("The launcher kind: %s, %s or %s", connect.ClientKindGUI, connect.ClientKindTUI, connect.ClientKindCLI))
+	flags.StringVar(&launcher.Command, "command", "", "The command template that opens the client")
+	flags.StringVar(&launcher.AuthCommand, "auth-command", "", "A command template to run before the client command")
+	flags.StringVar(&launcher.DisplayName, "display-name", "", "The name shown when selecting a client, defaults to the id")
+	flags.StringVar(&launcher.PasswordEncoding, "password-encoding", "", "How to encode the __APONO_PASSWORD__ placeholder, 'url' or empty for none")
+	_ = cmd.MarkFlagRequired("kind")
+	_ = cmd.MarkFlagRequired("command")
+
+	return cmd
+}
+
+func LaunchersRemove() *cobra.Command {
+	return &cobra.Command{
+		Use:     "remove <id>",
+		Aliases: []string{"rm"},
+		Short:   "Remove a local launcher",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.RemoveLauncher(args[0]); err != nil {
+				return err
+			}
+
+			_, err := fmt.Fprintf(cmd.OutOrStdout(), "Launcher %q removed\n", args[0])
+			return err
+		},
+	}
+}
@@ -0,0 +1,71 @@
+package actions
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/config"
+	"github.com/apono-io/apono-cli/pkg/secrets"
+	"github.com/apono-io/apono-cli/pkg/services"
+)
+
+func Secrets() *cobra.Command {
+	return &cobra.Command{
+		Use:   "secrets",
+		Short: "Manage where tokens and cached credentials are stored",
+		Long: fmt.Sprintf(`Manage where tokens and cached credentials are stored. Backends:
+  %s    the OS keyring: the Secret Service (GNOME Keyring, KWallet) on Linux or the keychain on macOS
+  %s       an AES-GCM encrypted file, unlocked with %s or the key file set in secrets.key_file
+  %s  the config and cache files, unencrypted
+
+Without a configured backend the keyring is used when available, the encrypted file when %s or secrets.key_file is set, and plaintext otherwise.`,
+			secrets.BackendKeyring, secrets.BackendFile, config.SecretsPassphraseEnvVar, secrets.BackendPlaintext, config.SecretsPassphraseEnvVar),
+	}
+}
+
+func SecretsMigrate() *cobra.Command {
+	var backend string
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Move existing tokens and cached credentials to a secrets backend",
+		Long: `Move the tokens of all profiles and the cached vault credentials and session passwords to a secrets backend, and use it from now on.
+Defaults to the configured backend, or the OS keyring when none is configured.`,
+		Example: "  APONO_SECRETS_PASSPHRASE=... apono config secrets migrate --backend file",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if backend == "" {
+				backend = config.ConfiguredSecretsBackend()
+			}
+			if backend == "" {
+				backend = secrets.BackendKeyring
+			}
+			if _, err := secrets.ParseBackend(backend); err != nil {
+				return err
+			}
+
+			result, err := services.MigrateSecrets(backend)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			for _, profile := range result.Profiles {
+				_, _ = fmt.Fprintf(out, "Moved the tokens of profile %s\n", profile)
+			}
+			if result.VaultCredentials > 0 || result.SessionPasswords > 0 {
+				_, _ = fmt.Fprintf(out, "Moved %d cached vault credentials and %d cached session passwords\n", result.VaultCredentials, result.SessionPasswords)
+			}
+			if backend == secrets.BackendPlaintext {
+				_, _ = fmt.Fprintln(out, "Cached credentials are not moved back to plaintext; reset them with `apono access reset-credentials` if needed")
+			}
+			_, err = fmt.Fprintf(out, "Secrets are now stored in the %s backend\n", backend)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&backend, "backend", "", fmt.Sprintf("The backend to move secrets to: %s, %s or %s", secrets.BackendKeyring, secrets.BackendFile, secrets.BackendPlaintext))
+
+	return cmd
+}
@@ -15,5 +15,21 @@ func (c *Configurator) ConfigureCommands(rootCmd *cobra.Command) error {
 	configCmd.AddCommand(actions.ConfigSet())
 	configCmd.AddCommand(actions.ConfigGet())
 
+	launchersCmd := actions.Launchers()
+	configCmd.AddCommand(launchersCmd)
+	launchersCmd.AddCommand(actions.LaunchersList())
+	launchersCmd.AddCommand(actions.LaunchersAdd())
+	launchersCmd.AddCommand(actions.LaunchersRemove())
+
+	hooksCmd := actions.Hooks()
+	configCmd.AddCommand(hooksCmd)
+	hooksCmd.AddCommand(actions.HooksList())
+	hooksCmd.AddCommand(actions.HooksAdd())
+	hooksCmd.AddCommand(actions.HooksRemove())
+
+	secretsCmd := actions.Secrets()
+	configCmd.AddCommand(secretsCmd)
+	secretsCmd.AddCommand(actions.SecretsMigrate())
+
 	return nil
 }
@@ -0,0 +1,72 @@
+package actions
+
+import (
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/config"
+	"github.com/apono-io/apono-cli/pkg/daemon"
+	"github.com/apono-io/apono-cli/pkg/groups"
+)
+
+func Daemon() *cobra.Command {
+	var interval time.Duration
+	var expiryWarning time.Duration
+	var noNotifications bool
+
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Notify about access changes and run hooks",
+		Long: `Run in the foreground and watch your access requests. When access is granted, is about to expire, expired, was rejected or requires MFA, a desktop notification is shown and the matching hooks run with the event JSON on stdin.
+
+Configure hooks with 'apono config hooks add', the warning time with 'apono config set daemon.expiry_warning' and notifications with 'apono config set daemon.desktop_notifications'.
+The command logs to stdout and stops on SIGINT or SIGTERM, so it can run as a systemd user service or a launchd agent, for example:
+
+  [Service]
+  ExecStart=/usr/local/bin/apono daemon
+  Restart=on-failure`,
+		GroupID: groups.OtherCommandsGroup.ID,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			client, err := aponoapi.GetClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if interval <= 0 {
+				return fmt.Errorf("--interval must be positive")
+			}
+
+			daemonConfig := config.GetDaemonConfig()
+			if !cmd.Flags().Changed("expiry-warning") && daemonConfig.ExpiryWarning != "" {
+				expiryWarning, err = time.ParseDuration(daemonConfig.ExpiryWarning)
+				if err != nil {
+					return fmt.Errorf("invalid daemon.expiry_warning in config: %w", err)
+				}
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGTERM)
+			defer stop()
+
+			return daemon.Run(ctx, client, daemon.Options{
+				Interval:             interval,
+				ExpiryWarning:        expiryWarning,
+				DesktopNotifications: !noNotifications && config.IsDaemonDesktopNotificationsEnabled(),
+				Hooks:                daemonConfig.Hooks,
+				Out:                  cmd.OutOrStdout(),
+			})
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.DurationVar(&interval, "interval", daemon.DefaultInterval, "How often to poll your access requests")
+	flags.DurationVar(&expiryWarning, "expiry-warning", daemon.DefaultExpiryWarning, "How long before access expires to warn, overrides daemon.expiry_warning")
+	flags.BoolVar(&noNotifications, "no-notifications", false, "Only run hooks, without desktop notifications")
+
+	return cmd
+}
@@ -0,0 +1,15 @@
+package daemon
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/commands/daemon/actions"
+)
+
+type Configurator struct{}
+
+func (c *Configurator) ConfigureCommands(rootCmd *cobra.Command) error {
+	rootCmd.AddCommand(actions.Daemon())
+
+	return nil
+}
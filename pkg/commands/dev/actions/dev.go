@@ -0,0 +1,18 @@
+package actions
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/groups"
+)
+
+func Dev() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "dev",
+		Short:             "Tools for developing and testing integrations with the CLI",
+		GroupID:           groups.OtherCommandsGroup.ID,
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error { return nil },
+	}
+
+	return cmd
+}
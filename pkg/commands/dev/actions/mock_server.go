@@ -0,0 +1,104 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/mockserver"
+)
+
+const mockServerShutdownTimeout = 5 * time.Second
+
+func MockServer() *cobra.Command {
+	var addr string
+	var fixturesPath string
+	var printFixtures bool
+
+	cmd := &cobra.Command{
+		Use:   "mock-server",
+		Short: "Run an offline mock of the Apono API",
+		Long: `Run a local server that simulates the Apono client API, so scripts built on the CLI and the SDK can be tested without a tenant or network access.
+
+Access requests move from Pending to Granting to Active and, once their duration ends or they are revoked, to Revoking and Revoked, on the timing of the fixtures. Active requests have a session whose access details come from the fixtures. Inventory, MCP and the other operations of the bundled OpenAPI spec are served too; operations without a simulation answer with the smallest response their schema accepts and the X-Apono-Mock-Stub header.
+
+The fixtures file describes the user, the integrations, bundles, access details and timing. Print the defaults with --print-fixtures and pass an edited copy with --fixtures.`,
+		Example: `  apono dev mock-server --addr 127.0.0.1:8080 &
+  APONO_TOKEN=mock APONO_API_URL=http://127.0.0.1:8080 apono requests create --bundle db-admins --justification "Testing my script"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if printFixtures {
+				_, err := cmd.OutOrStdout().Write(mockserver.DefaultFixtures)
+				return err
+			}
+
+			fixtures, err := mockserver.LoadFixtures(fixturesPath)
+			if err != nil {
+				return err
+			}
+
+			server, err := mockserver.New(fixtures)
+			if err != nil {
+				return err
+			}
+
+			listener, err := net.Listen("tcp", addr)
+			if err != nil {
+				return fmt.Errorf("failed to listen on %s: %w", addr, err)
+			}
+
+			token := fixtures.Token
+			if token == "" {
+				token = "mock"
+			}
+			url := "http://" + listener.Addr().String()
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Mock Apono API listening on %s\n", url)
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Use it with: APONO_TOKEN=%s APONO_API_URL=%s apono ...\n", token, url)
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Or log in with: apono login --personal-token %s --api-url %s --profile mock\n", token, url)
+
+			return serveUntilSignal(cmd.Context(), &http.Server{Handler: server, ReadHeaderTimeout: 10 * time.Second}, listener)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&addr, "addr", "127.0.0.1:8080", "The address to listen on, use port 0 for any free port")
+	flags.StringVar(&fixturesPath, "fixtures", "", "Path to a fixtures file, defaults to the bundled fixtures")
+	flags.BoolVar(&printFixtures, "print-fixtures", false, "Print the default fixtures and exit")
+
+	return cmd
+}
+
+func serveUntilSignal(ctx context.Context, server *http.Server, listener net.Listener) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), mockServerShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
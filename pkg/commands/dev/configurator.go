@@ -0,0 +1,18 @@
+package dev
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/commands/dev/actions"
+)
+
+type Configurator struct{}
+
+func (c *Configurator) ConfigureCommands(rootCmd *cobra.Command) error {
+	devCmd := actions.Dev()
+	rootCmd.AddCommand(devCmd)
+
+	devCmd.AddCommand(actions.MockServer())
+
+	return nil
+}
@@ -1,23 +1,35 @@
 package actions
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/clientapi"
 	"github.com/apono-io/apono-cli/pkg/services"
 	"github.com/apono-io/apono-cli/pkg/utils"
 
-	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 )
 
 func ListBundles() *cobra.Command {
 	format := new(utils.Format)
+	var allProfiles bool
 	cmd := &cobra.Command{
 		Use:     "bundles",
 		Short:   "List all bundles available for requesting access",
 		Aliases: []string{"bundle"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if allProfiles {
+				results, err := services.ListFromAllProfiles(cmd.Context(), cmd.ErrOrStderr(), func(ctx context.Context, client *aponoapi.AponoClient) ([]clientapi.BundleClientModel, error) {
+					return services.ListBundles(ctx, client, "")
+				})
+				if err != nil {
+					return err
+				}
+
+				return services.PrintProfilesBundles(cmd, results, *format)
+			}
+
 			client, err := aponoapi.GetClient(cmd.Context())
 			if err != nil {
 				return err
@@ -28,27 +40,12 @@ func ListBundles() *cobra.Command {
 				return err
 			}
 
-			switch *format {
-			case utils.TableFormat:
-				table := uitable.New()
-				table.AddRow("ID", "NAME")
-				for _, bundle := range bundles {
-					table.AddRow(bundle.Id, bundle.Name)
-				}
-
-				_, err = fmt.Fprintln(cmd.OutOrStdout(), table)
-				return err
-			case utils.JSONFormat:
-				return utils.PrintObjectsAsJSON(cmd.OutOrStdout(), bundles)
-			case utils.YamlFormat:
-				return utils.PrintObjectsAsYaml(cmd.OutOrStdout(), bundles)
-			default:
-				return fmt.Errorf("unsupported output format")
-			}
+			return services.PrintBundles(cmd, bundles, *format)
 		},
 	}
 	flags := cmd.Flags()
 	utils.AddFormatFlag(flags, format)
+	utils.AddAllProfilesFlag(flags, &allProfiles)
 
 	return cmd
 }
@@ -1,23 +1,35 @@
 package actions
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/clientapi"
 	"github.com/apono-io/apono-cli/pkg/services"
 	"github.com/apono-io/apono-cli/pkg/utils"
 
-	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 )
 
 func ListIntegrations() *cobra.Command {
 	format := new(utils.Format)
+	var allProfiles bool
 	cmd := &cobra.Command{
 		Use:     "integrations",
 		Short:   "List all integrations available for requesting access",
 		Aliases: []string{"integration"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if allProfiles {
+				results, err := services.ListFromAllProfiles(cmd.Context(), cmd.ErrOrStderr(), func(ctx context.Context, client *aponoapi.AponoClient) ([]clientapi.IntegrationClientModel, error) {
+					return services.ListIntegrations(ctx, client)
+				})
+				if err != nil {
+					return err
+				}
+
+				return services.PrintProfilesIntegrations(cmd, results, *format)
+			}
+
 			client, err := aponoapi.GetClient(cmd.Context())
 			if err != nil {
 				return err
@@ -28,27 +40,12 @@ func ListIntegrations() *cobra.Command {
 				return err
 			}
 
-			switch *format {
-			case utils.TableFormat:
-				table := uitable.New()
-				table.AddRow("ID", "TYPE", "NAME")
-				for _, integration := range integrations {
-					table.AddRow(integration.Id, integration.Type, integration.Name)
-				}
-
-				_, err = fmt.Fprintln(cmd.OutOrStdout(), table)
-				return err
-			case utils.JSONFormat:
-				return utils.PrintObjectsAsJSON(cmd.OutOrStdout(), integrations)
-			case utils.YamlFormat:
-				return utils.PrintObjectsAsYaml(cmd.OutOrStdout(), integrations)
-			default:
-				return fmt.Errorf("unsupported output format")
-			}
+			return services.PrintIntegrations(cmd, integrations, *format)
 		},
 	}
 	flags := cmd.Flags()
 	utils.AddFormatFlag(flags, format)
+	utils.AddAllProfilesFlag(flags, &allProfiles)
 
 	return cmd
 }
@@ -1,18 +1,20 @@
 package actions
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/clientapi"
 	"github.com/apono-io/apono-cli/pkg/services"
 	"github.com/apono-io/apono-cli/pkg/utils"
 
-	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 )
 
 func ListPermissions() *cobra.Command {
 	format := new(utils.Format)
+	var allProfiles bool
 	var integrationIDOrName string
 	var resourceType string
 	cmd := &cobra.Command{
@@ -20,6 +22,23 @@ func ListPermissions() *cobra.Command {
 		Short:   "List all permissions of integration resource type",
 		Aliases: []string{"permission"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if allProfiles {
+				// Integration names resolve to different ids in every account.
+				results, err := services.ListFromAllProfiles(cmd.Context(), cmd.ErrOrStderr(), func(ctx context.Context, client *aponoapi.AponoClient) ([]clientapi.PermissionClientModel, error) {
+					integration, err := services.GetIntegrationByIDOrByTypeAndName(ctx, client, integrationIDOrName)
+					if err != nil {
+						return nil, fmt.Errorf("failed to get integration: %w", err)
+					}
+
+					return services.ListPermissions(ctx, client, integration.Id, resourceType)
+				})
+				if err != nil {
+					return err
+				}
+
+				return services.PrintProfilesPermissions(cmd, results, *format)
+			}
+
 			client, err := aponoapi.GetClient(cmd.Context())
 			if err != nil {
 				return err
@@ -35,28 +54,13 @@ func ListPermissions() *cobra.Command {
 				return err
 			}
 
-			switch *format {
-			case utils.TableFormat:
-				table := uitable.New()
-				table.AddRow("ID", "NAME")
-				for _, permission := range permissions {
-					table.AddRow(permission.Id, permission.Name)
-				}
-
-				_, err = fmt.Fprintln(cmd.OutOrStdout(), table)
-				return err
-			case utils.JSONFormat:
-				return utils.PrintObjectsAsJSON(cmd.OutOrStdout(), permissions)
-			case utils.YamlFormat:
-				return utils.PrintObjectsAsYaml(cmd.OutOrStdout(), permissions)
-			default:
-				return fmt.Errorf("unsupported output format")
-			}
+			return services.PrintPermissions(cmd, permissions, *format)
 		},
 	}
 
 	flags := cmd.Flags()
 	utils.AddFormatFlag(flags, format)
+	utils.AddAllProfilesFlag(flags, &allProfiles)
 	flags.StringVarP(&integrationIDOrName, "integration", "i", "", "the integration id or type/name, for example: \"aws-account/My AWS integration\"")
 	flags.StringVarP(&resourceType, "type", "t", "", "the resource type")
 	_ = cmd.MarkFlagRequired("integration")
@@ -1,24 +1,43 @@
 package actions
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/clientapi"
 	"github.com/apono-io/apono-cli/pkg/services"
 	"github.com/apono-io/apono-cli/pkg/utils"
 
-	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 )
 
 func ListResourceTypes() *cobra.Command {
 	format := new(utils.Format)
+	var allProfiles bool
 	var integrationIDOrName string
 	cmd := &cobra.Command{
 		Use:     "resource-types",
 		Short:   "List all resource types of integration",
 		Aliases: []string{"resource-type"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if allProfiles {
+				// Integration names resolve to different ids in every account.
+				results, err := services.ListFromAllProfiles(cmd.Context(), cmd.ErrOrStderr(), func(ctx context.Context, client *aponoapi.AponoClient) ([]clientapi.ResourceTypeClientModel, error) {
+					integration, err := services.GetIntegrationByIDOrByTypeAndName(ctx, client, integrationIDOrName)
+					if err != nil {
+						return nil, fmt.Errorf("failed to get integration: %w", err)
+					}
+
+					return services.ListResourceTypes(ctx, client, integration.Id)
+				})
+				if err != nil {
+					return err
+				}
+
+				return services.PrintProfilesResourceTypes(cmd, results, *format)
+			}
+
 			client, err := aponoapi.GetClient(cmd.Context())
 			if err != nil {
 				return err
@@ -34,28 +53,13 @@ func ListResourceTypes() *cobra.Command {
 				return err
 			}
 
-			switch *format {
-			case utils.TableFormat:
-				table := uitable.New()
-				table.AddRow("ID", "NAME")
-				for _, resourceType := range resourceTypes {
-					table.AddRow(resourceType.Id, resourceType.Name)
-				}
-
-				_, err = fmt.Fprintln(cmd.OutOrStdout(), table)
-				return err
-			case utils.JSONFormat:
-				return utils.PrintObjectsAsJSON(cmd.OutOrStdout(), resourceTypes)
-			case utils.YamlFormat:
-				return utils.PrintObjectsAsYaml(cmd.OutOrStdout(), resourceTypes)
-			default:
-				return fmt.Errorf("unsupported output format")
-			}
+			return services.PrintResourceTypes(cmd, resourceTypes, *format)
 		},
 	}
 
 	flags := cmd.Flags()
 	utils.AddFormatFlag(flags, format)
+	utils.AddAllProfilesFlag(flags, &allProfiles)
 	flags.StringVarP(&integrationIDOrName, "integration", "i", "", "the integration id or type/name, for example: \"aws-account/My AWS integration\"")
 	_ = cmd.MarkFlagRequired("integration")
 
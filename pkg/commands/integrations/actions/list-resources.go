@@ -1,18 +1,20 @@
 package actions
 
 import (
+	"context"
 	"fmt"
 
-	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/clientapi"
 	"github.com/apono-io/apono-cli/pkg/services"
 	"github.com/apono-io/apono-cli/pkg/utils"
 )
 
 func ListResources() *cobra.Command {
 	format := new(utils.Format)
+	var allProfiles bool
 	var integrationIDOrName string
 	var resourceType string
 	cmd := &cobra.Command{
@@ -20,6 +22,23 @@ func ListResources() *cobra.Command {
 		Short:   "List all resources of integration resource type",
 		Aliases: []string{"resource"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if allProfiles {
+				// Integration names resolve to different ids in every account.
+				results, err := services.ListFromAllProfiles(cmd.Context(), cmd.ErrOrStderr(), func(ctx context.Context, client *aponoapi.AponoClient) ([]clientapi.ResourceClientModel, error) {
+					integration, err := services.GetIntegrationByIDOrByTypeAndName(ctx, client, integrationIDOrName)
+					if err != nil {
+						return nil, fmt.Errorf("failed to get integration: %w", err)
+					}
+
+					return services.ListResources(ctx, client, integration.Id, resourceType, nil)
+				})
+				if err != nil {
+					return err
+				}
+
+				return services.PrintProfilesResources(cmd, results, *format)
+			}
+
 			client, err := aponoapi.GetClient(cmd.Context())
 			if err != nil {
 				return err
@@ -35,28 +54,13 @@ func ListResources() *cobra.Command {
 				return err
 			}
 
-			switch *format {
-			case utils.TableFormat:
-				table := uitable.New()
-				table.AddRow("ID", "NAME")
-				for _, resource := range resources {
-					table.AddRow(resource.SourceId, resource.Name)
-				}
-
-				_, err = fmt.Fprintln(cmd.OutOrStdout(), table)
-				return err
-			case utils.JSONFormat:
-				return utils.PrintObjectsAsJSON(cmd.OutOrStdout(), resources)
-			case utils.YamlFormat:
-				return utils.PrintObjectsAsYaml(cmd.OutOrStdout(), resources)
-			default:
-				return fmt.Errorf("unsupported output format")
-			}
+			return services.PrintResources(cmd, resources, *format)
 		},
 	}
 
 	flags := cmd.Flags()
 	utils.AddFormatFlag(flags, format)
+	utils.AddAllProfilesFlag(flags, &allProfiles)
 	flags.StringVarP(&integrationIDOrName, "integration", "i", "", "the integration id or type/name, for example: \"aws-account/My AWS integration\"")
 	flags.StringVarP(&resourceType, "type", "t", "", "the resource type")
 	_ = cmd.MarkFlagRequired("integration")
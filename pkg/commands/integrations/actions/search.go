@@ -0,0 +1,118 @@
+package actions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/interactive/flows"
+	"github.com/apono-io/apono-cli/pkg/interactive/selectors"
+	"github.com/apono-io/apono-cli/pkg/services"
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+const defaultSearchLimit = 20
+
+func Search() *cobra.Command {
+	format := new(utils.Format)
+	var kinds []string
+	var limit int
+	var request bool
+
+	cmd := &cobra.Command{
+		Use:   "search <text>",
+		Short: "Search bundles, integrations, resource types, resources and permissions",
+		Long: `Search bundles, integrations, resource types, resources and permissions you can request access to.
+Results are ranked by how well they match, and each result comes with the command to request it.`,
+		Example: `  apono inventory search prod-db
+  apono inventory search "s3 bucket" --kind resource-type,resource
+  apono inventory search billing --request`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, kind := range kinds {
+				if !utils.Contains(services.InventorySearchKinds, kind) {
+					return fmt.Errorf("invalid kind %q, valid values are: %s", kind, strings.Join(services.InventorySearchKinds, ", "))
+				}
+			}
+
+			client, err := aponoapi.GetClient(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			hits, err := services.SearchInventory(cmd.Context(), client, strings.Join(args, " "), services.InventorySearchOptions{
+				Kinds: kinds,
+				Limit: limit,
+			})
+			if err != nil {
+				return err
+			}
+
+			if request {
+				return requestInventorySearchHit(cmd, client, hits)
+			}
+
+			if len(hits) == 0 {
+				_, err = fmt.Fprintln(cmd.OutOrStdout(), "No results found")
+				return err
+			}
+
+			if format.IsTable() {
+				return printInventorySearchHits(cmd, hits)
+			}
+
+			table := utils.NewTable(utils.Column("KIND"), utils.Column("NAME"), utils.Column("LOCATION"), utils.Column("SCORE"), utils.Column("COMMAND"))
+			for _, hit := range hits {
+				table.AddRow(hit.Kind, hit.Name, hit.Location, hit.Score, hit.Command)
+			}
+
+			return utils.PrintObjects(cmd.OutOrStdout(), *format, hits, table)
+		},
+	}
+
+	flags := cmd.Flags()
+	utils.AddFormatFlag(flags, format)
+	flags.StringSliceVar(&kinds, "kind", nil, "Only search these kinds: "+strings.Join(services.InventorySearchKinds, ", "))
+	flags.IntVar(&limit, "limit", defaultSearchLimit, "The maximum number of results, 0 for no limit")
+	flags.BoolVar(&request, "request", false, "Open the interactive request builder pre-filled with the selected result")
+
+	_ = cmd.RegisterFlagCompletionFunc("kind", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return services.InventorySearchKinds, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	return cmd
+}
+
+func printInventorySearchHits(cmd *cobra.Command, hits []services.InventorySearchHit) error {
+	for i, hit := range hits {
+		_, err := fmt.Fprintf(cmd.OutOrStdout(), "%d. %s %s %s\n   %s\n",
+			i+1,
+			color.Gray.Sprint(hit.Kind),
+			color.Bold.Sprint(hit.Name),
+			color.Gray.Sprintf("(%s)", hit.Location),
+			color.Green.Sprint(hit.Command),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func requestInventorySearchHit(cmd *cobra.Command, client *aponoapi.AponoClient, hits []services.InventorySearchHit) error {
+	hit, err := selectors.RunInventorySearchHitSelector(hits)
+	if err != nil {
+		return err
+	}
+
+	req, err := flows.StartInventorySearchHitRequestBuilderInteractiveMode(cmd, client, hit)
+	if err != nil {
+		return err
+	}
+
+	return flows.RunSubmitRequestInteractiveFlow(cmd, client, req)
+}
@@ -17,5 +17,6 @@ func (c *Configurator) ConfigureCommands(rootCmd *cobra.Command) error {
 	inventoryRootCmd.AddCommand(actions.ListResourceTypes())
 	inventoryRootCmd.AddCommand(actions.ListResources())
 	inventoryRootCmd.AddCommand(actions.ListBundles())
+	inventoryRootCmd.AddCommand(actions.Search())
 	return nil
 }
@@ -50,8 +50,9 @@ func MCP() *cobra.Command {
 		GroupID:           groups.OtherCommandsGroup.ID,
 		PersistentPreRunE: func(_ *cobra.Command, _ []string) error { return nil },
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// The log is best effort, so read-only filesystems can still run the server.
 			if err := utils.InitMcpLogFile(); err != nil {
-				return fmt.Errorf("failed to initialize MCP log file: %w", err)
+				fmt.Fprintf(os.Stderr, "warning: MCP log file not available: %v\n", err)
 			}
 			defer utils.CloseMcpLogFile()
 
@@ -77,7 +78,8 @@ func MCP() *cobra.Command {
 func createAponoMCPClient(cmd *cobra.Command) (string, *http.Client, error) {
 	utils.McpLogf("=== Starting Setup ===")
 
-	sessionCfg, err := config.GetProfileByName("")
+	profileName, _ := cmd.Flags().GetString("profile")
+	sessionCfg, err := config.GetProfileByName(config.ProfileName(profileName))
 	if err != nil {
 		utils.McpLogf("[Error]: Couldn't get profile: %v", err)
 		return "", nil, fmt.Errorf("failed to get profile: %w", err)
@@ -94,7 +96,7 @@ func createAponoMCPClient(cmd *cobra.Command) (string, *http.Client, error) {
 	if sessionCfg.PersonalToken != "" {
 		httpClient = aponoapi.HTTPClientWithPersonalToken(sessionCfg.PersonalToken)
 	} else {
-		client, err := aponoapi.CreateClient(cmd.Context(), "")
+		client, err := aponoapi.CreateClient(cmd.Context(), profileName)
 		if err != nil {
 			utils.McpLogf("[Error]: Couldn't create API client: %v", err)
 			return "", nil, fmt.Errorf("failed to create API client: %w", err)
@@ -0,0 +1,206 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/config"
+	"github.com/apono-io/apono-cli/pkg/groups"
+	"github.com/apono-io/apono-cli/pkg/plugins"
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+const pluginPathAnnotation = "apono.io/plugin-path"
+
+// Commands cobra adds when the CLI runs, after the plugin commands.
+var cobraDefaultCommands = []string{"help", "completion"}
+
+func Plugin() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugin",
+		Short: "Manage the plugins that extend the CLI",
+		Long: `Plugins are executables named apono-<name> on your PATH, which run as 'apono <name>'. The arguments after the name are passed to the plugin, except for leading flags of the CLI itself such as --profile.
+
+A plugin runs as the profile selected by --profile, APONO_PROFILE, the project file or the active profile, and receives:
+  APONO_PROFILE        the resolved profile, unset when APONO_TOKEN configures the CLI
+  APONO_API_URL        the API URL of the profile
+  APONO_TOKEN          a valid access token of the profile, which the CLI also accepts
+  APONO_TOKEN_EXPIRY   when the access token expires, unset for personal tokens
+
+For a profile that logged in with a personal token, APONO_TOKEN is the personal token itself, which does not expire until it is revoked. Only run plugins you trust with it.
+
+Plugins cannot replace built-in commands, and when two plugins have the same name the one earlier on PATH wins.`,
+		GroupID:           groups.OtherCommandsGroup.ID,
+		Aliases:           []string{"plugins"},
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error { return nil },
+	}
+
+	return cmd
+}
+
+func PluginList() *cobra.Command {
+	format := new(utils.Format)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the plugins on PATH",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			found := plugins.Discover()
+
+			table := utils.NewTable(
+				utils.Column("NAME"),
+				utils.Column("PATH"),
+				utils.Column("WARNING"),
+			)
+			for _, plugin := range found {
+				table.AddRow(plugin.Name, plugin.Path, pluginWarning(cmd.Root(), plugin))
+			}
+
+			return utils.PrintObjects(cmd.OutOrStdout(), *format, found, table)
+		},
+	}
+
+	utils.AddFormatFlag(cmd.Flags(), format)
+
+	return cmd
+}
+
+func pluginWarning(rootCmd *cobra.Command, plugin plugins.Plugin) string {
+	switch {
+	case plugin.ShadowedBy != "":
+		return fmt.Sprintf("shadowed by %s", plugin.ShadowedBy)
+	case IsBuiltinCommand(rootCmd, plugin.Name):
+		return fmt.Sprintf("ignored, 'apono %s' is a built-in command", plugin.Name)
+	default:
+		return ""
+	}
+}
+
+// IsBuiltinCommand reports whether name is a command, or an alias, of the
+// CLI itself rather than of a plugin.
+func IsBuiltinCommand(rootCmd *cobra.Command, name string) bool {
+	if utils.Contains(cobraDefaultCommands, name) {
+		return true
+	}
+
+	for _, cmd := range rootCmd.Commands() {
+		if _, isPlugin := cmd.Annotations[pluginPathAnnotation]; isPlugin {
+			continue
+		}
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func PluginCommand(plugin plugins.Plugin) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                plugin.Name,
+		Short:              fmt.Sprintf("Run the %s plugin", plugin.Path),
+		GroupID:            groups.PluginCommandsGroup.ID,
+		Annotations:        map[string]string{pluginPathAnnotation: plugin.Path},
+		DisableFlagParsing: true,
+		// The plugin resolves its own profile and its arguments may hold
+		// anything, so no client is created and no analytics are sent.
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error { return nil },
+		PersistentPostRun: func(_ *cobra.Command, _ []string) {},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			args, err := parseRootFlags(cmd.Root(), args)
+			if err != nil {
+				return err
+			}
+
+			profileName, _ := cmd.Root().PersistentFlags().GetString("profile")
+			env, err := pluginEnv(cmd.Context(), config.ProfileName(profileName))
+			if err != nil {
+				return fmt.Errorf("failed to authenticate plugin %s: %w", plugin.Name, err)
+			}
+
+			return runPlugin(cmd, plugin, args, env)
+		},
+	}
+
+	return cmd
+}
+
+// parseRootFlags sets the flags of the root command, such as --profile, that
+// lead the arguments, and returns the arguments left for the plugin. Flag
+// parsing is disabled for plugins, so `apono --profile prod <plugin>` and
+// `apono <plugin> --profile prod` both pass the flag here.
+func parseRootFlags(rootCmd *cobra.Command, args []string) ([]string, error) {
+	flags := rootCmd.PersistentFlags()
+	for len(args) > 0 && strings.HasPrefix(args[0], "--") {
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(args[0], "--"), "=")
+		flag := flags.Lookup(name)
+		if flag == nil {
+			break
+		}
+
+		args = args[1:]
+		if !hasValue {
+			if flag.NoOptDefVal != "" {
+				value = flag.NoOptDefVal
+			} else {
+				if len(args) == 0 {
+					return nil, fmt.Errorf("flag needs an argument: --%s", name)
+				}
+				value, args = args[0], args[1:]
+			}
+		}
+
+		if err := flags.Set(name, value); err != nil {
+			return nil, fmt.Errorf("invalid argument %q for --%s: %w", value, name, err)
+		}
+	}
+
+	return args, nil
+}
+
+func pluginEnv(ctx context.Context, profileFlag config.ProfileName) ([]string, error) {
+	profileName := config.ResolveActiveProfileName(profileFlag)
+	session, err := config.GetProfileByName(profileName)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := aponoapi.GetAccessToken(ctx, string(profileName))
+	if err != nil {
+		return nil, err
+	}
+
+	return plugins.Env(profileName, session.ApiURL, token), nil
+}
+
+func runPlugin(cmd *cobra.Command, plugin plugins.Plugin, args []string, env []string) error {
+	// As with `apono vault run`, Ctrl+C reaches the plugin directly through the
+	// terminal and it decides how to shut down.
+	child := exec.CommandContext(context.Background(), plugin.Path, args...) //nolint:gosec // runs the plugin the user invoked
+	child.Stdin = cmd.InOrStdin()
+	child.Stdout = cmd.OutOrStdout()
+	child.Stderr = cmd.ErrOrStderr()
+	child.Env = append(os.Environ(), env...)
+
+	err := child.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		code := exitErr.ExitCode()
+		if code < 0 {
+			code = 1
+		}
+		return &utils.ExitCodeError{Code: code}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to run plugin %s: %w", plugin.Name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package actions
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestParseRootFlags(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantArgs    []string
+		wantProfile string
+		wantErr     bool
+	}{
+		{name: "no flags", args: []string{"a", "--b"}, wantArgs: []string{"a", "--b"}},
+		{name: "profile", args: []string{"--profile", "prod", "a"}, wantArgs: []string{"a"}, wantProfile: "prod"},
+		{name: "profile with equals", args: []string{"--profile=prod", "a"}, wantArgs: []string{"a"}, wantProfile: "prod"},
+		{name: "profile after an argument", args: []string{"a", "--profile", "prod"}, wantArgs: []string{"a", "--profile", "prod"}},
+		{name: "plugin flag first", args: []string{"--verbose", "--profile", "prod"}, wantArgs: []string{"--verbose", "--profile", "prod"}},
+		{name: "missing value", args: []string{"--profile"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rootCmd := &cobra.Command{Use: "apono"}
+			rootCmd.PersistentFlags().String("profile", "", "profile name")
+
+			args, err := parseRootFlags(rootCmd, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRootFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(args) == 0 {
+				args = nil
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("parseRootFlags() = %q, want %q", args, tt.wantArgs)
+			}
+			if profile, _ := rootCmd.PersistentFlags().GetString("profile"); profile != tt.wantProfile {
+				t.Errorf("profile = %q, want %q", profile, tt.wantProfile)
+			}
+		})
+	}
+}
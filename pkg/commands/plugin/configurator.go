@@ -0,0 +1,38 @@
+package plugin
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/commands/plugin/actions"
+	"github.com/apono-io/apono-cli/pkg/groups"
+	"github.com/apono-io/apono-cli/pkg/plugins"
+)
+
+type Configurator struct{}
+
+func (c *Configurator) ConfigureCommands(rootCmd *cobra.Command) error {
+	pluginCmd := actions.Plugin()
+	rootCmd.AddCommand(pluginCmd)
+
+	pluginCmd.AddCommand(actions.PluginList())
+
+	return nil
+}
+
+// AddPluginCommands adds a command for every plugin on PATH. It runs after
+// all built-in commands are added, so plugins cannot replace them.
+func AddPluginCommands(rootCmd *cobra.Command) {
+	var added bool
+	for _, plugin := range plugins.Discover() {
+		if plugin.ShadowedBy != "" || actions.IsBuiltinCommand(rootCmd, plugin.Name) {
+			continue
+		}
+
+		rootCmd.AddCommand(actions.PluginCommand(plugin))
+		added = true
+	}
+
+	if added {
+		rootCmd.AddGroup(groups.PluginCommandsGroup)
+	}
+}
@@ -0,0 +1,50 @@
+package actions
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	//go:embed snippets/starship.toml
+	starshipSnippet string
+
+	//go:embed snippets/p10k.zsh
+	p10kSnippet string
+)
+
+var promptSnippets = map[string]string{
+	"starship": starshipSnippet,
+	"p10k":     p10kSnippet,
+}
+
+func PromptInit() *cobra.Command {
+	return &cobra.Command{
+		Use:       "init <starship|p10k>",
+		Short:     "Print a prompt snippet for starship or powerlevel10k",
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: promptSnippetNames(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			snippet, ok := promptSnippets[args[0]]
+			if !ok {
+				return fmt.Errorf("unsupported prompt %q, supported prompts are: %s", args[0], strings.Join(promptSnippetNames(), ", "))
+			}
+
+			_, err := fmt.Fprint(cmd.OutOrStdout(), snippet)
+			return err
+		},
+	}
+}
+
+func promptSnippetNames() []string {
+	names := make([]string, 0, len(promptSnippets))
+	for name := range promptSnippets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,81 @@
+package actions
+
+import (
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/config"
+	"github.com/apono-io/apono-cli/pkg/groups"
+	"github.com/apono-io/apono-cli/pkg/services"
+)
+
+const (
+	defaultPromptFormat = `{{if .Sessions}}apono {{.Sessions}}{{if .Expiry}} {{.Expiry}}{{end}}{{if .Stale}}?{{end}}{{end}}`
+	defaultPromptMaxAge = time.Hour
+)
+
+func Prompt() *cobra.Command {
+	var format string
+	var maxAge time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "prompt",
+		Short: "Print active session info for shell prompts",
+		Long: `Print the number of active sessions and the soonest expiry for shell prompts such as PS1, starship or powerlevel10k.
+The command only reads a local cache that other apono commands update whenever they list sessions or requests, so it never calls the API and returns within milliseconds.
+
+The --format flag is a Go template with the fields:
+  .Profile    the profile name
+  .Sessions   the number of active sessions
+  .Pending    the number of pending requests
+  .Expiry     the time until the soonest access expires, e.g. 1h05m
+  .ExpiresAt  the local time the soonest access expires, e.g. 17:30
+  .Stale      true when the cache is older than --max-age or the soonest access already expired
+
+Run 'apono prompt init starship' or 'apono prompt init p10k' for ready to use snippets.`,
+		Example:           `  PS1='$(apono prompt --format "{{if .Sessions}}[{{.Sessions}} until {{.ExpiresAt}}] {{end}}")'"$PS1"`,
+		GroupID:           groups.OtherCommandsGroup.ID,
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error { return nil },
+		PersistentPostRun: func(_ *cobra.Command, _ []string) {},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			tmpl, err := template.New("prompt").Parse(format)
+			if err != nil {
+				return fmt.Errorf("failed to parse prompt format: %w", err)
+			}
+
+			profileName, _ := cmd.Flags().GetString("profile")
+			profile, accountID := resolvePromptProfile(profileName)
+			state, err := services.ReadPromptState(services.PromptStatePath(), accountID)
+			if err != nil {
+				// A broken cache must not break the shell prompt.
+				return nil
+			}
+
+			return tmpl.Execute(cmd.OutOrStdout(), services.NewPromptInfo(profile, state, time.Now(), maxAge))
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&format, "format", defaultPromptFormat, "Go template used to render the prompt")
+	flags.DurationVar(&maxAge, "max-age", defaultPromptMaxAge, "Age after which the cached state is considered stale")
+
+	return cmd
+}
+
+func resolvePromptProfile(profileName string) (string, string) {
+	cfg, err := config.Get()
+	if err != nil {
+		return profileName, ""
+	}
+
+	name := config.ResolveProfileName(config.ProfileName(profileName))
+	if name == "" {
+		name = cfg.Auth.ActiveProfile
+	}
+
+	return string(name), cfg.Auth.Profiles[name].AccountID
+}
@@ -0,0 +1,18 @@
+package prompt
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/commands/prompt/actions"
+)
+
+type Configurator struct{}
+
+func (c *Configurator) ConfigureCommands(rootCmd *cobra.Command) error {
+	promptCmd := actions.Prompt()
+	rootCmd.AddCommand(promptCmd)
+
+	promptCmd.AddCommand(actions.PromptInit())
+
+	return nil
+}
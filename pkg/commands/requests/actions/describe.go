@@ -9,8 +9,6 @@ import (
 
 	"github.com/apono-io/apono-cli/pkg/services"
 
-	"github.com/apono-io/apono-cli/pkg/clientapi"
-
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
 )
 
@@ -32,18 +30,18 @@ func Describe() *cobra.Command {
 			}
 
 			requestID := args[0]
-			resp, _, err := client.ClientAPI.AccessRequestsAPI.GetAccessRequest(cmd.Context(), requestID).Execute()
+			details, err := services.GetAccessRequestDetails(cmd.Context(), client, requestID)
 			if err != nil {
 				return err
 			}
 
-			err = services.PrintAccessRequests(cmd, []clientapi.AccessRequestClientModel{*resp}, *format, false)
+			err = services.PrintAccessRequestDetails(cmd, details, *format)
 			if err != nil {
 				return err
 			}
 
-			if services.IsRequestWaitingForMFA(resp) && *format == utils.TableFormat {
-				err = services.PrintAccessRequestMFALink(cmd, &resp.Id)
+			if services.IsRequestWaitingForMFA(&details.Request) && format.IsTable() {
+				err = services.PrintAccessRequestMFALink(cmd, &details.Request.Id)
 				if err != nil {
 					return err
 				}
@@ -1,6 +1,8 @@
 package actions
 
 import (
+	"context"
+
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
 	"github.com/apono-io/apono-cli/pkg/clientapi"
 	"github.com/apono-io/apono-cli/pkg/services"
@@ -12,11 +14,23 @@ import (
 func List() *cobra.Command {
 	format := new(utils.Format)
 	var daysOffset int64
+	var allProfiles bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all access request",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if allProfiles {
+				results, err := services.ListFromAllProfiles(cmd.Context(), cmd.ErrOrStderr(), func(ctx context.Context, client *aponoapi.AponoClient) ([]clientapi.AccessRequestClientModel, error) {
+					return services.ListRequests(ctx, client, daysOffset)
+				})
+				if err != nil {
+					return err
+				}
+
+				return services.PrintProfilesAccessRequests(cmd, results, *format)
+			}
+
 			client, err := aponoapi.GetClient(cmd.Context())
 			if err != nil {
 				return err
@@ -46,6 +60,7 @@ func List() *cobra.Command {
 	flags := cmd.Flags()
 	flags.Int64VarP(&daysOffset, "days", "d", 7, "number of days to list")
 	utils.AddFormatFlag(flags, format)
+	utils.AddAllProfilesFlag(flags, &allProfiles)
 
 	return cmd
 }
@@ -2,14 +2,17 @@ package actions
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
 	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/config"
 	"github.com/apono-io/apono-cli/pkg/interactive/flows"
 	requestloader "github.com/apono-io/apono-cli/pkg/interactive/inputs/request_loader"
+	"github.com/apono-io/apono-cli/pkg/sdk"
 	"github.com/apono-io/apono-cli/pkg/services"
 	"github.com/apono-io/apono-cli/pkg/utils"
 
@@ -27,6 +30,9 @@ const (
 	noWaitFlagName               = "no-wait"
 	timeoutFlagName              = "timeout"
 	durationFlagName             = "duration"
+	granteeFlagName              = "grantee"
+	customFieldFlagName          = "custom-field"
+	presetFlagName               = "preset"
 	defaultWaitTimeForNewRequest = 60 * time.Second
 	defaultAccessDuration        = 0
 )
@@ -44,14 +50,19 @@ type createRequestFlags struct {
 	timeout             time.Duration
 	output              utils.Format
 	customFields        []string
+	grantee             string
+	preset              string
 }
 
 func Create() *cobra.Command {
 	cmdFlags := &createRequestFlags{}
 
 	cmd := &cobra.Command{
-		Use:     "create",
-		Short:   "Create a new access request",
+		Use:   "create",
+		Short: "Create a new access request",
+		Long: `Create a new access request.
+
+Flags that are not set default to the values of the ` + config.ProjectFileName + ` file in the current directory or one of its parents, and --preset applies a named preset from it.`,
 		Aliases: []string{"new"},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := aponoapi.GetClient(cmd.Context())
@@ -59,30 +70,22 @@ func Create() *cobra.Command {
 				return err
 			}
 
-			req, err := createNewRequestAPIModelFromFlags(cmd, client, cmdFlags)
-			if err != nil {
+			if err = applyProjectDefaults(cmd, cmdFlags, cmdFlags.preset); err != nil {
 				return err
 			}
 
-			createResp, resp, err := client.ClientAPI.AccessRequestsAPI.CreateUserAccessRequest(cmd.Context()).
-				CreateAccessRequestClientModel(*req).
-				Execute()
+			sdkClient := sdk.NewClientFromAPI(client)
+			req, err := createNewRequestAPIModelFromFlags(cmd, sdkClient, cmdFlags)
 			if err != nil {
-				apiError := utils.ReturnAPIResponseError(resp)
-				if apiError != nil {
-					return apiError
-				}
-
 				return err
 			}
 
-			var newAccessRequest *clientapi.AccessRequestClientModel
-
-			if len(createResp.RequestIds) == 0 {
-				return fmt.Errorf("failed to create access request, no request IDs returned from the API")
+			requestID, err := sdkClient.SubmitRequest(cmd.Context(), req)
+			if err != nil {
+				return err
 			}
-			requestID := createResp.RequestIds[0]
-			newAccessRequest, err = waitForRequest(cmd.Context(), client, cmdFlags, requestID)
+
+			newAccessRequest, err := waitForRequest(cmd.Context(), sdkClient, cmdFlags, requestID)
 			if err != nil {
 				return err
 			}
@@ -96,7 +99,7 @@ func Create() *cobra.Command {
 				return err
 			}
 
-			if services.IsRequestWaitingForMFA(newAccessRequest) && cmdFlags.output == utils.TableFormat {
+			if services.IsRequestWaitingForMFA(newAccessRequest) && cmdFlags.output.IsTable() {
 				err = services.PrintAccessRequestMFALink(cmd, &newAccessRequest.Id)
 				if err != nil {
 					return err
@@ -119,7 +122,9 @@ func Create() *cobra.Command {
 	flags.BoolVar(&cmdFlags.noWait, noWaitFlagName, false, "Dont wait for the request to be granted")
 	flags.DurationVar(&cmdFlags.timeout, timeoutFlagName, defaultWaitTimeForNewRequest, "Timeout for waiting for the request to be granted")
 	flags.DurationVarP(&cmdFlags.accessDuration, durationFlagName, "d", defaultAccessDuration, "The duration of the access request")
-	flags.StringSliceVar(&cmdFlags.customFields, "custom-field", []string{}, "Custom field values in format 'field-id=value'")
+	flags.StringSliceVar(&cmdFlags.customFields, customFieldFlagName, []string{}, "Custom field values in format 'field-id=value'")
+	flags.StringVar(&cmdFlags.grantee, granteeFlagName, "", "Request the access on behalf of another user or group, by email or id")
+	flags.StringVar(&cmdFlags.preset, presetFlagName, "", fmt.Sprintf("A request preset from the %s project file", config.ProjectFileName))
 
 	cmd.MarkFlagsMutuallyExclusive(bundleFlagName, integrationFlagName)
 
@@ -139,6 +144,18 @@ func Create() *cobra.Command {
 		return permissionsAutocompleteFunc(cmd, cmdFlags.integrationIDOrName, cmdFlags.resourceType, toComplete)
 	})
 
+	_ = cmd.RegisterFlagCompletionFunc(granteeFlagName, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return granteesAutocompleteFunc(cmd, toComplete)
+	})
+
+	_ = cmd.RegisterFlagCompletionFunc(presetFlagName, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		project, err := config.GetProjectConfig()
+		if err != nil || project == nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return filterOptions[string](project.PresetNames(), func(name string) string { return name }, toComplete), cobra.ShellCompDirectiveNoFileComp
+	})
+
 	_ = cmd.RegisterFlagCompletionFunc(bundleFlagName, func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return bundlesAutoCompleteFunc(cmd, toComplete)
 	})
@@ -164,61 +181,91 @@ func parseCustomFields(fields []string) (map[string]string, error) {
 	return result, nil
 }
 
-func createNewRequestAPIModelFromFlags(cmd *cobra.Command, client *aponoapi.AponoClient, flags *createRequestFlags) (*clientapi.CreateAccessRequestClientModel, error) {
-	req := services.GetEmptyNewRequestAPIModel()
+func createNewRequestAPIModelFromFlags(cmd *cobra.Command, client *sdk.Client, flags *createRequestFlags) (*clientapi.CreateAccessRequestClientModel, error) {
+	if flags.integrationIDOrName != "" {
+		if err := validateIntegrationRequestFlagCombinations(flags); err != nil {
+			return nil, err
+		}
+	}
+
+	if flags.runInteractiveMode {
+		return createRequestInteractively(cmd, client.API(), flags)
+	}
 
-	if flags.justification != "" {
-		req.Justification = *clientapi.NewNullableString(&flags.justification)
+	if flags.integrationIDOrName == "" && flags.bundleIDOrName == "" {
+		return nil, fmt.Errorf("either --%s, --%s or --%s flags must be specified", integrationFlagName, bundleFlagName, interactiveFlagName)
 	}
 
-	durationFlag := cmd.Flag(durationFlagName)
-	var durationFlagValue *time.Duration
-	if durationFlag.Changed {
-		if flags.accessDuration <= 0 {
-			return nil, fmt.Errorf("duration must be greater than 0")
-		}
+	builder, err := requestBuilderFromFlags(cmd, flags)
+	if err != nil {
+		return nil, err
+	}
 
-		durationFlagValue = &flags.accessDuration
-		durationInSec := int32(flags.accessDuration.Seconds())
-		req.DurationInSec = *clientapi.NewNullableInt32(&durationInSec)
+	req, err := client.BuildRequest(cmd.Context(), builder)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validateRequest(cmd.Context(), client, req); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func requestBuilderFromFlags(cmd *cobra.Command, flags *createRequestFlags) (*sdk.RequestBuilder, error) {
+	var builder *sdk.RequestBuilder
+	if flags.integrationIDOrName != "" {
+		builder = sdk.IntegrationRequest(flags.integrationIDOrName, flags.resourceType).
+			WithResources(flags.resourceIDs...).
+			WithPermissions(flags.permissionIDs...)
+	} else {
+		builder = sdk.BundleRequest(flags.bundleIDOrName)
+	}
+
+	builder.WithJustification(flags.justification).WithGrantee(flags.grantee)
+	if cmd.Flag(durationFlagName).Changed {
+		builder.WithDuration(flags.accessDuration)
 	}
 
 	customFieldValues, err := parseCustomFields(flags.customFields)
 	if err != nil {
 		return nil, err
 	}
+	for fieldID, value := range customFieldValues {
+		builder.WithCustomField(fieldID, value)
+	}
 
-	req.CustomFields = customFieldValues
+	return builder, nil
+}
 
-	switch {
-	case flags.integrationIDOrName != "":
-		err := validateIntegrationRequestFlagCombinations(flags)
+func createRequestInteractively(cmd *cobra.Command, client *aponoapi.AponoClient, flags *createRequestFlags) (*clientapi.CreateAccessRequestClientModel, error) {
+	var durationFlagValue *time.Duration
+	if cmd.Flag(durationFlagName).Changed {
+		if flags.accessDuration <= 0 {
+			return nil, fmt.Errorf("duration must be greater than 0")
+		}
+
+		durationFlagValue = &flags.accessDuration
+	}
+
+	var grantee *clientapi.GranteeClientModel
+	if flags.grantee != "" {
+		var err error
+		grantee, err = services.GetGranteeByIDOrEmail(cmd.Context(), client, flags.grantee)
 		if err != nil {
 			return nil, err
 		}
+	}
 
-		var integration *clientapi.IntegrationClientModel
-		integration, err = services.GetIntegrationByIDOrByTypeAndName(cmd.Context(), client, flags.integrationIDOrName)
+	switch {
+	case flags.integrationIDOrName != "":
+		integration, err := services.GetIntegrationByIDOrByTypeAndName(cmd.Context(), client, flags.integrationIDOrName)
 		if err != nil {
 			return nil, err
 		}
 
-		if flags.runInteractiveMode {
-			req, err = flows.StartIntegrationRequestBuilderInteractiveMode(cmd, client, integration.Id, flags.resourceType, flags.resourceIDs, flags.permissionIDs, flags.justification, durationFlagValue)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			resourceIDs, err := listResourcesIDsFromSourceIDs(cmd.Context(), client, integration.Id, flags.resourceType, flags.resourceIDs)
-			if err != nil {
-				return nil, err
-			}
-
-			req.FilterIntegrationIds = []string{integration.Id}
-			req.FilterResourceTypeIds = []string{flags.resourceType}
-			req.FilterResources = services.ListResourceFiltersFromResourcesIDs(resourceIDs)
-			req.FilterPermissionIds = flags.permissionIDs
-		}
+		return flows.StartIntegrationRequestBuilderInteractiveMode(cmd, client, integration.Id, flags.resourceType, flags.resourceIDs, flags.permissionIDs, flags.justification, durationFlagValue, grantee)
 
 	case flags.bundleIDOrName != "":
 		bundle, err := services.GetBundleByNameOrID(cmd.Context(), client, flags.bundleIDOrName)
@@ -226,35 +273,11 @@ func createNewRequestAPIModelFromFlags(cmd *cobra.Command, client *aponoapi.Apon
 			return nil, err
 		}
 
-		if flags.runInteractiveMode {
-			req, err = flows.StartBundleRequestBuilderInteractiveMode(cmd, client, bundle.Id, flags.justification, durationFlagValue)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			req.FilterBundleIds = []string{bundle.Id}
-		}
+		return flows.StartBundleRequestBuilderInteractiveMode(cmd, client, bundle.Id, flags.justification, durationFlagValue, grantee)
 
 	default:
-		if flags.runInteractiveMode {
-			var err error
-			req, err = flows.StartRequestBuilderInteractiveMode(cmd, client)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			return nil, fmt.Errorf("either --%s, --%s or --%s flags must be specified", integrationFlagName, bundleFlagName, interactiveFlagName)
-		}
+		return flows.StartRequestBuilderInteractiveMode(cmd, client, grantee)
 	}
-
-	if !flags.runInteractiveMode {
-		dryRunValidationErr := dryRunValidation(cmd, client, flags, req)
-		if dryRunValidationErr != nil {
-			return nil, dryRunValidationErr
-		}
-	}
-
-	return req, nil
 }
 
 func integrationsAutocompleteFunc(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective) {
@@ -352,6 +375,23 @@ func permissionsAutocompleteFunc(cmd *cobra.Command, integrationIDOrName string,
 	})
 }
 
+func granteesAutocompleteFunc(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completeWithClient(cmd, func(client *aponoapi.AponoClient) ([]string, cobra.ShellCompDirective) {
+		grantees, err := services.ListGrantees(cmd.Context(), client, toComplete)
+		if err != nil {
+			_, _ = fmt.Fprintln(cmd.ErrOrStderr(), "failed to fetch available grantees:", err)
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		var options []string
+		for _, grantee := range grantees {
+			options = append(options, fmt.Sprintf("%s\t%s", services.GranteeFlagValue(&grantee), services.GranteeLabel(&grantee)))
+		}
+
+		return options, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
 func bundlesAutoCompleteFunc(cmd *cobra.Command, toComplete string) ([]string, cobra.ShellCompDirective) {
 	return completeWithClient(cmd, func(client *aponoapi.AponoClient) ([]string, cobra.ShellCompDirective) {
 		selectableBundles, err := services.ListBundles(cmd.Context(), client, "")
@@ -390,85 +430,30 @@ func filterOptions[T any](allOptions []T, optionValueExtractor func(T) string, t
 	return options
 }
 
-func listResourcesIDsFromSourceIDs(ctx context.Context, client *aponoapi.AponoClient, integrationID string, resourceType string, resourceIDs []string) ([]string, error) {
-	resources, err := services.ListResourcesBySourceIDs(ctx, client, integrationID, resourceType, resourceIDs)
-	if err != nil {
-		return nil, err
-	}
-
-	var resourceIDsToReturn []string
-	for _, resource := range resources {
-		resourceIDsToReturn = append(resourceIDsToReturn, resource.Id)
-	}
-
-	return resourceIDsToReturn, nil
-}
-
-func waitForRequest(ctx context.Context, client *aponoapi.AponoClient, cmdFlags *createRequestFlags, requestID string) (*clientapi.AccessRequestClientModel, error) {
-	var newAccessRequest *clientapi.AccessRequestClientModel
-	var err error
-
+func waitForRequest(ctx context.Context, client *sdk.Client, cmdFlags *createRequestFlags, requestID string) (*clientapi.AccessRequestClientModel, error) {
 	if cmdFlags.runInteractiveMode {
-		newAccessRequest, err = requestloader.RunRequestLoader(ctx, client, requestID, cmdFlags.timeout, cmdFlags.noWait)
-		if err != nil {
-			return nil, err
-		}
-
-		return newAccessRequest, nil
-	}
-
-	newAccessRequest, err = services.GetRequestByID(ctx, client, requestID)
-	if err != nil {
-		return nil, err
+		return requestloader.RunRequestLoader(ctx, client.API(), requestID, cmdFlags.timeout, cmdFlags.noWait)
 	}
 
 	if cmdFlags.noWait {
-		return newAccessRequest, nil
+		return client.GetRequest(ctx, requestID)
 	}
 
-	startTime := time.Now()
-	for {
-		if requestloader.ShouldStopLoading(newAccessRequest) {
-			return newAccessRequest, nil
-		}
-
-		if time.Now().After(startTime.Add(cmdFlags.timeout)) {
-			return newAccessRequest, fmt.Errorf("timeout waiting for request to be granted")
-		}
-
-		time.Sleep(1 * time.Second)
-
-		newAccessRequest, err = services.GetRequestByID(ctx, client, requestID)
-		if err != nil {
-			return nil, err
-		}
-	}
+	return client.WaitForRequest(ctx, requestID, sdk.WaitOptions{Timeout: cmdFlags.timeout})
 }
 
-func dryRunValidation(cmd *cobra.Command, client *aponoapi.AponoClient, flags *createRequestFlags, req *clientapi.CreateAccessRequestClientModel) error {
-	dryRunResp, err := services.DryRunRequest(cmd.Context(), client, req)
-	if err != nil {
-		return nil
-	}
-
-	isJustificationOptional := services.IsJustificationOptionalForRequest(dryRunResp)
-	if !isJustificationOptional && !req.Justification.IsSet() {
-		return fmt.Errorf("justification is required for this request, please use the --%s flag", justificationFlagName)
-	}
-
-	isDurationRequired := services.IsDurationRequiredForRequest(dryRunResp)
-	if isDurationRequired {
-		if !req.DurationInSec.IsSet() {
-			return fmt.Errorf("duration is required for this request, please use the --%s flag", durationFlagName)
-		}
-
-		requestMaximumDuration := services.GetMaximumRequestDuration(dryRunResp)
-		if flags.accessDuration > requestMaximumDuration {
-			return fmt.Errorf("duration is too long, maximum duration is %.2f hours", requestMaximumDuration.Hours())
-		}
+// validateRequest names the flag that fixes a request the access flow does
+// not accept.
+func validateRequest(ctx context.Context, client *sdk.Client, req *clientapi.CreateAccessRequestClientModel) error {
+	err := client.ValidateRequest(ctx, req)
+	switch {
+	case errors.Is(err, sdk.ErrJustificationRequired):
+		return fmt.Errorf("%w, please use the --%s flag", err, justificationFlagName)
+	case errors.Is(err, sdk.ErrDurationRequired):
+		return fmt.Errorf("%w, please use the --%s flag", err, durationFlagName)
 	}
 
-	return nil
+	return err
 }
 
 func validateIntegrationRequestFlagCombinations(flags *createRequestFlags) error {
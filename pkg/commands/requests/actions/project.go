@@ -0,0 +1,113 @@
+package actions
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/config"
+)
+
+// justificationTemplateData is what the justification template of the project
+// file is rendered with.
+type justificationTemplateData struct {
+	Project string
+	Branch  string
+	Preset  string
+}
+
+// applyProjectDefaults fills the flags that were not set on the command line
+// from the .apono.yaml project file and the selected preset.
+func applyProjectDefaults(cmd *cobra.Command, cmdFlags *createRequestFlags, preset string) error {
+	project, err := config.GetProjectConfig()
+	if err != nil {
+		return err
+	}
+	if project == nil {
+		if preset != "" {
+			return fmt.Errorf("--%s requires a %s file in the current directory or one of its parents", presetFlagName, config.ProjectFileName)
+		}
+		return nil
+	}
+
+	defaults, err := project.RequestDefaultsFor(preset)
+	if err != nil {
+		return err
+	}
+
+	flags := cmd.Flags()
+	if !flags.Changed(integrationFlagName) && !flags.Changed(bundleFlagName) {
+		cmdFlags.integrationIDOrName = defaults.Integration
+		cmdFlags.bundleIDOrName = defaults.Bundle
+		if !flags.Changed(resourceTypeFlagName) {
+			cmdFlags.resourceType = defaults.ResourceType
+		}
+		if !flags.Changed(resourceFlagName) {
+			cmdFlags.resourceIDs = defaults.Resources
+		}
+		if !flags.Changed(permissionFlagName) {
+			cmdFlags.permissionIDs = defaults.Permissions
+		}
+	}
+
+	if defaults.Justification != "" && !flags.Changed(justificationFlagName) {
+		justification, renderErr := renderJustification(cmd.Context(), project, defaults.Justification, preset)
+		if renderErr != nil {
+			return renderErr
+		}
+		cmdFlags.justification = justification
+	}
+
+	// The duration is set through the flag, as only a changed duration flag is
+	// sent with the request.
+	if defaults.Duration != "" && !flags.Changed(durationFlagName) {
+		if err = flags.Set(durationFlagName, defaults.Duration); err != nil {
+			return fmt.Errorf("invalid duration %q in %s: %w", defaults.Duration, project.Path, err)
+		}
+	}
+
+	// Custom fields given on the command line come last, so they win.
+	customFields := make([]string, 0, len(defaults.CustomFields)+len(cmdFlags.customFields))
+	for key, value := range defaults.CustomFields {
+		customFields = append(customFields, fmt.Sprintf("%s=%s", key, value))
+	}
+	cmdFlags.customFields = append(customFields, cmdFlags.customFields...)
+
+	return nil
+}
+
+func renderJustification(ctx context.Context, project *config.ProjectConfig, text, preset string) (string, error) {
+	tmpl, err := template.New("justification").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid justification template in %s: %w", project.Path, err)
+	}
+
+	projectDir := filepath.Dir(project.Path)
+	data := justificationTemplateData{
+		Project: filepath.Base(projectDir),
+		Branch:  gitBranch(ctx, projectDir),
+		Preset:  preset,
+	}
+
+	var rendered bytes.Buffer
+	if err = tmpl.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("render justification template of %s: %w", project.Path, err)
+	}
+	return strings.TrimSpace(rendered.String()), nil
+}
+
+// gitBranch returns the checked out branch of the repo at dir, or an empty
+// string outside of a git repo.
+func gitBranch(ctx context.Context, dir string) string {
+	out, err := exec.CommandContext(ctx, "git", "-C", dir, "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/apono-io/apono-cli/pkg/services"
+	"github.com/apono-io/apono-cli/pkg/sdk"
 
 	"github.com/spf13/cobra"
 
@@ -34,14 +34,15 @@ func Revoke() *cobra.Command {
 				return err
 			}
 
+			sdkClient := sdk.NewClientFromAPI(client)
 			requestID := args[0]
-			err = services.RevokeRequest(cmd.Context(), client, requestID)
+			err = sdkClient.Revoke(cmd.Context(), requestID)
 			if err != nil {
 				return err
 			}
 
 			if wait {
-				err = waitForRequestToBeRevoked(cmd, client, requestID, waitTimeout)
+				_, err = sdkClient.WaitForRevoke(cmd.Context(), requestID, sdk.WaitOptions{Timeout: waitTimeout})
 				if err != nil {
 					return err
 				}
@@ -67,26 +68,3 @@ func Revoke() *cobra.Command {
 
 	return cmd
 }
-
-func waitForRequestToBeRevoked(cmd *cobra.Command, client *aponoapi.AponoClient, requestID string, timeout time.Duration) error {
-	startTime := time.Now()
-	for {
-		accessRequest, _, err := client.ClientAPI.AccessRequestsAPI.GetAccessRequest(cmd.Context(), requestID).Execute()
-		if err != nil {
-			return err
-		}
-
-		if accessRequest.Status.Status == services.AccessRequestRevokedStatus {
-			return nil
-		}
-		if accessRequest.Status.Status == services.AccessRequestFailedStatus {
-			return fmt.Errorf("request failed to revoke")
-		}
-
-		time.Sleep(1 * time.Second)
-
-		if time.Now().After(startTime.Add(timeout)) {
-			return fmt.Errorf("timeout while waiting for request to be revoked")
-		}
-	}
-}
@@ -7,7 +7,6 @@ import (
 	"github.com/apono-io/apono-cli/pkg/services"
 	"github.com/apono-io/apono-cli/pkg/utils"
 
-	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 )
 
@@ -44,28 +43,31 @@ func printRequestAccessUnits(cmd *cobra.Command, client *aponoapi.AponoClient, r
 		return err
 	}
 
-	switch format {
-	case utils.TableFormat:
-		table := uitable.New()
-		table.AddRow("RESOURCE ID", "RESOURCE TYPE", "RESOURCE NAME", "PERMISSION", "INTEGRATION NAME", "INTEGRATION TYPE")
-		for _, requestAccessUnit := range requestAccessUnits {
-			table.AddRow(
-				requestAccessUnit.Resource.SourceId,
-				requestAccessUnit.Resource.Type.Id,
-				requestAccessUnit.Resource.Name,
-				requestAccessUnit.Permission.Id,
-				requestAccessUnit.Resource.Integration.Name,
-				requestAccessUnit.Resource.Integration.Type,
-			)
+	table := utils.NewTable(
+		utils.Column("RESOURCE ID"),
+		utils.Column("RESOURCE TYPE"),
+		utils.Column("RESOURCE NAME"),
+		utils.Column("PERMISSION"),
+		utils.Column("INTEGRATION NAME"),
+		utils.Column("INTEGRATION TYPE"),
+		utils.WideColumn("STATUS"),
+	)
+	for _, requestAccessUnit := range requestAccessUnits {
+		status := "NA"
+		if requestAccessUnit.Status.IsSet() && requestAccessUnit.Status.Get() != nil {
+			status = requestAccessUnit.Status.Get().Status
 		}
 
-		_, err := fmt.Fprintln(cmd.OutOrStdout(), table)
-		return err
-	case utils.JSONFormat:
-		return utils.PrintObjectsAsJSON(cmd.OutOrStdout(), requestAccessUnits)
-	case utils.YamlFormat:
-		return utils.PrintObjectsAsYaml(cmd.OutOrStdout(), requestAccessUnits)
-	default:
-		return fmt.Errorf("unsupported output format")
+		table.AddRow(
+			requestAccessUnit.Resource.SourceId,
+			requestAccessUnit.Resource.Type.Id,
+			requestAccessUnit.Resource.Name,
+			requestAccessUnit.Permission.Id,
+			requestAccessUnit.Resource.Integration.Name,
+			requestAccessUnit.Resource.Integration.Type,
+			status,
+		)
 	}
+
+	return utils.PrintObjects(cmd.OutOrStdout(), format, requestAccessUnits, table)
 }
@@ -0,0 +1,133 @@
+package actions
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/services"
+)
+
+func VaultCopy() *cobra.Command {
+	return vaultTransferCommand("cp", "Copy a secret or a subtree of secrets", false)
+}
+
+func VaultMove() *cobra.Command {
+	return vaultTransferCommand("mv", "Move a secret or a subtree of secrets", true)
+}
+
+func vaultTransferCommand(use, short string, deleteSource bool) *cobra.Command {
+	transferFlags := &vaultTransferFlags{}
+	var vaultID string
+	var destroySource bool
+
+	long := short + `.
+When the source is a folder, every secret under it is copied to the same relative path under the destination.`
+	if deleteSource {
+		long += `
+The latest version of each moved source is soft-deleted, as with 'apono vault delete', and can be restored with 'apono vault undelete'. Use --destroy-source to permanently delete the sources with their metadata and all of their versions.`
+	}
+
+	cmd := &cobra.Command{
+		Use:   use + " <source> <destination>",
+		Short: short,
+		Long:  long,
+		Args:  requireTwoPathArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			sourcePath, destinationPath := args[0], args[1]
+
+			client, err := aponoapi.GetClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			vc, creds, err := services.ResolveVaultClient(ctx, client, vaultID)
+			if err != nil {
+				return err
+			}
+
+			sourceMount, sourcePrefix := services.ParseVaultPrefix(sourcePath)
+			destinationMount, _ := services.ParseVaultPrefix(destinationPath)
+			for _, mount := range []string{sourceMount, destinationMount} {
+				if err = validateMount(mount, creds); err != nil {
+					return err
+				}
+			}
+
+			tree, err := vc.ReadSubtree(ctx, sourceMount, sourcePrefix)
+			if err != nil {
+				return err
+			}
+
+			var writes []services.VaultWrite
+			for _, relativePath := range tree.Paths() {
+				write := services.VaultWrite{
+					Source: services.JoinVaultPath(sourcePath, relativePath),
+					Target: services.JoinVaultPath(destinationPath, relativePath),
+					Data:   tree[relativePath],
+				}
+				if write.Source == write.Target {
+					return fmt.Errorf("source and destination are the same: %s", write.Source)
+				}
+				if _, _, err = services.ParseVaultPath(write.Target); err != nil {
+					return err
+				}
+
+				writes = append(writes, write)
+			}
+
+			plan, err := transferFlags.planAndApply(ctx, cmd, vc, writes)
+			if err != nil || !deleteSource || transferFlags.dryRun {
+				return err
+			}
+
+			return deleteMovedSources(cmd, vc, plan, destroySource)
+		},
+	}
+
+	flags := cmd.Flags()
+	transferFlags.addFlags(flags)
+	flags.StringVar(&vaultID, "vault-id", "", "The vault integration name or ID")
+	if deleteSource {
+		flags.BoolVar(&destroySource, "destroy-source", false, "Permanently delete the sources with their metadata and all of their versions instead of soft-deleting them")
+	}
+	_ = cmd.MarkFlagRequired("vault-id")
+
+	return cmd
+}
+
+// deleteMovedSources soft-deletes the sources that were written to their
+// destination, or destroys them when destroy is set. Skipped sources are kept
+// so no data is lost.
+func deleteMovedSources(cmd *cobra.Command, vc *services.VaultClient, plan []services.VaultPlanItem, destroy bool) error {
+	kept := 0
+	for _, item := range plan {
+		if item.Action == services.VaultPlanSkip {
+			kept++
+			continue
+		}
+
+		mount, secretPath, err := services.ParseVaultPath(item.Source)
+		if err != nil {
+			return err
+		}
+
+		if destroy {
+			err = vc.DeleteSecret(cmd.Context(), mount, secretPath)
+		} else {
+			err = vc.SoftDeleteSecret(cmd.Context(), mount, secretPath, nil)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if kept > 0 {
+		_, err := fmt.Fprintf(cmd.OutOrStdout(), "%d skipped sources were kept in place\n", kept)
+		return err
+	}
+
+	return nil
+}
@@ -2,56 +2,78 @@ package actions
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
-
-	"github.com/apono-io/apono-cli/pkg/aponoapi"
-	"github.com/apono-io/apono-cli/pkg/services"
 )
 
 func VaultDelete() *cobra.Command {
 	var vaultID string
+	var versions []int
+	var allVersions bool
 
 	cmd := &cobra.Command{
 		Use:   "delete <path>",
 		Short: "Delete a secret from a vault",
-		Args:  requirePathArg,
+		Long: `Delete a secret from a vault.
+By default the latest version is soft-deleted and can be restored with 'apono vault undelete'.
+Use --all-versions to permanently delete the secret with its metadata and all of its versions.`,
+		Args: requirePathArg,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx := cmd.Context()
 			secretPath := args[0]
-
-			client, err := aponoapi.GetClient(ctx)
-			if err != nil {
-				return err
+			if allVersions && len(versions) > 0 {
+				return fmt.Errorf("--versions cannot be used with --all-versions")
 			}
 
-			vc, creds, err := services.ResolveVaultClient(ctx, client, vaultID)
+			vc, mount, secretName, err := resolveSecret(cmd, vaultID, secretPath)
 			if err != nil {
 				return err
 			}
 
-			mount, secretName, err := services.ParseVaultPath(secretPath)
-			if err != nil {
-				return err
-			}
+			if allVersions {
+				if err = vc.DeleteSecret(cmd.Context(), mount, secretName); err != nil {
+					return err
+				}
 
-			if err = validateMount(mount, creds); err != nil {
+				_, err = fmt.Fprintf(cmd.OutOrStdout(), "Secret %q and all of its versions deleted permanently\n", secretPath)
 				return err
 			}
 
-			err = vc.DeleteSecret(ctx, mount, secretName)
-			if err != nil {
+			if err = vc.SoftDeleteSecret(cmd.Context(), mount, secretName, versions); err != nil {
 				return err
 			}
 
-			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Secret %q deleted successfully\n", secretPath)
+			_, err = fmt.Fprint(cmd.OutOrStdout(), softDeleteMessage(vaultID, secretPath, versions))
 			return err
 		},
 	}
 
 	flags := cmd.Flags()
 	flags.StringVar(&vaultID, "vault-id", "", "The vault integration name or ID")
+	flags.IntSliceVar(&versions, "versions", nil, "The versions to soft-delete, defaults to the latest version")
+	flags.BoolVar(&allVersions, "all-versions", false, "Permanently delete the secret metadata and all of its versions")
 	_ = cmd.MarkFlagRequired("vault-id")
 
 	return cmd
 }
+
+// softDeleteMessage tells how to restore the soft-deleted versions, or how to
+// remove them for good. The latest version is only known by its number, which
+// `apono vault history` lists.
+func softDeleteMessage(vaultID, secretPath string, versions []int) string {
+	versionsFlag := "<version>"
+	if len(versions) > 0 {
+		texts := make([]string, len(versions))
+		for i, version := range versions {
+			texts[i] = strconv.Itoa(version)
+		}
+		versionsFlag = strings.Join(texts, ",")
+	}
+
+	args := fmt.Sprintf("%s --vault-id %s --versions %s", secretPath, vaultID, versionsFlag)
+	return fmt.Sprintf("Secret %q %s soft-deleted successfully\n"+
+		"It can be restored with: apono vault undelete %s\n"+
+		"To remove it permanently, run: apono vault destroy %s\n",
+		secretPath, versionsText(versions), args, args)
+}
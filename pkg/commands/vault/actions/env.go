@@ -0,0 +1,104 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/services"
+)
+
+const secretFlagName = "secret"
+
+type vaultEnvFlags struct {
+	vaultID     string
+	secretPaths []string
+	prefix      string
+	renames     []string
+	keepCase    bool
+}
+
+func (f *vaultEnvFlags) addFlags(cmd *cobra.Command, flags *pflag.FlagSet) {
+	flags.StringVar(&f.vaultID, "vault-id", "", "The vault integration name or ID")
+	flags.StringArrayVar(&f.secretPaths, secretFlagName, []string{}, "Secret path to load, e.g. apono-store/app/db. Can be repeated, later secrets override earlier ones")
+	flags.StringVar(&f.prefix, "prefix", "", "Prefix added to every environment variable name")
+	flags.StringArrayVar(&f.renames, "rename", []string{}, "Rename a secret key, in the format 'secret-key=ENV_NAME'. Can be repeated")
+	flags.BoolVar(&f.keepCase, "keep-case", false, "Keep the case of secret keys instead of upper-casing them")
+	_ = cmd.MarkFlagRequired("vault-id")
+	_ = cmd.MarkFlagRequired(secretFlagName)
+}
+
+// resolveEnv reads the requested secrets and maps them to environment
+// variables. Secrets are kept in memory only.
+func (f *vaultEnvFlags) resolveEnv(ctx context.Context) (map[string]string, error) {
+	renames, err := services.ParseVaultEnvRenames(f.renames)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := aponoapi.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	vc, creds, err := services.ResolveVaultClient(ctx, client, f.vaultID)
+	if err != nil {
+		return nil, err
+	}
+
+	var secrets []map[string]interface{}
+	for _, secretPath := range f.secretPaths {
+		mount, secretName, parseErr := services.ParseVaultPath(secretPath)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+
+		if err = validateMount(mount, creds); err != nil {
+			return nil, err
+		}
+
+		secret, readErr := vc.ReadSecret(ctx, mount, secretName)
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read secret %q: %w", secretPath, readErr)
+		}
+
+		secrets = append(secrets, secret)
+	}
+
+	return services.VaultSecretsToEnv(secrets, services.VaultEnvOptions{
+		Prefix:    f.prefix,
+		Renames:   renames,
+		Uppercase: !f.keepCase,
+	})
+}
+
+func VaultEnv() *cobra.Command {
+	envFlags := &vaultEnvFlags{}
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "env",
+		Short: "Print vault secrets as environment variables",
+		Long: `Print vault secrets as environment variables, for example:
+
+  eval "$(apono vault env --vault-id my-vault --secret apono-store/app/db)"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			env, err := envFlags.resolveEnv(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			return services.PrintVaultEnv(cmd.OutOrStdout(), env, format)
+		},
+	}
+
+	flags := cmd.Flags()
+	envFlags.addFlags(cmd, flags)
+	flags.StringVar(&format, "format", services.VaultEnvExportFormat, "Output format. Valid values are 'export', 'dotenv' or 'json'")
+
+	return cmd
+}
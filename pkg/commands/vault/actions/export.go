@@ -0,0 +1,92 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/services"
+)
+
+const exportedFilePermission = 0o600
+
+func VaultExport() *cobra.Command {
+	var vaultID string
+	var format string
+	var outputFile string
+	var outputDir string
+
+	cmd := &cobra.Command{
+		Use:   "export <path>",
+		Short: "Export a secret or a subtree of secrets",
+		Long: `Export a secret or a subtree of secrets as dotenv, JSON or YAML.
+
+A subtree is exported as a single document keyed by secret path, or as one file
+per secret with --output-dir. Exported files contain plaintext secrets.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			sourcePath := args[0]
+
+			client, err := aponoapi.GetClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			vc, creds, err := services.ResolveVaultClient(ctx, client, vaultID)
+			if err != nil {
+				return err
+			}
+
+			mount, prefix := services.ParseVaultPrefix(sourcePath)
+			if err = validateMount(mount, creds); err != nil {
+				return err
+			}
+
+			tree, err := vc.ReadSubtree(ctx, mount, prefix)
+			if err != nil {
+				return err
+			}
+
+			if len(tree) == 0 {
+				return fmt.Errorf("no secrets found under %s", sourcePath)
+			}
+
+			if outputDir != "" {
+				written, writeErr := services.WriteVaultSecretDir(outputDir, tree, format, path.Base(prefix))
+				if writeErr != nil {
+					return writeErr
+				}
+
+				_, err = fmt.Fprintf(cmd.OutOrStdout(), "Exported %d secrets to %s\n", len(written), outputDir)
+				return err
+			}
+
+			content, err := services.EncodeVaultSecretTree(tree, format)
+			if err != nil {
+				return err
+			}
+
+			if outputFile != "" {
+				return os.WriteFile(filepath.Clean(outputFile), content, exportedFilePermission)
+			}
+
+			_, err = cmd.OutOrStdout().Write(content)
+			return err
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&vaultID, "vault-id", "", "The vault integration name or ID")
+	flags.StringVar(&format, "format", services.VaultFileJSONFormat, "Output format. Valid values are 'dotenv', 'json' or 'yaml'")
+	flags.StringVarP(&outputFile, "file", "f", "", "Write the export to a file instead of stdout")
+	flags.StringVar(&outputDir, "output-dir", "", "Write one file per secret under this directory")
+	_ = cmd.MarkFlagRequired("vault-id")
+	cmd.MarkFlagsMutuallyExclusive("file", "output-dir")
+
+	return cmd
+}
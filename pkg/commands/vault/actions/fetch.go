@@ -3,71 +3,44 @@ package actions
 import (
 	"fmt"
 
-	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 
-	"github.com/apono-io/apono-cli/pkg/aponoapi"
-	"github.com/apono-io/apono-cli/pkg/services"
 	"github.com/apono-io/apono-cli/pkg/utils"
 )
 
 func VaultFetch() *cobra.Command {
 	format := new(utils.Format)
 	var vaultID string
+	var version int
 
 	cmd := &cobra.Command{
 		Use:   "fetch <path>",
 		Short: "Fetch a secret from a vault",
 		Args:  requirePathArg,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			ctx := cmd.Context()
-			secretPath := args[0]
-
-			client, err := aponoapi.GetClient(ctx)
+			vc, mount, secretName, err := resolveSecret(cmd, vaultID, args[0])
 			if err != nil {
 				return err
 			}
 
-			vc, creds, err := services.ResolveVaultClient(ctx, client, vaultID)
+			result, err := vc.ReadSecretVersion(cmd.Context(), mount, secretName, version)
 			if err != nil {
 				return err
 			}
 
-			mount, secretName, err := services.ParseVaultPath(secretPath)
-			if err != nil {
-				return err
+			table := utils.NewTable(utils.Column("KEY"), utils.Column("VALUE"))
+			for k, v := range result {
+				table.AddRow(k, fmt.Sprintf("%v", v))
 			}
 
-			if err = validateMount(mount, creds); err != nil {
-				return err
-			}
-
-			result, err := vc.ReadSecret(ctx, mount, secretName)
-			if err != nil {
-				return err
-			}
-
-			switch *format {
-			case utils.JSONFormat:
-				return utils.PrintObjectsAsJSON(cmd.OutOrStdout(), result)
-			case utils.YamlFormat:
-				return utils.PrintObjectsAsYaml(cmd.OutOrStdout(), result)
-			default:
-				table := uitable.New()
-				table.AddRow("KEY", "VALUE")
-				for k, v := range result {
-					table.AddRow(k, fmt.Sprintf("%v", v))
-				}
-
-				_, err = fmt.Fprintln(cmd.OutOrStdout(), table)
-				return err
-			}
+			return utils.PrintObjects(cmd.OutOrStdout(), *format, result, table)
 		},
 	}
 
 	flags := cmd.Flags()
 	utils.AddFormatFlag(flags, format)
 	flags.StringVar(&vaultID, "vault-id", "", "The vault integration name or ID")
+	flags.IntVar(&version, "version", 0, "The secret version to fetch, defaults to the latest version")
 	_ = cmd.MarkFlagRequired("vault-id")
 
 	return cmd
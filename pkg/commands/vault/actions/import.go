@@ -0,0 +1,115 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/services"
+)
+
+func VaultImport() *cobra.Command {
+	transferFlags := &vaultTransferFlags{}
+	var vaultID string
+	var targetPath string
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "import <file|directory>",
+		Short: "Import secrets from dotenv, JSON or YAML files",
+		Long: `Import secrets from a dotenv, JSON or YAML file, or from a directory of such files.
+
+A dotenv file is imported as a single secret. A JSON or YAML file is imported as a single
+secret, unless all of its values are objects, in which case each key is a secret path.
+In a directory, the path of each file without its extension becomes the secret path.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			source := args[0]
+
+			tree, err := loadImportSource(source, format)
+			if err != nil {
+				return err
+			}
+
+			client, err := aponoapi.GetClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			vc, creds, err := services.ResolveVaultClient(ctx, client, vaultID)
+			if err != nil {
+				return err
+			}
+
+			mount, _ := services.ParseVaultPrefix(targetPath)
+			if err = validateMount(mount, creds); err != nil {
+				return err
+			}
+
+			var writes []services.VaultWrite
+			for _, relativePath := range tree.Paths() {
+				target := services.JoinVaultPath(targetPath, relativePath)
+				if _, _, err = services.ParseVaultPath(target); err != nil {
+					return err
+				}
+
+				writes = append(writes, services.VaultWrite{
+					Source: services.JoinVaultPath(source, relativePath),
+					Target: target,
+					Data:   tree[relativePath],
+				})
+			}
+
+			_, err = transferFlags.planAndApply(ctx, cmd, vc, writes)
+			return err
+		},
+	}
+
+	flags := cmd.Flags()
+	transferFlags.addFlags(flags)
+	flags.StringVar(&vaultID, "vault-id", "", "The vault integration name or ID")
+	flags.StringVar(&targetPath, "to", "", "The vault path to import into, e.g. apono-store/app")
+	flags.StringVar(&format, "format", "", "The file format: 'dotenv', 'json' or 'yaml'. Detected from the file extension by default")
+	_ = cmd.MarkFlagRequired("vault-id")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func loadImportSource(source string, format string) (services.VaultSecretTree, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		if format != "" {
+			return nil, fmt.Errorf("--format cannot be used when importing a directory")
+		}
+		return services.LoadVaultSecretDir(source)
+	}
+
+	if format == "" {
+		format, err = services.VaultFileFormatFromPath(source)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Clean(source))
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := services.ParseVaultSecretFile(content, strings.ToLower(format))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", source, err)
+	}
+
+	return tree, nil
+}
@@ -3,7 +3,6 @@ package actions
 import (
 	"fmt"
 
-	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
@@ -51,21 +50,12 @@ func VaultList() *cobra.Command {
 				paths[i] = mountName + "/" + key
 			}
 
-			switch *format {
-			case utils.JSONFormat:
-				return utils.PrintObjectsAsJSON(cmd.OutOrStdout(), paths)
-			case utils.YamlFormat:
-				return utils.PrintObjectsAsYaml(cmd.OutOrStdout(), paths)
-			default:
-				table := uitable.New()
-				table.AddRow("SECRET PATH")
-				for _, p := range paths {
-					table.AddRow(p)
-				}
-
-				_, err = fmt.Fprintln(cmd.OutOrStdout(), table)
-				return err
+			table := utils.NewTable(utils.Column("SECRET PATH"))
+			for _, p := range paths {
+				table.AddRow(p)
 			}
+
+			return utils.PrintObjects(cmd.OutOrStdout(), *format, paths, table)
 		},
 	}
 
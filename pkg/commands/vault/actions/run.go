@@ -0,0 +1,75 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+func VaultRun() *cobra.Command {
+	envFlags := &vaultEnvFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "run -- <command> [args...]",
+		Short: "Run a command with vault secrets injected as environment variables",
+		Long: `Run a command with vault secrets injected as environment variables.
+The secrets are passed only to the child process environment and are never written to disk.
+
+  apono vault run --vault-id my-vault --secret apono-store/app/db -- ./migrate.sh`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("requires a command to run, e.g.: apono vault run --secret <mount>/<secret> -- <command>")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			env, err := envFlags.resolveEnv(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			return runWithEnv(cmd, args, env)
+		},
+	}
+
+	flags := cmd.Flags()
+	envFlags.addFlags(cmd, flags)
+	flags.SetInterspersed(false)
+
+	return cmd
+}
+
+func runWithEnv(cmd *cobra.Command, args []string, env map[string]string) error {
+	// The child is not bound to the command context: Ctrl+C reaches it directly
+	// through the terminal and it decides how to shut down.
+	child := exec.CommandContext(context.Background(), args[0], args[1:]...) //nolint:gosec // runs the command requested by the user
+	child.Stdin = cmd.InOrStdin()
+	child.Stdout = cmd.OutOrStdout()
+	child.Stderr = cmd.ErrOrStderr()
+	child.Env = os.Environ()
+	for name, value := range env {
+		child.Env = append(child.Env, name+"="+value)
+	}
+
+	err := child.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		code := exitErr.ExitCode()
+		if code < 0 {
+			code = 1
+		}
+		return &utils.ExitCodeError{Code: code}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to run %s: %w", args[0], err)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/apono-io/apono-cli/pkg/services"
+)
+
+type vaultTransferFlags struct {
+	conflict string
+	dryRun   bool
+}
+
+func (f *vaultTransferFlags) addFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&f.conflict, "conflict", string(services.VaultConflictFail), "What to do when a target secret already exists. Valid values are 'skip', 'overwrite' or 'fail'")
+	flags.BoolVar(&f.dryRun, "dry-run", false, "Print the plan without writing any secret")
+}
+
+// planAndApply plans the writes, applies them unless this is a dry run, and
+// prints the plan. It returns the applied plan.
+func (f *vaultTransferFlags) planAndApply(ctx context.Context, cmd *cobra.Command, vc *services.VaultClient, writes []services.VaultWrite) ([]services.VaultPlanItem, error) {
+	policy, err := services.ParseVaultConflictPolicy(f.conflict)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := vc.PlanWrites(ctx, writes, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	if !f.dryRun {
+		if err = vc.ApplyPlan(ctx, plan); err != nil {
+			return nil, err
+		}
+	}
+
+	return plan, services.PrintVaultPlan(cmd.OutOrStdout(), plan, f.dryRun)
+}
+
+func requireTwoPathArgs(cmd *cobra.Command, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("requires a source and a destination path, e.g.: apono vault %s <mount>/<source> <mount>/<destination>", cmd.Name())
+	}
+
+	return nil
+}
@@ -114,3 +114,29 @@ func vaultWriteCommand(use, short, pastTense string, requireNew bool) *cobra.Com
 
 	return cmd
 }
+
+// resolveSecret resolves the vault client for vaultID and splits secretPath
+// into a mount the session has access to and the secret name.
+func resolveSecret(cmd *cobra.Command, vaultID, secretPath string) (*services.VaultClient, string, string, error) {
+	ctx := cmd.Context()
+	client, err := aponoapi.GetClient(ctx)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	vc, creds, err := services.ResolveVaultClient(ctx, client, vaultID)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	mount, secretName, err := services.ParseVaultPath(secretPath)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	if err = validateMount(mount, creds); err != nil {
+		return nil, "", "", err
+	}
+
+	return vc, mount, secretName, nil
+}
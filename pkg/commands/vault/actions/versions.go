@@ -0,0 +1,255 @@
+package actions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/services"
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+func VaultHistory() *cobra.Command {
+	format := new(utils.Format)
+	var vaultID string
+
+	cmd := &cobra.Command{
+		Use:   "history <path>",
+		Short: "List the versions of a secret",
+		Args:  requirePathArg,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			vc, mount, secretName, err := resolveSecret(cmd, vaultID, args[0])
+			if err != nil {
+				return err
+			}
+
+			metadata, err := vc.ReadSecretMetadata(cmd.Context(), mount, secretName)
+			if err != nil {
+				return err
+			}
+
+			table := utils.NewTable(
+				utils.Column("VERSION"),
+				utils.Column("CREATED"),
+				utils.Column("STATE"),
+				utils.Column("CURRENT"),
+				utils.WideColumn("DELETED"),
+			)
+			for _, version := range metadata.Versions {
+				deleted := ""
+				if version.DeletionTime != nil {
+					deleted = utils.DisplayTime(*version.DeletionTime)
+				}
+
+				current := ""
+				if version.Current {
+					current = "*"
+				}
+
+				table.AddRow(version.Version, utils.DisplayTime(version.CreatedTime), version.State(), current, deleted)
+			}
+
+			return utils.PrintObjects(cmd.OutOrStdout(), *format, metadata, table)
+		},
+	}
+
+	flags := cmd.Flags()
+	utils.AddFormatFlag(flags, format)
+	flags.StringVar(&vaultID, "vault-id", "", "The vault integration name or ID")
+	_ = cmd.MarkFlagRequired("vault-id")
+
+	return cmd
+}
+
+func VaultDiff() *cobra.Command {
+	format := new(utils.Format)
+	var vaultID string
+	var showValues bool
+
+	cmd := &cobra.Command{
+		Use:   "diff <path> [from-version] [to-version]",
+		Short: "Show the differences between two versions of a secret",
+		Long: `Show the differences between two versions of a secret.
+Without versions the current version is compared to the one before it, and with a single version it is compared to the current version.
+Values are masked unless --show-values is set.`,
+		Args: cobra.RangeArgs(1, 3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			versions, err := parseVersionArgs(args[1:])
+			if err != nil {
+				return err
+			}
+
+			vc, mount, secretName, err := resolveSecret(cmd, vaultID, args[0])
+			if err != nil {
+				return err
+			}
+
+			if len(versions) < 2 {
+				metadata, metadataErr := vc.ReadSecretMetadata(ctx, mount, secretName)
+				if metadataErr != nil {
+					return metadataErr
+				}
+
+				if len(versions) == 0 {
+					versions = append(versions, metadata.CurrentVersion-1)
+				}
+				versions = append(versions, metadata.CurrentVersion)
+			}
+
+			if versions[0] < 1 {
+				return fmt.Errorf("secret %q has a single version, nothing to compare", args[0])
+			}
+
+			fromData, err := vc.ReadSecretVersion(ctx, mount, secretName, versions[0])
+			if err != nil {
+				return err
+			}
+
+			toData, err := vc.ReadSecretVersion(ctx, mount, secretName, versions[1])
+			if err != nil {
+				return err
+			}
+
+			changes := services.DiffVaultSecrets(fromData, toData)
+			if !showValues {
+				changes = services.MaskVaultSecretChanges(changes)
+			}
+
+			if format.IsTable() {
+				_, err = fmt.Fprintf(cmd.OutOrStdout(), "Comparing version %d to version %d\n", versions[0], versions[1])
+				if err != nil {
+					return err
+				}
+
+				return services.PrintVaultSecretDiff(cmd.OutOrStdout(), changes)
+			}
+
+			return utils.PrintObjects(cmd.OutOrStdout(), *format, changes, nil)
+		},
+	}
+
+	flags := cmd.Flags()
+	utils.AddFormatFlag(flags, format)
+	flags.StringVar(&vaultID, "vault-id", "", "The vault integration name or ID")
+	flags.BoolVar(&showValues, "show-values", false, "Show the secret values instead of masking them")
+	_ = cmd.MarkFlagRequired("vault-id")
+
+	return cmd
+}
+
+func VaultRollback() *cobra.Command {
+	var vaultID string
+	var toVersion int
+
+	cmd := &cobra.Command{
+		Use:   "rollback <path>",
+		Short: "Restore a previous version of a secret",
+		Long: `Restore a previous version of a secret.
+The data of the given version is written as a new version, so the history is kept.`,
+		Args: requirePathArg,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if toVersion < 1 {
+				return fmt.Errorf("--to must be a positive version number")
+			}
+
+			vc, mount, secretName, err := resolveSecret(cmd, vaultID, args[0])
+			if err != nil {
+				return err
+			}
+
+			newVersion, err := vc.RollbackSecret(cmd.Context(), mount, secretName, toVersion)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Secret %q rolled back to version %d as version %d\n", args[0], toVersion, newVersion)
+			return err
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&vaultID, "vault-id", "", "The vault integration name or ID")
+	flags.IntVar(&toVersion, "to", 0, "The version to restore")
+	_ = cmd.MarkFlagRequired("vault-id")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func VaultUndelete() *cobra.Command {
+	return vaultVersionsCommand("undelete", "Restore soft-deleted versions of a secret", "restored",
+		func(cmd *cobra.Command, vc *services.VaultClient, mount, secretName string, versions []int) error {
+			return vc.UndeleteSecret(cmd.Context(), mount, secretName, versions)
+		})
+}
+
+func VaultDestroy() *cobra.Command {
+	return vaultVersionsCommand("destroy", "Permanently destroy versions of a secret", "destroyed",
+		func(cmd *cobra.Command, vc *services.VaultClient, mount, secretName string, versions []int) error {
+			return vc.DestroySecret(cmd.Context(), mount, secretName, versions)
+		})
+}
+
+func vaultVersionsCommand(use, short, pastTense string, apply func(cmd *cobra.Command, vc *services.VaultClient, mount, secretName string, versions []int) error) *cobra.Command {
+	var vaultID string
+	var versions []int
+
+	cmd := &cobra.Command{
+		Use:   use + " <path>",
+		Short: short,
+		Args:  requirePathArg,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			vc, mount, secretName, err := resolveSecret(cmd, vaultID, args[0])
+			if err != nil {
+				return err
+			}
+
+			if err = apply(cmd, vc, mount, secretName, versions); err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Secret %q %s %s successfully\n", args[0], versionsText(versions), pastTense)
+			return err
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&vaultID, "vault-id", "", "The vault integration name or ID")
+	flags.IntSliceVar(&versions, "versions", nil, "The versions to "+use)
+	_ = cmd.MarkFlagRequired("vault-id")
+	_ = cmd.MarkFlagRequired("versions")
+
+	return cmd
+}
+
+func parseVersionArgs(args []string) ([]int, error) {
+	versions := make([]int, 0, len(args))
+	for _, arg := range args {
+		version, err := strconv.Atoi(arg)
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("invalid version %q, expected a positive number", arg)
+		}
+
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+func versionsText(versions []int) string {
+	switch len(versions) {
+	case 0:
+		return "latest version"
+	case 1:
+		return fmt.Sprintf("version %d", versions[0])
+	default:
+		texts := make([]string, len(versions))
+		for i, version := range versions {
+			texts[i] = strconv.Itoa(version)
+		}
+		return "versions " + strings.Join(texts, ", ")
+	}
+}
@@ -17,6 +17,17 @@ func (c *Configurator) ConfigureCommands(rootCmd *cobra.Command) error {
 	vaultCmd.AddCommand(actions.VaultCreate())
 	vaultCmd.AddCommand(actions.VaultUpdate())
 	vaultCmd.AddCommand(actions.VaultDelete())
+	vaultCmd.AddCommand(actions.VaultUndelete())
+	vaultCmd.AddCommand(actions.VaultDestroy())
+	vaultCmd.AddCommand(actions.VaultHistory())
+	vaultCmd.AddCommand(actions.VaultDiff())
+	vaultCmd.AddCommand(actions.VaultRollback())
+	vaultCmd.AddCommand(actions.VaultEnv())
+	vaultCmd.AddCommand(actions.VaultRun())
+	vaultCmd.AddCommand(actions.VaultImport())
+	vaultCmd.AddCommand(actions.VaultExport())
+	vaultCmd.AddCommand(actions.VaultCopy())
+	vaultCmd.AddCommand(actions.VaultMove())
 
 	return nil
 }
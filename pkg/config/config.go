@@ -24,6 +24,44 @@ type Config struct {
 	Auth                   AuthConfig          `json:"auth"`
 	Notifications          NotificationsConfig `json:"notifications"`
 	AccessHandlerAnnounced bool                `json:"access_handler_announced,omitempty"`
+	Launchers              []LauncherConfig    `json:"launchers,omitempty"`
+	Multiplexer            MultiplexerConfig   `json:"multiplexer,omitempty"`
+	Daemon                 DaemonConfig        `json:"daemon,omitempty"`
+	Secrets                SecretsConfig       `json:"secrets,omitempty"`
+}
+
+// DaemonConfig controls the notifications and hooks of `apono daemon`.
+type DaemonConfig struct {
+	ExpiryWarning        string       `json:"expiry_warning,omitempty"`
+	DesktopNotifications *bool        `json:"desktop_notifications,omitempty"`
+	Hooks                []HookConfig `json:"hooks,omitempty"`
+}
+
+// HookConfig is a shell command `apono daemon` runs on the given events, with
+// the event JSON on stdin.
+type HookConfig struct {
+	Name    string   `json:"name"`
+	Events  []string `json:"events"`
+	Command string   `json:"command"`
+}
+
+// MultiplexerConfig controls how sessions open when the CLI runs inside tmux
+// or screen.
+type MultiplexerConfig struct {
+	Mode     string `json:"mode,omitempty"`
+	OnExpiry string `json:"on_expiry,omitempty"`
+}
+
+// LauncherConfig is a user defined client for `apono access use --client`.
+// Commands are Go templates over the JSON access details of the session, and
+// may use the __APONO_PASSWORD__ placeholder like server provided launchers.
+type LauncherConfig struct {
+	ID               string `json:"id"`
+	DisplayName      string `json:"display_name,omitempty"`
+	Kind             string `json:"kind"`
+	AuthCommand      string `json:"auth_command,omitempty"`
+	Command          string `json:"command"`
+	PasswordEncoding string `json:"password_encoding,omitempty"`
 }
 
 type NotificationsConfig struct {
@@ -50,6 +88,9 @@ type SessionConfig struct {
 	Token         oauth2.Token `json:"token"`
 	CreatedAt     time.Time    `json:"created_at"`
 	PersonalToken string       `json:"personal_token"`
+	// SecretsBackend is where Token and PersonalToken are kept when they are
+	// not stored in config.json.
+	SecretsBackend string `json:"secrets_backend,omitempty"`
 }
 
 func (c SessionConfig) GetOAuth2Config() oauth2.Config {
@@ -71,7 +112,21 @@ func GetOAuthTokenURL(appURL string) string {
 	return fmt.Sprintf("%s/oauth/token", appURL)
 }
 
+func GetOAuthDeviceAuthURL(appURL string) string {
+	return fmt.Sprintf("%s/oauth/device/code", appURL)
+}
+
+// GetProfileByName returns the named profile, or the active one when the name
+// is empty, with the environment overrides applied. When APONO_TOKEN is set
+// and no profile is named, the config file is not read at all.
 func GetProfileByName(profileName ProfileName) (*SessionConfig, error) {
+	profileName = ResolveProfileName(profileName)
+	if profileName == "" {
+		if session := envSession(); session != nil {
+			return session, nil
+		}
+	}
+
 	cfg, err := Get()
 	if err != nil {
 		return nil, err
@@ -101,6 +156,11 @@ func GetProfileByName(profileName ProfileName) (*SessionConfig, error) {
 		return nil, fmt.Errorf("%s %s", pn, ErrProfileNotExists)
 	}
 
+	if err = LoadProfileSecrets(pn, &sessionCfg); err != nil {
+		return nil, err
+	}
+	applyEnvOverrides(&sessionCfg)
+
 	return &sessionCfg, nil
 }
 
@@ -113,12 +173,10 @@ func IsAccessHandlerAnnounced() bool {
 }
 
 func MarkAccessHandlerAnnounced() error {
-	cfg, err := Get()
-	if err != nil {
-		return err
-	}
-	cfg.AccessHandlerAnnounced = true
-	return Save(cfg)
+	return Update(func(cfg *Config) error {
+		cfg.AccessHandlerAnnounced = true
+		return nil
+	})
 }
 
 func IsFeatureAnnouncementNotificationsEnabled() bool {
@@ -133,10 +191,112 @@ func IsFeatureAnnouncementNotificationsEnabled() bool {
 }
 
 func SetFeatureAnnouncementsNotification(value bool) error {
+	return Update(func(cfg *Config) error {
+		cfg.Notifications.FeatureAnnouncements = &value
+		return nil
+	})
+}
+
+func GetLaunchers() []LauncherConfig {
 	cfg, err := Get()
 	if err != nil {
-		cfg = &Config{}
+		return nil
+	}
+	return cfg.Launchers
+}
+
+// SaveLauncher adds the launcher to the config, replacing an existing
+// launcher with the same id.
+func SaveLauncher(launcher LauncherConfig) error {
+	return Update(func(cfg *Config) error {
+		for i, existing := range cfg.Launchers {
+			if existing.ID == launcher.ID {
+				cfg.Launchers[i] = launcher
+				return nil
+			}
+		}
+
+		cfg.Launchers = append(cfg.Launchers, launcher)
+		return nil
+	})
+}
+
+func RemoveLauncher(id string) error {
+	return Update(func(cfg *Config) error {
+		for i, existing := range cfg.Launchers {
+			if existing.ID == id {
+				cfg.Launchers = append(cfg.Launchers[:i], cfg.Launchers[i+1:]...)
+				return nil
+			}
+		}
+
+		return fmt.Errorf("launcher %q not found", id)
+	})
+}
+
+func GetMultiplexerConfig() MultiplexerConfig {
+	cfg, err := Get()
+	if err != nil {
+		return MultiplexerConfig{}
+	}
+	return cfg.Multiplexer
+}
+
+func SetMultiplexerConfig(multiplexer MultiplexerConfig) error {
+	return Update(func(cfg *Config) error {
+		cfg.Multiplexer = multiplexer
+		return nil
+	})
+}
+
+func GetDaemonConfig() DaemonConfig {
+	cfg, err := Get()
+	if err != nil {
+		return DaemonConfig{}
+	}
+	return cfg.Daemon
+}
+
+func SetDaemonConfig(daemon DaemonConfig) error {
+	return Update(func(cfg *Config) error {
+		cfg.Daemon = daemon
+		return nil
+	})
+}
+
+// SaveHook adds the hook to the config, replacing an existing hook with the
+// same name.
+func SaveHook(hook HookConfig) error {
+	return Update(func(cfg *Config) error {
+		for i, existing := range cfg.Daemon.Hooks {
+			if existing.Name == hook.Name {
+				cfg.Daemon.Hooks[i] = hook
+				return nil
+			}
+		}
+
+		cfg.Daemon.Hooks = append(cfg.Daemon.Hooks, hook)
+		return nil
+	})
+}
+
+func RemoveHook(name string) error {
+	return Update(func(cfg *Config) error {
+		for i, existing := range cfg.Daemon.Hooks {
+			if existing.Name == name {
+				cfg.Daemon.Hooks = append(cfg.Daemon.Hooks[:i], cfg.Daemon.Hooks[i+1:]...)
+				return nil
+			}
+		}
+
+		return fmt.Errorf("hook %q not found", name)
+	})
+}
+
+func IsDaemonDesktopNotificationsEnabled() bool {
+	notifications := GetDaemonConfig().DesktopNotifications
+	if notifications == nil {
+		return true
 	}
-	cfg.Notifications.FeatureAnnouncements = &value
-	return Save(cfg)
+	return *notifications
 }
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"os"
+
+	"github.com/kirsle/configdir"
+	"golang.org/x/oauth2"
+)
+
+// Environment variables that configure the CLI without a profile, e.g. in CI.
+// Flags take precedence over these, and these over the values stored in the
+// profile.
+const (
+	TokenEnvVar     = "APONO_TOKEN"
+	ProfileEnvVar   = "APONO_PROFILE"
+	APIURLEnvVar    = "APONO_API_URL"
+	AppURLEnvVar    = "APONO_APP_URL"
+	PortalURLEnvVar = "APONO_PORTAL_URL"
+	ConfigDirEnvVar = "APONO_CONFIG_DIR"
+)
+
+// EnvOrDefault returns the value of the environment variable, or value when it
+// is not set. Flag defaults use it so an explicit flag still wins.
+func EnvOrDefault(envVar, value string) string {
+	if env := os.Getenv(envVar); env != "" {
+		return env
+	}
+	return value
+}
+
+// ResolveProfileName returns the profile selected by --profile, falling back
+// to APONO_PROFILE and then to the profile of the project file. The project
+// file is ignored when APONO_TOKEN is set, so a committed .apono.yaml does not
+// make CI read a config file it does not have. An empty name means the active
+// profile, or the APONO_TOKEN configuration.
+func ResolveProfileName(profileName ProfileName) ProfileName {
+	if profileName != "" {
+		return profileName
+	}
+	if env := os.Getenv(ProfileEnvVar); env != "" {
+		return ProfileName(env)
+	}
+	if os.Getenv(TokenEnvVar) != "" {
+		return ""
+	}
+	if project, err := GetProjectConfig(); err == nil && project != nil {
+		return ProfileName(project.Profile)
+	}
+	return ""
+}
+
+// ResolveActiveProfileName is ResolveProfileName falling back to the active
+// profile, unless APONO_TOKEN configures the CLI without a profile. An empty
+// name means no profile is used.
+func ResolveActiveProfileName(profileName ProfileName) ProfileName {
+	name := ResolveProfileName(profileName)
+	if name == "" && os.Getenv(TokenEnvVar) == "" {
+		if cfg, err := Get(); err == nil {
+			name = cfg.Auth.ActiveProfile
+		}
+	}
+	return name
+}
+
+// envSession returns the session configured by APONO_TOKEN, or nil when it is
+// not set. It is built from the environment only, so it works without a
+// config file or a writable disk.
+func envSession() *SessionConfig {
+	if os.Getenv(TokenEnvVar) == "" {
+		return nil
+	}
+
+	session := &SessionConfig{
+		ApiURL:    APIDefaultURL,
+		AppURL:    AppDefaultURL,
+		PortalURL: PortalDefaultURL,
+	}
+	applyEnvOverrides(session)
+	return session
+}
+
+func applyEnvOverrides(session *SessionConfig) {
+	if token := os.Getenv(TokenEnvVar); token != "" {
+		session.PersonalToken = token
+		session.Token = oauth2.Token{}
+	}
+	session.ApiURL = EnvOrDefault(APIURLEnvVar, session.ApiURL)
+	session.AppURL = EnvOrDefault(AppURLEnvVar, session.AppURL)
+	session.PortalURL = EnvOrDefault(PortalURLEnvVar, session.PortalURL)
+}
+
+func configDirPath() string {
+	return EnvOrDefault(ConfigDirEnvVar, configdir.LocalConfig("apono-cli"))
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestGetProfileByNameFromEnvironment(t *testing.T) {
+	originalDirPath := DirPath
+	// Reading a config file under a regular file fails, so the test fails if
+	// the config is read at all.
+	DirPath = filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(DirPath, nil, filePerm); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { DirPath = originalDirPath }()
+
+	t.Setenv(TokenEnvVar, "personal-token")
+	t.Setenv(APIURLEnvVar, "https://api.example.com")
+	t.Setenv(ProfileEnvVar, "")
+
+	session, err := GetProfileByName("")
+	if err != nil {
+		t.Fatalf("GetProfileByName() error = %v", err)
+	}
+	if session.PersonalToken != "personal-token" {
+		t.Errorf("PersonalToken = %q, want the APONO_TOKEN value", session.PersonalToken)
+	}
+	if session.ApiURL != "https://api.example.com" || session.AppURL != AppDefaultURL {
+		t.Errorf("ApiURL = %q, AppURL = %q, want the APONO_API_URL value and the default app URL", session.ApiURL, session.AppURL)
+	}
+}
+
+func TestGetProfileByNameEnvironmentOverridesProfile(t *testing.T) {
+	originalDirPath := DirPath
+	DirPath = t.TempDir()
+	defer func() { DirPath = originalDirPath }()
+
+	t.Setenv(SecretsBackendEnvVar, "plaintext")
+	err := Save(&Config{Auth: AuthConfig{
+		ActiveProfile: "default",
+		Profiles: map[ProfileName]SessionConfig{
+			"default": {ApiURL: "https://default.example.com", PersonalToken: "default-token"},
+			"ci":      {ApiURL: "https://ci.example.com", AppURL: "https://app.ci.example.com", PersonalToken: "ci-token"},
+		},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(ProfileEnvVar, "ci")
+	t.Setenv(TokenEnvVar, "env-token")
+	t.Setenv(APIURLEnvVar, "https://api.example.com")
+
+	tests := []struct {
+		name        string
+		profileName ProfileName
+		wantAppURL  string
+	}{
+		{name: "APONO_PROFILE selects the profile", profileName: "", wantAppURL: "https://app.ci.example.com"},
+		{name: "--profile wins over APONO_PROFILE", profileName: "default", wantAppURL: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session, err := GetProfileByName(tt.profileName)
+			if err != nil {
+				t.Fatalf("GetProfileByName() error = %v", err)
+			}
+			if session.AppURL != tt.wantAppURL {
+				t.Errorf("AppURL = %q, want %q", session.AppURL, tt.wantAppURL)
+			}
+			if session.PersonalToken != "env-token" || session.ApiURL != "https://api.example.com" {
+				t.Errorf("PersonalToken = %q, ApiURL = %q, want the environment values", session.PersonalToken, session.ApiURL)
+			}
+		})
+	}
+}
+
+func TestResolveProfileNameWithProjectFile(t *testing.T) {
+	originalDirPath := DirPath
+	DirPath = filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(DirPath, nil, filePerm); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { DirPath = originalDirPath }()
+
+	projectDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(projectDir, ProjectFileName), []byte("profile: staging\n"), filePerm); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(projectDir)
+	projectConfigOnce = sync.Once{}
+	t.Cleanup(func() { projectConfigOnce = sync.Once{} })
+
+	t.Setenv(ProfileEnvVar, "")
+	t.Setenv(APIURLEnvVar, "https://api.example.com")
+
+	t.Run("without APONO_TOKEN", func(t *testing.T) {
+		t.Setenv(TokenEnvVar, "")
+		if got := ResolveProfileName(""); got != "staging" {
+			t.Errorf("ResolveProfileName() = %q, want the project profile", got)
+		}
+	})
+
+	t.Run("with APONO_TOKEN", func(t *testing.T) {
+		t.Setenv(TokenEnvVar, "env-token")
+		if got := ResolveProfileName(""); got != "" {
+			t.Errorf("ResolveProfileName() = %q, want no profile", got)
+		}
+
+		// The config dir is not readable, so this only works from the
+		// environment.
+		session, err := GetProfileByName("")
+		if err != nil {
+			t.Fatalf("GetProfileByName() error = %v", err)
+		}
+		if session.PersonalToken != "env-token" {
+			t.Errorf("PersonalToken = %q, want the APONO_TOKEN value", session.PersonalToken)
+		}
+	})
+}
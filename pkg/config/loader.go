@@ -1,7 +1,6 @@
 package config
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,14 +12,15 @@ import (
 	"github.com/kirsle/configdir"
 )
 
-var (
-	DirPath        = configdir.LocalConfig("apono-cli")
-	configFilePath = path.Join(DirPath, "config.json")
-)
+var DirPath = configDirPath()
+
+func configFilePath() string {
+	return path.Join(DirPath, "config.json")
+}
 
 func Get() (*Config, error) {
 	cfg := new(Config)
-	configFile, err := os.Open(filepath.Clean(configFilePath))
+	configFile, err := os.Open(filepath.Clean(configFilePath()))
 	if err != nil {
 		if os.IsNotExist(err) {
 			return &Config{}, nil
@@ -48,35 +48,42 @@ func Get() (*Config, error) {
 	return cfg, nil
 }
 
+// Save writes the config to a temp file and renames it over config.json, so
+// readers never see a partial file. Use Update to modify the latest config.
 func Save(cfg *Config) error {
 	configBytes, err := json.Marshal(*cfg)
 	if err != nil {
 		return fmt.Errorf("failed to serialize config: %w", err)
 	}
 
-	if _, err = os.Stat("/path/to/whatever"); os.IsNotExist(err) {
-		err = configdir.MakePath(DirPath) // Ensure it exists.
-		if err != nil {
-			return err
-		}
+	if err = configdir.MakePath(DirPath); err != nil {
+		return err
 	}
 
-	configFile, err := os.Create(filepath.Clean(configFilePath))
+	tmpFile, err := os.CreateTemp(DirPath, "config.json.*")
 	if err != nil {
-		return fmt.Errorf("failed to open config file: %w", err)
+		return fmt.Errorf("failed to create temp config file: %w", err)
 	}
+	defer func() { _ = os.Remove(tmpFile.Name()) }()
 
-	defer func(file *os.File) {
-		err = file.Close()
-		if err != nil {
-			log.Printf("failed to close config file: %s", err.Error())
-		}
-	}(configFile)
-
-	_, err = io.Copy(configFile, bytes.NewBuffer(configBytes))
-	if err != nil {
+	if _, err = tmpFile.Write(configBytes); err != nil {
+		_ = tmpFile.Close()
+		return fmt.Errorf("failed write config to file: %w", err)
+	}
+	if err = tmpFile.Sync(); err != nil {
+		_ = tmpFile.Close()
 		return fmt.Errorf("failed write config to file: %w", err)
 	}
+	if err = tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed write config to file: %w", err)
+	}
+	if err = os.Chmod(tmpFile.Name(), filePerm); err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmpFile.Name(), configFilePath()); err != nil {
+		return fmt.Errorf("failed to replace config file: %w", err)
+	}
 
 	return nil
 }
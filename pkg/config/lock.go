@@ -0,0 +1,48 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/apono-io/apono-cli/pkg/filelock"
+)
+
+const (
+	lockFileName = "config.lock"
+
+	dirPerm  os.FileMode = 0o700
+	filePerm os.FileMode = 0o600
+)
+
+// WithLock runs fn while holding an exclusive advisory lock on the config
+// dir, so read-modify-write cycles of parallel apono processes do not
+// overwrite each other. The lock is not reentrant: fn must use Get and Save
+// rather than Update.
+func WithLock(fn func() error) error {
+	if err := os.MkdirAll(DirPath, dirPerm); err != nil {
+		return fmt.Errorf("failed to create config dir: %w", err)
+	}
+
+	unlock, err := filelock.Lock(path.Join(DirPath, lockFileName))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return fn()
+}
+
+// Update applies fn to the latest config and saves it under the config lock.
+func Update(fn func(cfg *Config) error) error {
+	return WithLock(func() error {
+		cfg, err := Get()
+		if err != nil {
+			return err
+		}
+		if err = fn(cfg); err != nil {
+			return err
+		}
+		return Save(cfg)
+	})
+}
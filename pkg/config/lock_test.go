@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sync"
+	"testing"
+)
+
+func TestUpdateKeepsConcurrentChanges(t *testing.T) {
+	originalDirPath := DirPath
+	DirPath = t.TempDir()
+	defer func() { DirPath = originalDirPath }()
+
+	const writers = 20
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := SaveLauncher(LauncherConfig{ID: fmt.Sprintf("launcher-%d", i), Kind: "CLI", Command: "true"}); err != nil {
+				t.Errorf("SaveLauncher() error = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if got := len(GetLaunchers()); got != writers {
+		t.Errorf("got %d launchers, want %d", got, writers)
+	}
+
+	info, err := os.Stat(path.Join(DirPath, "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != filePerm {
+		t.Errorf("config file mode = %v, want %v", info.Mode().Perm(), filePerm)
+	}
+
+	entries, err := os.ReadDir(DirPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.Name() != "config.json" && entry.Name() != lockFileName {
+			t.Errorf("unexpected file %s left in the config dir", entry.Name())
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"golang.org/x/oauth2"
+
+	"github.com/apono-io/apono-cli/pkg/secrets"
+)
+
+const ProfilesExportVersion = 1
+
+// Token redaction modes of ExportProfiles.
+const (
+	RedactAllTokens      = "all"
+	RedactOAuthTokens    = "oauth"
+	RedactPersonalTokens = "personal"
+	RedactNoTokens       = "none"
+)
+
+// ProfilesExport is the portable file written by `apono profiles export`.
+// Tokens are inlined, as the secrets backend of the importing machine may
+// differ.
+type ProfilesExport struct {
+	Version  int                           `json:"version"`
+	Profiles map[ProfileName]SessionConfig `json:"profiles"`
+}
+
+func ParseTokenRedaction(value string) (string, error) {
+	switch value {
+	case RedactAllTokens, RedactOAuthTokens, RedactPersonalTokens, RedactNoTokens:
+		return value, nil
+	default:
+		return "", fmt.Errorf("invalid token redaction %q, valid values are '%s', '%s', '%s' or '%s'", value, RedactAllTokens, RedactOAuthTokens, RedactPersonalTokens, RedactNoTokens)
+	}
+}
+
+// ProfileNames returns the configured profiles in sorted order.
+func ProfileNames(cfg *Config) []ProfileName {
+	names := make([]ProfileName, 0, len(cfg.Auth.Profiles))
+	for name := range cfg.Auth.Profiles {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+// RenameProfile moves a profile and its secrets to a new name, keeping it
+// active if it was.
+func RenameProfile(from, to ProfileName) error {
+	return moveProfile(from, to, true)
+}
+
+// CopyProfile duplicates a profile and its secrets under a new name.
+func CopyProfile(from, to ProfileName) error {
+	return moveProfile(from, to, false)
+}
+
+func moveProfile(from, to ProfileName, removeSource bool) error {
+	var source SessionConfig
+	err := Update(func(cfg *Config) error {
+		var exists bool
+		source, exists = cfg.Auth.Profiles[from]
+		if !exists {
+			return fmt.Errorf("%s %w", from, ErrProfileNotExists)
+		}
+		if _, exists = cfg.Auth.Profiles[to]; exists {
+			return fmt.Errorf("profile %s already exists", to)
+		}
+
+		session := source
+		if err := LoadProfileSecrets(from, &session); err != nil {
+			return err
+		}
+		if err := StoreProfileSecrets(to, &session, profileSecretsBackend(source)); err != nil {
+			return fmt.Errorf("failed to store the secrets of profile %s: %w", to, err)
+		}
+		cfg.Auth.Profiles[to] = session
+
+		if removeSource {
+			delete(cfg.Auth.Profiles, from)
+			if cfg.Auth.ActiveProfile == from {
+				cfg.Auth.ActiveProfile = to
+			}
+		}
+		return nil
+	})
+	if err != nil || !removeSource {
+		return err
+	}
+
+	// The old secrets are removed only once the config no longer points at them.
+	return DeleteProfileSecrets(from, source)
+}
+
+// DeleteProfile removes a profile and its secrets. Deleting the active
+// profile activates the first remaining one, if any.
+func DeleteProfile(name ProfileName) error {
+	var session SessionConfig
+	err := Update(func(cfg *Config) error {
+		var exists bool
+		session, exists = cfg.Auth.Profiles[name]
+		if !exists {
+			return fmt.Errorf("%s %w", name, ErrProfileNotExists)
+		}
+
+		delete(cfg.Auth.Profiles, name)
+		if cfg.Auth.ActiveProfile == name {
+			cfg.Auth.ActiveProfile = ""
+			if names := ProfileNames(cfg); len(names) > 0 {
+				cfg.Auth.ActiveProfile = names[0]
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The secrets are removed only once the config no longer points at them.
+	return DeleteProfileSecrets(name, session)
+}
+
+// ExportProfiles returns the named profiles, or all of them when names is
+// empty, with their secrets inlined and the tokens selected by redact removed.
+func ExportProfiles(names []ProfileName, redact string) (*ProfilesExport, error) {
+	cfg, err := Get()
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.Auth.Profiles) == 0 {
+		return nil, ErrNoProfiles
+	}
+	if len(names) == 0 {
+		names = ProfileNames(cfg)
+	}
+
+	export := &ProfilesExport{Version: ProfilesExportVersion, Profiles: make(map[ProfileName]SessionConfig)}
+	for _, name := range names {
+		session, exists := cfg.Auth.Profiles[name]
+		if !exists {
+			return nil, fmt.Errorf("%s %w", name, ErrProfileNotExists)
+		}
+		if err = LoadProfileSecrets(name, &session); err != nil {
+			return nil, err
+		}
+		session.SecretsBackend = ""
+
+		if redact == RedactAllTokens || redact == RedactOAuthTokens {
+			session.Token = oauth2.Token{}
+		}
+		if redact == RedactAllTokens || redact == RedactPersonalTokens {
+			session.PersonalToken = ""
+		}
+		export.Profiles[name] = session
+	}
+
+	return export, nil
+}
+
+// ImportProfiles adds the exported profiles, storing their tokens in the
+// current secrets backend. Existing profiles are only replaced when overwrite
+// is set. It returns the imported names in sorted order.
+func ImportProfiles(export *ProfilesExport, overwrite bool) ([]ProfileName, error) {
+	if export.Version != ProfilesExportVersion {
+		return nil, fmt.Errorf("unsupported profiles export version %d", export.Version)
+	}
+	if len(export.Profiles) == 0 {
+		return nil, fmt.Errorf("the export has no profiles")
+	}
+
+	names := ProfileNames(&Config{Auth: AuthConfig{Profiles: export.Profiles}})
+	replaced := make(map[ProfileName]SessionConfig)
+	backend := SecretsBackend()
+	err := Update(func(cfg *Config) error {
+		if cfg.Auth.Profiles == nil {
+			cfg.Auth.Profiles = make(map[ProfileName]SessionConfig)
+		}
+		for _, name := range names {
+			if existing, exists := cfg.Auth.Profiles[name]; exists {
+				if !overwrite {
+					return fmt.Errorf("profile %s already exists, use --overwrite to replace it", name)
+				}
+				replaced[name] = existing
+			}
+		}
+
+		for _, name := range names {
+			session := export.Profiles[name]
+			if err := StoreProfileSecrets(name, &session, backend); err != nil {
+				return fmt.Errorf("failed to store the secrets of profile %s: %w", name, err)
+			}
+			cfg.Auth.Profiles[name] = session
+		}
+		if cfg.Auth.ActiveProfile == "" {
+			cfg.Auth.ActiveProfile = names[0]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Secrets of replaced profiles that lived in another backend are left
+	// behind otherwise.
+	for name, existing := range replaced {
+		if existing.SecretsBackend != "" && existing.SecretsBackend != backend {
+			_ = DeleteProfileSecrets(name, existing)
+		}
+	}
+
+	return names, nil
+}
+
+func profileSecretsBackend(session SessionConfig) string {
+	if session.SecretsBackend == "" {
+		return secrets.BackendPlaintext
+	}
+	return session.SecretsBackend
+}
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/apono-io/apono-cli/pkg/secrets"
+)
+
+func setupProfilesTest(t *testing.T) {
+	t.Helper()
+
+	originalDirPath := DirPath
+	DirPath = t.TempDir()
+	t.Cleanup(func() { DirPath = originalDirPath })
+
+	t.Setenv(SecretsBackendEnvVar, secrets.BackendFile)
+	t.Setenv(SecretsPassphraseEnvVar, "test-passphrase")
+
+	profiles := map[ProfileName]SessionConfig{
+		"default": {AccountID: "account-1", ApiURL: "https://api.example.com", Token: oauth2.Token{AccessToken: "access", RefreshToken: "refresh"}},
+		"ci":      {AccountID: "account-2", ApiURL: "https://api.example.com", PersonalToken: "personal"},
+	}
+	for name, session := range profiles {
+		if err := StoreProfileSecrets(name, &session, secrets.BackendFile); err != nil {
+			t.Fatal(err)
+		}
+		profiles[name] = session
+	}
+	if err := Save(&Config{Auth: AuthConfig{ActiveProfile: "default", Profiles: profiles}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRenameProfileMovesSecrets(t *testing.T) {
+	setupProfilesTest(t)
+
+	if err := RenameProfile("default", "prod"); err != nil {
+		t.Fatalf("RenameProfile() error = %v", err)
+	}
+
+	cfg, err := Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Auth.ActiveProfile != "prod" {
+		t.Errorf("ActiveProfile = %q, want the renamed profile", cfg.Auth.ActiveProfile)
+	}
+	if _, exists := cfg.Auth.Profiles["default"]; exists {
+		t.Error("the old profile still exists")
+	}
+
+	session, err := GetProfileByName("prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session.Token.AccessToken != "access" || session.Token.RefreshToken != "refresh" {
+		t.Errorf("Token = %+v, want the token of the old profile", session.Token)
+	}
+
+	if err = RenameProfile("prod", "ci"); err == nil {
+		t.Error("RenameProfile() onto an existing profile succeeded, want an error")
+	}
+	if err = CopyProfile("missing", "other"); err == nil {
+		t.Error("CopyProfile() of a missing profile succeeded, want an error")
+	}
+}
+
+func TestExportImportProfiles(t *testing.T) {
+	setupProfilesTest(t)
+
+	export, err := ExportProfiles(nil, RedactOAuthTokens)
+	if err != nil {
+		t.Fatalf("ExportProfiles() error = %v", err)
+	}
+	if got := export.Profiles["default"].Token; got.AccessToken != "" || got.RefreshToken != "" {
+		t.Errorf("exported OAuth token = %+v, want it redacted", got)
+	}
+	if got := export.Profiles["ci"].PersonalToken; got != "personal" {
+		t.Errorf("exported personal token = %q, want it inlined", got)
+	}
+	if got := export.Profiles["ci"].SecretsBackend; got != "" {
+		t.Errorf("exported secrets backend = %q, want it cleared", got)
+	}
+
+	if _, err = ImportProfiles(export, false); err == nil {
+		t.Error("ImportProfiles() over existing profiles succeeded, want an error")
+	}
+
+	DirPath = t.TempDir()
+	names, err := ImportProfiles(export, false)
+	if err != nil {
+		t.Fatalf("ImportProfiles() error = %v", err)
+	}
+	if want := []ProfileName{"ci", "default"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("ImportProfiles() = %v, want %v", names, want)
+	}
+
+	cfg, err := Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Auth.ActiveProfile != "ci" {
+		t.Errorf("ActiveProfile = %q, want the first imported profile", cfg.Auth.ActiveProfile)
+	}
+	session, err := GetProfileByName("ci")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session.PersonalToken != "personal" || session.SecretsBackend != secrets.BackendFile {
+		t.Errorf("imported session = %+v, want the token in the file backend", session)
+	}
+}
+
+func TestDeleteProfile(t *testing.T) {
+	setupProfilesTest(t)
+
+	if err := DeleteProfile("default"); err != nil {
+		t.Fatalf("DeleteProfile() error = %v", err)
+	}
+
+	cfg, err := Get()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, exists := cfg.Auth.Profiles["default"]; exists {
+		t.Error("the deleted profile still exists")
+	}
+	if cfg.Auth.ActiveProfile != "ci" {
+		t.Errorf("ActiveProfile = %q, want the remaining profile", cfg.Auth.ActiveProfile)
+	}
+	if _, err = GetProfileByName("ci"); err != nil {
+		t.Errorf("GetProfileByName() of the remaining profile error = %v", err)
+	}
+
+	if err = DeleteProfile("default"); !errors.Is(err, ErrProfileNotExists) || !strings.Contains(err.Error(), "default") {
+		t.Errorf("DeleteProfile() of a missing profile error = %v, want it to name the profile", err)
+	}
+
+	if err = DeleteProfile("ci"); err != nil {
+		t.Fatalf("DeleteProfile() error = %v", err)
+	}
+	if cfg, err = Get(); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Auth.ActiveProfile != "" {
+		t.Errorf("ActiveProfile = %q, want none after deleting the last profile", cfg.Auth.ActiveProfile)
+	}
+}
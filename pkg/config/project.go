@@ -0,0 +1,151 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+const ProjectFileName = ".apono.yaml"
+
+// ProjectConfig is read from the .apono.yaml file found in the working
+// directory or one of its parents, so each repo can default to its own
+// profile and access requests.
+type ProjectConfig struct {
+	Profile string `yaml:"profile,omitempty"`
+	// RequestDefaults apply to every `apono requests create` in the project.
+	RequestDefaults `yaml:",inline"`
+	Presets         map[string]RequestDefaults `yaml:"presets,omitempty"`
+
+	// Path is the file the config was read from.
+	Path string `yaml:"-"`
+}
+
+// RequestDefaults are the values `apono requests create` uses for the flags
+// that were not set on the command line. Justification is a Go template.
+type RequestDefaults struct {
+	Integration   string            `yaml:"integration,omitempty"`
+	Bundle        string            `yaml:"bundle,omitempty"`
+	ResourceType  string            `yaml:"resource_type,omitempty"`
+	Resources     []string          `yaml:"resources,omitempty"`
+	Permissions   []string          `yaml:"permissions,omitempty"`
+	Justification string            `yaml:"justification,omitempty"`
+	Duration      string            `yaml:"duration,omitempty"`
+	CustomFields  map[string]string `yaml:"custom_fields,omitempty"`
+}
+
+var (
+	projectConfigOnce sync.Once
+	projectConfig     *ProjectConfig
+	errProjectConfig  error
+)
+
+// GetProjectConfig returns the project config of the working directory, or nil
+// when there is none. It is looked up once per process.
+func GetProjectConfig() (*ProjectConfig, error) {
+	projectConfigOnce.Do(func() {
+		dir, err := os.Getwd()
+		if err != nil {
+			return
+		}
+		projectConfig, errProjectConfig = FindProjectConfig(dir)
+	})
+	return projectConfig, errProjectConfig
+}
+
+// FindProjectConfig walks up from dir and reads the first .apono.yaml found,
+// returning nil when there is none.
+func FindProjectConfig(dir string) (*ProjectConfig, error) {
+	for {
+		path := filepath.Join(dir, ProjectFileName)
+		data, err := os.ReadFile(filepath.Clean(path))
+		if err == nil {
+			return parseProjectConfig(path, data)
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+func parseProjectConfig(path string, data []byte) (*ProjectConfig, error) {
+	cfg := &ProjectConfig{Path: path}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if cfg.Integration != "" && cfg.Bundle != "" {
+		return nil, fmt.Errorf("%s: integration and bundle are mutually exclusive", path)
+	}
+	for name, preset := range cfg.Presets {
+		if preset.Integration != "" && preset.Bundle != "" {
+			return nil, fmt.Errorf("%s: preset %q: integration and bundle are mutually exclusive", path, name)
+		}
+	}
+	return cfg, nil
+}
+
+// RequestDefaultsFor returns the project defaults with the named preset applied
+// on top, or just the defaults when preset is empty.
+func (c *ProjectConfig) RequestDefaultsFor(preset string) (RequestDefaults, error) {
+	if preset == "" {
+		return c.RequestDefaults, nil
+	}
+
+	values, ok := c.Presets[preset]
+	if !ok {
+		return RequestDefaults{}, fmt.Errorf("preset %q not found in %s, available presets: %v", preset, c.Path, c.PresetNames())
+	}
+	return c.RequestDefaults.Merge(values), nil
+}
+
+func (c *ProjectConfig) PresetNames() []string {
+	names := make([]string, 0, len(c.Presets))
+	for name := range c.Presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Merge returns d overridden by the values set in override. The request target
+// (integration or bundle, resource type, resources and permissions) is
+// replaced as a whole, so a preset never mixes with the default target.
+func (d RequestDefaults) Merge(override RequestDefaults) RequestDefaults {
+	merged := d
+	if override.Integration != "" || override.Bundle != "" {
+		merged.Integration = override.Integration
+		merged.Bundle = override.Bundle
+		merged.ResourceType = override.ResourceType
+		merged.Resources = override.Resources
+		merged.Permissions = override.Permissions
+	}
+	if override.Justification != "" {
+		merged.Justification = override.Justification
+	}
+	if override.Duration != "" {
+		merged.Duration = override.Duration
+	}
+
+	if len(override.CustomFields) > 0 {
+		merged.CustomFields = make(map[string]string, len(d.CustomFields)+len(override.CustomFields))
+		for key, value := range d.CustomFields {
+			merged.CustomFields[key] = value
+		}
+		for key, value := range override.CustomFields {
+			merged.CustomFields[key] = value
+		}
+	}
+
+	return merged
+}
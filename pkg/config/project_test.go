@@ -0,0 +1,85 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testProjectFile = `profile: staging
+integration: postgresql/staging
+resource_type: postgresql-database
+resources: [orders]
+permissions: [READ_ONLY]
+justification: "Working on {{.Branch}}"
+duration: 1h
+custom_fields:
+  team: payments
+  ticket: none
+presets:
+  admin:
+    bundle: DB admins
+    duration: 30m
+    custom_fields:
+      ticket: OPS-1
+`
+
+func TestFindProjectConfigWalksUp(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "services", "orders")
+	if err := os.MkdirAll(nested, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ProjectFileName), []byte(testProjectFile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	project, err := FindProjectConfig(nested)
+	if err != nil {
+		t.Fatalf("FindProjectConfig() error = %v", err)
+	}
+	if project == nil || project.Path != filepath.Join(root, ProjectFileName) {
+		t.Fatalf("FindProjectConfig() = %+v, want the file of the parent dir", project)
+	}
+	if project.Profile != "staging" || project.Integration != "postgresql/staging" {
+		t.Errorf("FindProjectConfig() = %+v, want the parsed file", project)
+	}
+
+	if project, err = FindProjectConfig(t.TempDir()); err != nil || project != nil {
+		t.Errorf("FindProjectConfig() = %+v, %v, want nil without a project file", project, err)
+	}
+}
+
+func TestRequestDefaultsFor(t *testing.T) {
+	project, err := parseProjectConfig(ProjectFileName, []byte(testProjectFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defaults, err := project.RequestDefaultsFor("admin")
+	if err != nil {
+		t.Fatalf("RequestDefaultsFor() error = %v", err)
+	}
+
+	want := RequestDefaults{
+		Bundle:        "DB admins",
+		Justification: "Working on {{.Branch}}",
+		Duration:      "30m",
+		CustomFields:  map[string]string{"team": "payments", "ticket": "OPS-1"},
+	}
+	if !reflect.DeepEqual(defaults, want) {
+		t.Errorf("RequestDefaultsFor() = %+v, want %+v", defaults, want)
+	}
+
+	if _, err = project.RequestDefaultsFor("missing"); err == nil {
+		t.Error("RequestDefaultsFor() of an unknown preset should fail")
+	}
+}
+
+func TestParseProjectConfigRejectsIntegrationAndBundle(t *testing.T) {
+	_, err := parseProjectConfig(ProjectFileName, []byte("presets:\n  both:\n    integration: a\n    bundle: b\n"))
+	if err == nil {
+		t.Error("parseProjectConfig() should reject a preset with both an integration and a bundle")
+	}
+}
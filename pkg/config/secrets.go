@@ -0,0 +1,239 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/oauth2"
+
+	"github.com/apono-io/apono-cli/pkg/secrets"
+)
+
+const (
+	SecretsBackendEnvVar    = "APONO_SECRETS_BACKEND"
+	SecretsPassphraseEnvVar = "APONO_SECRETS_PASSPHRASE"
+
+	secretsFileName = "secrets.enc"
+)
+
+// SecretsConfig selects where tokens and cached credentials are stored. An
+// empty backend uses the OS keyring when there is one, the encrypted file when
+// a passphrase or key file is set, and plaintext otherwise, so hosts without a
+// keyring, such as SSH sessions and CI runners, keep working.
+type SecretsConfig struct {
+	Backend string `json:"backend,omitempty"`
+	KeyFile string `json:"key_file,omitempty"`
+}
+
+var (
+	secretStoresMu sync.Mutex
+	secretStores   = make(map[string]secrets.Store)
+)
+
+// SecretsBackend returns the backend new secrets are written to.
+func SecretsBackend() string {
+	if backend := ConfiguredSecretsBackend(); backend != "" {
+		return backend
+	}
+	if secrets.KeyringAvailable() {
+		return secrets.BackendKeyring
+	}
+	if os.Getenv(SecretsPassphraseEnvVar) != "" || GetSecretsConfig().KeyFile != "" {
+		return secrets.BackendFile
+	}
+	return secrets.BackendPlaintext
+}
+
+// ConfiguredSecretsBackend returns the backend chosen by APONO_SECRETS_BACKEND
+// or secrets.backend, or an empty string when none was chosen.
+func ConfiguredSecretsBackend() string {
+	if backend := os.Getenv(SecretsBackendEnvVar); backend != "" {
+		return backend
+	}
+	cfg, err := Get()
+	if err != nil {
+		return ""
+	}
+	return cfg.Secrets.Backend
+}
+
+func GetSecretsConfig() SecretsConfig {
+	cfg, err := Get()
+	if err != nil {
+		return SecretsConfig{}
+	}
+	return cfg.Secrets
+}
+
+func SetSecretsConfig(secretsConfig SecretsConfig) error {
+	return Update(func(cfg *Config) error {
+		cfg.Secrets = secretsConfig
+		return nil
+	})
+}
+
+// SecretStore returns the store of the current backend, or nil when secrets
+// are kept in plaintext in the config and cache files.
+func SecretStore() (secrets.Store, error) {
+	return OpenSecretStore(SecretsBackend())
+}
+
+// OpenSecretStore returns the store of the backend, or nil for plaintext.
+// Stores are opened once per process, so the passphrase of the secrets file
+// is asked for only once.
+func OpenSecretStore(backend string) (secrets.Store, error) {
+	if _, err := secrets.ParseBackend(backend); err != nil {
+		return nil, err
+	}
+	if backend == secrets.BackendPlaintext {
+		return nil, nil
+	}
+
+	secretStoresMu.Lock()
+	defer secretStoresMu.Unlock()
+
+	if store, ok := secretStores[backend]; ok {
+		return store, nil
+	}
+
+	var store secrets.Store
+	switch backend {
+	case secrets.BackendKeyring:
+		if !secrets.KeyringAvailable() {
+			return nil, fmt.Errorf("no OS keyring available, use another secrets backend with `apono config set secrets.backend`")
+		}
+		store = secrets.NewKeyringStore()
+	case secrets.BackendFile:
+		key, err := secretsFileKey()
+		if err != nil {
+			return nil, err
+		}
+		fileStore, err := secrets.NewFileStore(path.Join(DirPath, secretsFileName), key)
+		if err != nil {
+			return nil, err
+		}
+		store = fileStore
+	}
+
+	secretStores[backend] = store
+	return store, nil
+}
+
+func secretsFileKey() (secrets.FileKey, error) {
+	key := secrets.FileKey{Passphrase: os.Getenv(SecretsPassphraseEnvVar)}
+
+	cfg, err := Get()
+	if err != nil || cfg.Secrets.KeyFile == "" {
+		return key, nil
+	}
+
+	keyFile, err := os.ReadFile(filepath.Clean(cfg.Secrets.KeyFile))
+	if err != nil {
+		return key, fmt.Errorf("read secrets key file: %w", err)
+	}
+	key.KeyFile = keyFile
+	return key, nil
+}
+
+func profileTokenKey(name ProfileName) string {
+	return fmt.Sprintf("profile/%s/token", name)
+}
+
+func profilePersonalTokenKey(name ProfileName) string {
+	return fmt.Sprintf("profile/%s/personal_token", name)
+}
+
+// StoreProfileSecrets moves the tokens of the profile to the backend and
+// clears them from the session, which is then saved to config.json. The
+// plaintext backend keeps the tokens in the session.
+func StoreProfileSecrets(name ProfileName, session *SessionConfig, backend string) error {
+	store, err := OpenSecretStore(backend)
+	if err != nil {
+		return err
+	}
+	if store == nil {
+		session.SecretsBackend = ""
+		return nil
+	}
+
+	if session.Token.AccessToken != "" || session.Token.RefreshToken != "" {
+		token, marshalErr := json.Marshal(session.Token)
+		if marshalErr != nil {
+			return marshalErr
+		}
+		if err = store.Set(profileTokenKey(name), string(token)); err != nil {
+			return err
+		}
+	} else if err = store.Delete(profileTokenKey(name)); err != nil {
+		return err
+	}
+
+	if session.PersonalToken != "" {
+		if err = store.Set(profilePersonalTokenKey(name), session.PersonalToken); err != nil {
+			return err
+		}
+	} else if err = store.Delete(profilePersonalTokenKey(name)); err != nil {
+		return err
+	}
+
+	session.Token = oauth2.Token{}
+	session.PersonalToken = ""
+	session.SecretsBackend = backend
+	return nil
+}
+
+// LoadProfileSecrets fills in the tokens of a profile whose secrets are kept
+// outside of config.json.
+func LoadProfileSecrets(name ProfileName, session *SessionConfig) error {
+	if session.SecretsBackend == "" {
+		return nil
+	}
+
+	store, err := OpenSecretStore(session.SecretsBackend)
+	if err != nil {
+		return fmt.Errorf("load secrets of profile %s: %w", name, err)
+	}
+
+	token, err := store.Get(profileTokenKey(name))
+	switch {
+	case errors.Is(err, secrets.ErrNotFound):
+	case err != nil:
+		return fmt.Errorf("load secrets of profile %s: %w", name, err)
+	default:
+		if err = json.Unmarshal([]byte(token), &session.Token); err != nil {
+			return fmt.Errorf("parse token of profile %s: %w", name, err)
+		}
+	}
+
+	personalToken, err := store.Get(profilePersonalTokenKey(name))
+	switch {
+	case errors.Is(err, secrets.ErrNotFound):
+	case err != nil:
+		return fmt.Errorf("load secrets of profile %s: %w", name, err)
+	default:
+		session.PersonalToken = personalToken
+	}
+
+	return nil
+}
+
+// DeleteProfileSecrets removes the tokens of a profile from its backend.
+func DeleteProfileSecrets(name ProfileName, session SessionConfig) error {
+	if session.SecretsBackend == "" {
+		return nil
+	}
+
+	store, err := OpenSecretStore(session.SecretsBackend)
+	if err != nil {
+		return err
+	}
+	if err = store.Delete(profileTokenKey(name)); err != nil {
+		return err
+	}
+	return store.Delete(profilePersonalTokenKey(name))
+}
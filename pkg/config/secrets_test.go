@@ -0,0 +1,87 @@
+package config
+
+import (
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/apono-io/apono-cli/pkg/secrets"
+)
+
+// setupNoKeyringTest simulates a host without an OS keyring, such as an SSH
+// session or a CI runner, with an empty config dir.
+func setupNoKeyringTest(t *testing.T, backend, passphrase string) {
+	t.Helper()
+
+	originalDirPath := DirPath
+	DirPath = t.TempDir()
+	t.Cleanup(func() { DirPath = originalDirPath })
+
+	secretStoresMu.Lock()
+	secretStores = make(map[string]secrets.Store)
+	secretStoresMu.Unlock()
+
+	t.Setenv("DBUS_SESSION_BUS_ADDRESS", "")
+	t.Setenv(SecretsBackendEnvVar, backend)
+	t.Setenv(SecretsPassphraseEnvVar, passphrase)
+
+	if secrets.KeyringAvailable() {
+		t.Skip("an OS keyring is available")
+	}
+}
+
+func TestSecretStoreWithoutKeyring(t *testing.T) {
+	tests := []struct {
+		name        string
+		backend     string
+		passphrase  string
+		wantBackend string
+		wantStore   bool
+	}{
+		{name: "no backend", wantBackend: secrets.BackendPlaintext},
+		{name: "no backend with a passphrase", passphrase: "test-passphrase", wantBackend: secrets.BackendFile, wantStore: true},
+		{name: "plaintext", backend: secrets.BackendPlaintext, passphrase: "test-passphrase", wantBackend: secrets.BackendPlaintext},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupNoKeyringTest(t, tt.backend, tt.passphrase)
+
+			if got := SecretsBackend(); got != tt.wantBackend {
+				t.Errorf("SecretsBackend() = %q, want %q", got, tt.wantBackend)
+			}
+
+			store, err := SecretStore()
+			if err != nil {
+				t.Fatalf("SecretStore() error = %v", err)
+			}
+			if (store != nil) != tt.wantStore {
+				t.Errorf("SecretStore() = %v, want a store %v", store, tt.wantStore)
+			}
+		})
+	}
+}
+
+// Login stores the token in the default backend, which has to work on hosts
+// without a keyring and without a passphrase.
+func TestStoreProfileSecretsWithoutKeyring(t *testing.T) {
+	setupNoKeyringTest(t, "", "")
+
+	session := SessionConfig{ApiURL: "https://api.example.com", Token: oauth2.Token{AccessToken: "access", RefreshToken: "refresh"}}
+	if err := StoreProfileSecrets("default", &session, SecretsBackend()); err != nil {
+		t.Fatalf("StoreProfileSecrets() error = %v", err)
+	}
+	if session.SecretsBackend != "" || session.Token.AccessToken != "access" {
+		t.Errorf("session = backend %q, access token %q, want the token kept in the session", session.SecretsBackend, session.Token.AccessToken)
+	}
+
+	if err := Save(&Config{Auth: AuthConfig{ActiveProfile: "default", Profiles: map[ProfileName]SessionConfig{"default": session}}}); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := GetProfileByName("default")
+	if err != nil {
+		t.Fatalf("GetProfileByName() error = %v", err)
+	}
+	if loaded.Token.RefreshToken != "refresh" {
+		t.Errorf("loaded refresh token = %q, want %q", loaded.Token.RefreshToken, "refresh")
+	}
+}
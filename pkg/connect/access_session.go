@@ -5,6 +5,7 @@ import (
 
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
 	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/config"
 	"github.com/apono-io/apono-cli/pkg/utils"
 )
 
@@ -13,6 +14,17 @@ const CliClientID = "cli"
 type ClientFetchResult struct {
 	Clients    []clientapi.LauncherClientModel
 	ConsumedBy string
+	// LocalClientIDs are the clients that come from the launchers in the CLI
+	// config rather than from the server.
+	LocalClientIDs []string
+	// LocalClientErrors holds why configured launchers do not apply to the
+	// session, by launcher id.
+	LocalClientErrors map[string]error
+}
+
+// IsLocal reports whether the client was defined in the CLI config.
+func (r *ClientFetchResult) IsLocal(clientID string) bool {
+	return utils.Contains(r.LocalClientIDs, clientID)
 }
 
 func FetchAccessDetails(ctx context.Context, apiClient *aponoapi.AponoClient, sessionID string) (*clientapi.AccessSessionDetailsClientModel, error) {
@@ -27,7 +39,17 @@ func FetchAccessDetails(ctx context.Context, apiClient *aponoapi.AponoClient, se
 }
 
 func BuildClientFetchResult(details *clientapi.AccessSessionDetailsClientModel) *ClientFetchResult {
-	clients := details.Launchers
+	return buildClientFetchResult(details, config.GetLaunchers())
+}
+
+func buildClientFetchResult(details *clientapi.AccessSessionDetailsClientModel, launchers []config.LauncherConfig) *ClientFetchResult {
+	localClients, localErrors := RenderLocalLaunchers(launchers, details.Json)
+	localIDs := make([]string, 0, len(localClients))
+	for _, c := range localClients {
+		localIDs = append(localIDs, c.Id)
+	}
+
+	clients := mergeLaunchers(details.Launchers, localClients)
 	if cli := utils.FromNullableString(details.Cli); cli != "" {
 		clients = append(clients, clientapi.LauncherClientModel{
 			Id:                CliClientID,
@@ -36,8 +58,10 @@ func BuildClientFetchResult(details *clientapi.AccessSessionDetailsClientModel)
 		})
 	}
 	return &ClientFetchResult{
-		Clients:    clients,
-		ConsumedBy: utils.FromNullableString(details.ConsumedBy),
+		Clients:           clients,
+		ConsumedBy:        utils.FromNullableString(details.ConsumedBy),
+		LocalClientIDs:    localIDs,
+		LocalClientErrors: localErrors,
 	}
 }
 
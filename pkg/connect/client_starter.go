@@ -48,6 +48,9 @@ type ClientStarter struct {
 	BuildTerminalLaunchCommand func(string) (string, error)
 	IsRunningInTerminal        func() bool
 	Report                     func(ctx context.Context, level, message string, fields map[string]string)
+	// Multiplexer opens terminal launchers in a new tmux or screen window or
+	// split instead of the current pane when set.
+	Multiplexer *MultiplexerLaunch
 
 	secrets []string
 }
@@ -99,6 +102,11 @@ func (s *ClientStarter) Start(cobraCmd *cobra.Command, apiClient *aponoapi.Apono
 
 	client, ok := findClient(result.Clients, clientID)
 	if !ok {
+		if localErr, isLocal := result.LocalClientErrors[clientID]; isLocal {
+			err = fmt.Errorf("launcher %q from your CLI config does not apply to this session: %w", clientID, localErr)
+			s.reportLauncher(ctx, logshipping.LevelWarn, "launcher: local launcher not applicable", err, sessionID, clientID, "", isTerminal)
+			return err
+		}
 		err = fmt.Errorf("client %q is not supported yet.\nSupported clients for this session: %s.\nYou can still copy the connection command and run it manually in your preferred client", clientID, availableIDs(result.Clients))
 		s.reportLauncher(ctx, logshipping.LevelWarn, "launcher: client not supported", err, sessionID, clientID, "", isTerminal)
 		return err
@@ -144,6 +152,9 @@ func (s *ClientStarter) Start(cobraCmd *cobra.Command, apiClient *aponoapi.Apono
 		return nil
 
 	case ClientKindTUI, ClientKindTERMINAL, ClientKindCLI:
+		if !headlessTerminalLauncher && s.Multiplexer != nil {
+			return s.launchInMultiplexer(cobraCmd, invocationCommand, sessionID, clientID, launcherType, isTerminal)
+		}
 		if !headlessTerminalLauncher {
 			exitCode, launchErr := s.executeCommand(cobraCmd, invocationCommand)
 			if launchErr != nil {
@@ -177,6 +188,22 @@ func (s *ClientStarter) Start(cobraCmd *cobra.Command, apiClient *aponoapi.Apono
 	}
 }
 
+func (s *ClientStarter) launchInMultiplexer(cobraCmd *cobra.Command, invocationCommand, sessionID, clientID, launcherType string, isTerminal bool) error {
+	ctx := cobraCmd.Context()
+	muxCommand, err := s.Multiplexer.BuildCommand(invocationCommand)
+	if err != nil {
+		s.reportLauncher(ctx, logshipping.LevelWarn, "launcher: build multiplexer command failed", err, sessionID, clientID, launcherType, isTerminal)
+		return fmt.Errorf("build %s command: %w", s.Multiplexer.Multiplexer, err)
+	}
+	exitCode, err := s.executeCommand(cobraCmd, muxCommand)
+	if err != nil {
+		s.reportCommandFailure(ctx, logshipping.LevelWarn, "launcher: multiplexer launch failed", exitCode, err, sessionID, clientID, launcherType, isTerminal)
+		return err
+	}
+	s.reportLauncher(ctx, logshipping.LevelInfo, "launcher: client launched", nil, sessionID, clientID, launcherType, isTerminal)
+	return nil
+}
+
 func (s *ClientStarter) executeCommand(cobraCmd *cobra.Command, command string) (int, error) {
 	exitCode, stderr, err := s.RunShellCommand(cobraCmd, command)
 	if err != nil {
@@ -15,7 +15,9 @@ import (
 
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
 	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/config"
 	"github.com/apono-io/apono-cli/pkg/logshipping"
+	"github.com/apono-io/apono-cli/pkg/secrets"
 )
 
 type runShellCall struct {
@@ -450,6 +452,7 @@ func TestAvailableIDs_sorted(t *testing.T) {
 func TestStart_substitutesPasswordPlaceholder_withURLEncoding(t *testing.T) {
 	home := t.TempDir()
 	t.Setenv("HOME", home)
+	t.Setenv(config.SecretsBackendEnvVar, secrets.BackendPlaintext)
 	cacheDir := filepath.Join(home, ".apono", "cache")
 	if err := os.MkdirAll(cacheDir, 0o700); err != nil {
 		t.Fatalf("mkdir cache: %v", err)
@@ -644,6 +647,7 @@ func TestStart_launchFails_levelFollowsTheFailureText(t *testing.T) {
 func TestStart_launchFails_shippedTextHoldsNoPassword(t *testing.T) {
 	home := t.TempDir()
 	t.Setenv("HOME", home)
+	t.Setenv(config.SecretsBackendEnvVar, secrets.BackendPlaintext)
 	cacheDir := filepath.Join(home, ".apono", "cache")
 	if err := os.MkdirAll(cacheDir, 0o700); err != nil {
 		t.Fatalf("mkdir cache: %v", err)
@@ -696,6 +700,7 @@ func TestStart_launchFails_shippedTextHoldsNoPassword(t *testing.T) {
 func TestStart_headlessLaunchFails_shippedTextHoldsNoPassword(t *testing.T) {
 	home := t.TempDir()
 	t.Setenv("HOME", home)
+	t.Setenv(config.SecretsBackendEnvVar, secrets.BackendPlaintext)
 	cacheDir := filepath.Join(home, ".apono", "cache")
 	if err := os.MkdirAll(cacheDir, 0o700); err != nil {
 		t.Fatalf("mkdir cache: %v", err)
@@ -750,6 +755,7 @@ func TestStart_headlessLaunchFails_shippedTextHoldsNoPassword(t *testing.T) {
 func TestStart_wrapBuilderFails_shippedTextHoldsNoPassword(t *testing.T) {
 	home := t.TempDir()
 	t.Setenv("HOME", home)
+	t.Setenv(config.SecretsBackendEnvVar, secrets.BackendPlaintext)
 	cacheDir := filepath.Join(home, ".apono", "cache")
 	if err := os.MkdirAll(cacheDir, 0o700); err != nil {
 		t.Fatalf("mkdir cache: %v", err)
@@ -803,6 +809,7 @@ func TestStart_noPlaceholder_skipsCacheRead(t *testing.T) {
 	// readCachedPassword would error. dbeaver's invocation has no placeholder,
 	// so the cache must not be read.
 	t.Setenv("HOME", t.TempDir())
+	t.Setenv(config.SecretsBackendEnvVar, secrets.BackendPlaintext)
 
 	clients := []clientapi.LauncherClientModel{
 		newClientModel("dbeaver", ClientKindGUI, "echo setup", `dbeaver -con "host=h|password=$(base64 -d -i ~/.apono/cache/sess-1)"`),
@@ -819,6 +826,7 @@ func TestStart_noPlaceholder_skipsCacheRead(t *testing.T) {
 
 func TestStart_placeholderButCacheMissing_returnsError(t *testing.T) {
 	t.Setenv("HOME", t.TempDir())
+	t.Setenv(config.SecretsBackendEnvVar, secrets.BackendPlaintext)
 
 	tableplus := clientapi.LauncherClientModel{
 		Id:                "tableplus",
@@ -933,6 +941,7 @@ func TestStart_authFirstThenSubstitution(t *testing.T) {
 	// fresh cache populated by auth, not a stale or missing one.
 	home := t.TempDir()
 	t.Setenv("HOME", home)
+	t.Setenv(config.SecretsBackendEnvVar, secrets.BackendPlaintext)
 	cacheDir := filepath.Join(home, ".apono", "cache")
 	if err := os.MkdirAll(cacheDir, 0o700); err != nil {
 		t.Fatalf("mkdir cache: %v", err)
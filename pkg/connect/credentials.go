@@ -9,6 +9,8 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/apono-io/apono-cli/pkg/config"
+	"github.com/apono-io/apono-cli/pkg/secrets"
 	"github.com/apono-io/apono-cli/pkg/utils"
 )
 
@@ -20,17 +22,35 @@ const (
 	redactedMarker = "***"
 )
 
+// readCachedPassword reads the session password the auth command wrote to
+// the cache dir. When secrets are kept out of plaintext files, the password
+// is moved to the secret store and read from there on the next launches.
 func readCachedPassword(sessionID string) (string, error) {
+	store, err := config.SecretStore()
+	if err != nil {
+		return "", fmt.Errorf("open secret store: %w", err)
+	}
+
 	path := filepath.Join(utils.DefaultCacheDir(), sessionID)
 	raw, err := os.ReadFile(filepath.Clean(path))
 	if err != nil {
+		if store != nil && os.IsNotExist(err) {
+			if password, getErr := store.Get(secrets.SessionPasswordKey(sessionID)); getErr == nil {
+				return password, nil
+			}
+		}
 		return "", fmt.Errorf("read cache file: %w", err)
 	}
 	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(raw)))
 	if err != nil {
 		return "", fmt.Errorf("decode cache content: %w", err)
 	}
-	return strings.TrimRight(string(decoded), "\n\r"), nil
+	password := strings.TrimRight(string(decoded), "\n\r")
+
+	if store != nil && store.Set(secrets.SessionPasswordKey(sessionID), password) == nil {
+		_ = os.Remove(path)
+	}
+	return password, nil
 }
 
 func encodePassword(raw, encoding string) string {
@@ -6,6 +6,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/apono-io/apono-cli/pkg/config"
+	"github.com/apono-io/apono-cli/pkg/secrets"
 )
 
 const passwordWithSpecials = `p@ss w&rd!`
@@ -105,6 +108,7 @@ func TestEncodePassword_unknownEncoding_fallsBackToRaw(t *testing.T) {
 func TestReadCachedPassword_returnsDecodedContent(t *testing.T) {
 	home := t.TempDir()
 	t.Setenv("HOME", home)
+	t.Setenv(config.SecretsBackendEnvVar, secrets.BackendPlaintext)
 	cacheDir := filepath.Join(home, ".apono", "cache")
 	if err := os.MkdirAll(cacheDir, 0o700); err != nil {
 		t.Fatalf("mkdir cache: %v", err)
@@ -126,6 +130,7 @@ func TestReadCachedPassword_returnsDecodedContent(t *testing.T) {
 
 func TestReadCachedPassword_missingFile_errorWrapped(t *testing.T) {
 	t.Setenv("HOME", t.TempDir())
+	t.Setenv(config.SecretsBackendEnvVar, secrets.BackendPlaintext)
 	_, err := readCachedPassword("does-not-exist")
 	if err == nil {
 		t.Fatal("expected error for missing cache file, got nil")
@@ -139,6 +144,7 @@ func TestReadCachedPassword_stripsTrailingNewlineFromPayload(t *testing.T) {
 	// the password.
 	home := t.TempDir()
 	t.Setenv("HOME", home)
+	t.Setenv(config.SecretsBackendEnvVar, secrets.BackendPlaintext)
 	cacheDir := filepath.Join(home, ".apono", "cache")
 	if err := os.MkdirAll(cacheDir, 0o700); err != nil {
 		t.Fatalf("mkdir cache: %v", err)
@@ -160,6 +166,7 @@ func TestReadCachedPassword_stripsTrailingNewlineFromPayload(t *testing.T) {
 func TestReadCachedPassword_invalidBase64_errorWrapped(t *testing.T) {
 	home := t.TempDir()
 	t.Setenv("HOME", home)
+	t.Setenv(config.SecretsBackendEnvVar, secrets.BackendPlaintext)
 	cacheDir := filepath.Join(home, ".apono", "cache")
 	if err := os.MkdirAll(cacheDir, 0o700); err != nil {
 		t.Fatalf("mkdir cache: %v", err)
@@ -1,11 +1,15 @@
 package connect
 
 import (
+	"bufio"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
+	"strings"
 
 	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/utils"
 )
 
 const (
@@ -13,17 +17,35 @@ const (
 	systemAppsDir  = "/Applications"
 	setappAppsDir  = "/Applications/Setapp"
 	userAppsSubdir = "Applications"
+
+	desktopEntryExt       = ".desktop"
+	desktopEntryNameKey   = "Name="
+	xdgDataHomeEnvVar     = "XDG_DATA_HOME"
+	xdgDataDirsEnvVar     = "XDG_DATA_DIRS"
+	defaultXDGDataDirs    = "/usr/local/share:/usr/share"
+	xdgApplicationsSubdir = "applications"
 )
 
+// Flatpak and snap export their desktop entries outside of the default
+// XDG data dirs when those are not configured by the session.
+var extraDesktopEntryDirs = []string{
+	"/var/lib/flatpak/exports/share/applications",
+	"/var/lib/snapd/desktop/applications",
+}
+
 // IsInstalled reports whether the given launcher client is launchable on
 // this machine. TERMINAL is always considered installed; GUI checks for a
-// .app bundle in known macOS app prefixes; TUI/CLI checks $PATH.
+// .app bundle in known macOS app prefixes, and on Linux for a .desktop entry
+// or a binary on $PATH; TUI/CLI checks $PATH.
 func IsInstalled(client clientapi.LauncherClientModel) bool {
 	switch client.LauncherType {
 	case ClientKindTERMINAL:
 		return true
 	case ClientKindGUI:
-		return guiBundleExists(client.Id)
+		if guiBundleExists(client.Id) {
+			return true
+		}
+		return runtime.GOOS == utils.LinuxOS && linuxGUIExists(client.Id)
 	case ClientKindTUI, ClientKindCLI:
 		_, err := exec.LookPath(client.Id)
 		return err == nil
@@ -47,3 +69,88 @@ func guiBundleExists(id string) bool {
 	}
 	return false
 }
+
+func linuxGUIExists(id string) bool {
+	if _, err := exec.LookPath(strings.ToLower(id)); err == nil {
+		return true
+	}
+	return desktopEntryExists(id, desktopEntryDirs())
+}
+
+// desktopEntryDirs lists the applications dirs of the XDG data dirs, user
+// dirs first, followed by the flatpak and snap export dirs.
+func desktopEntryDirs() []string {
+	home, _ := os.UserHomeDir()
+	dataHome := os.Getenv(xdgDataHomeEnvVar)
+	if dataHome == "" && home != "" {
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	dataDirs := os.Getenv(xdgDataDirsEnvVar)
+	if dataDirs == "" {
+		dataDirs = defaultXDGDataDirs
+	}
+
+	var dirs []string
+	if dataHome != "" {
+		dirs = append(dirs,
+			filepath.Join(dataHome, xdgApplicationsSubdir),
+			filepath.Join(dataHome, "flatpak", "exports", "share", xdgApplicationsSubdir),
+		)
+	}
+	for _, dir := range filepath.SplitList(dataDirs) {
+		if dir != "" {
+			dirs = append(dirs, filepath.Join(dir, xdgApplicationsSubdir))
+		}
+	}
+	return append(dirs, extraDesktopEntryDirs...)
+}
+
+// desktopEntryExists looks for a .desktop entry whose file name or Name= key
+// matches the client id. File names are matched per dot or dash separated
+// part, so "dbeaver" matches both dbeaver-ce.desktop and
+// io.dbeaver.DBeaverCommunity.desktop.
+func desktopEntryExists(id string, dirs []string) bool {
+	id = strings.ToLower(id)
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := strings.ToLower(entry.Name())
+			if entry.IsDir() || !strings.HasSuffix(name, desktopEntryExt) {
+				continue
+			}
+			if desktopFileNameMatches(strings.TrimSuffix(name, desktopEntryExt), id) ||
+				desktopEntryNameMatches(filepath.Join(dir, entry.Name()), id) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func desktopFileNameMatches(fileName, id string) bool {
+	if fileName == id {
+		return true
+	}
+	parts := strings.FieldsFunc(fileName, func(r rune) bool { return r == '.' || r == '-' })
+	return utils.Contains(parts, id)
+}
+
+func desktopEntryNameMatches(path, id string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if name, ok := strings.CutPrefix(line, desktopEntryNameKey); ok {
+			return strings.EqualFold(strings.TrimSpace(name), id)
+		}
+	}
+	return false
+}
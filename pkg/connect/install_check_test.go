@@ -103,3 +103,56 @@ func TestIsInstalled_CLI_onPATH(t *testing.T) {
 		t.Errorf("IsInstalled(CLI on PATH) = false, want true")
 	}
 }
+
+func TestDesktopEntryExists_matchesFileNameParts(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"io.dbeaver.DBeaverCommunity.desktop", "tableplus-beta.desktop"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("[Desktop Entry]\nName=Something\n"), 0o600); err != nil {
+			t.Fatalf("write desktop entry: %v", err)
+		}
+	}
+
+	for _, id := range []string{"dbeaver", "TablePlus"} {
+		if !desktopEntryExists(id, []string{dir}) {
+			t.Errorf("desktopEntryExists(%q) = false, want true", id)
+		}
+	}
+	if desktopEntryExists("beta-client", []string{dir}) {
+		t.Errorf("desktopEntryExists(beta-client) = true, want false")
+	}
+}
+
+func TestDesktopEntryExists_matchesNameKey(t *testing.T) {
+	dir := t.TempDir()
+	body := "[Desktop Entry]\nType=Application\nName=Studio 3T\nExec=/opt/studio3t/bin\n"
+	if err := os.WriteFile(filepath.Join(dir, "com.example.s3t.desktop"), []byte(body), 0o600); err != nil {
+		t.Fatalf("write desktop entry: %v", err)
+	}
+
+	if !desktopEntryExists("studio 3t", []string{dir, filepath.Join(dir, "missing")}) {
+		t.Errorf("desktopEntryExists(by Name=) = false, want true")
+	}
+}
+
+func TestDesktopEntryDirs_honorsXDGEnv(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", "/home/me/.data")
+	t.Setenv("XDG_DATA_DIRS", "/opt/share:/usr/share")
+
+	dirs := desktopEntryDirs()
+	for _, want := range []string{
+		"/home/me/.data/applications",
+		"/opt/share/applications",
+		"/usr/share/applications",
+		"/var/lib/flatpak/exports/share/applications",
+	} {
+		found := false
+		for _, dir := range dirs {
+			if dir == want {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("desktopEntryDirs() missing %q, got %v", want, dirs)
+		}
+	}
+}
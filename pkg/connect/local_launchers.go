@@ -0,0 +1,136 @@
+package connect
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/config"
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+var localLauncherFuncs = template.FuncMap{
+	"shellquote": func(value interface{}) string {
+		return utils.ShellQuote(fmt.Sprint(value))
+	},
+}
+
+// ValidateLocalLauncher checks a launcher before it is saved to the config.
+func ValidateLocalLauncher(launcher config.LauncherConfig) error {
+	if strings.TrimSpace(launcher.ID) == "" {
+		return fmt.Errorf("launcher id is required")
+	}
+	if launcher.ID == CliClientID {
+		return fmt.Errorf("launcher id %q is reserved for the session CLI command", CliClientID)
+	}
+
+	switch launcher.Kind {
+	case ClientKindGUI, ClientKindTUI, ClientKindCLI:
+	default:
+		return fmt.Errorf("invalid launcher kind %q, valid values are '%s', '%s' or '%s'", launcher.Kind, ClientKindGUI, ClientKindTUI, ClientKindCLI)
+	}
+
+	if launcher.PasswordEncoding != "" && launcher.PasswordEncoding != passwordEncodingURL {
+		return fmt.Errorf("invalid password encoding %q, the only supported encoding is '%s'", launcher.PasswordEncoding, passwordEncodingURL)
+	}
+
+	if strings.TrimSpace(launcher.Command) == "" {
+		return fmt.Errorf("launcher command is required")
+	}
+	if _, err := parseLauncherTemplate("command", launcher.Command); err != nil {
+		return err
+	}
+	if _, err := parseLauncherTemplate("auth command", launcher.AuthCommand); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RenderLocalLaunchers renders the configured launchers against the JSON
+// access details of a session. Launchers that reference fields the session
+// does not have are left out and their error is returned by id.
+func RenderLocalLaunchers(launchers []config.LauncherConfig, accessDetails map[string]interface{}) ([]clientapi.LauncherClientModel, map[string]error) {
+	var clients []clientapi.LauncherClientModel
+	errs := make(map[string]error)
+	for _, launcher := range launchers {
+		client, err := renderLocalLauncher(launcher, accessDetails)
+		if err != nil {
+			errs[launcher.ID] = err
+			continue
+		}
+		clients = append(clients, client)
+	}
+
+	return clients, errs
+}
+
+func renderLocalLauncher(launcher config.LauncherConfig, accessDetails map[string]interface{}) (clientapi.LauncherClientModel, error) {
+	invocationCommand, err := renderLauncherTemplate("command", launcher.Command, accessDetails)
+	if err != nil {
+		return clientapi.LauncherClientModel{}, err
+	}
+
+	authCommand, err := renderLauncherTemplate("auth command", launcher.AuthCommand, accessDetails)
+	if err != nil {
+		return clientapi.LauncherClientModel{}, err
+	}
+
+	displayName := launcher.DisplayName
+	if displayName == "" {
+		displayName = launcher.ID
+	}
+
+	client := clientapi.LauncherClientModel{
+		Id:                launcher.ID,
+		DisplayName:       displayName,
+		LauncherType:      launcher.Kind,
+		InvocationCommand: invocationCommand,
+		PasswordEncoding:  launcher.PasswordEncoding,
+	}
+	if authCommand != "" {
+		client.AuthCommand = *clientapi.NewNullableString(&authCommand)
+	}
+
+	return client, nil
+}
+
+func parseLauncherTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(localLauncherFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid launcher %s template: %w", name, err)
+	}
+	return tmpl, nil
+}
+
+func renderLauncherTemplate(name, text string, accessDetails map[string]interface{}) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	tmpl, err := parseLauncherTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+
+	var rendered bytes.Buffer
+	if err = tmpl.Execute(&rendered, accessDetails); err != nil {
+		return "", fmt.Errorf("render launcher %s: %w", name, err)
+	}
+	return rendered.String(), nil
+}
+
+// mergeLaunchers appends the local launchers to the server provided ones. A
+// local launcher replaces a server launcher with the same id, so users can
+// override how a client is opened.
+func mergeLaunchers(server, local []clientapi.LauncherClientModel) []clientapi.LauncherClientModel {
+	merged := make([]clientapi.LauncherClientModel, 0, len(server)+len(local))
+	for _, client := range server {
+		if _, overridden := findClient(local, client.Id); !overridden {
+			merged = append(merged, client)
+		}
+	}
+	return append(merged, local...)
+}
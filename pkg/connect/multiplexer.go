@@ -0,0 +1,228 @@
+package connect
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/config"
+	"github.com/apono-io/apono-cli/pkg/terminal"
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+const (
+	MultiplexerTmux   = "tmux"
+	MultiplexerScreen = "screen"
+
+	MultiplexerModeInline = "inline"
+	MultiplexerModeWindow = "window"
+	MultiplexerModeSplit  = "split"
+
+	MultiplexerOnExpiryMark  = "mark"
+	MultiplexerOnExpiryClose = "close"
+
+	tmuxEnvVar     = "TMUX"
+	screenEnvVar   = "STY"
+	expiredSuffix  = " (expired)"
+	profileFlag    = "profile"
+	multiplexShell = "bash"
+)
+
+// watchEnvVars configure the watcher the same way as the CLI that launched it.
+// tmux runs it from the server, whose environment is not the caller's.
+var watchEnvVars = []string{
+	config.TokenEnvVar,
+	config.ProfileEnvVar,
+	config.APIURLEnvVar,
+	config.AppURLEnvVar,
+	config.PortalURLEnvVar,
+	config.ConfigDirEnvVar,
+	config.SecretsBackendEnvVar,
+	config.SecretsPassphraseEnvVar,
+}
+
+// MultiplexerLaunch opens a session command in a new tmux or screen window or
+// split, named after the session, instead of taking over the current pane.
+type MultiplexerLaunch struct {
+	Multiplexer string
+	Mode        string
+	OnExpiry    string
+	Name        string
+	// WatchCommand exits once the session expired, after which the window is
+	// closed or marked according to OnExpiry.
+	WatchCommand string
+}
+
+// DetectMultiplexer returns the terminal multiplexer the CLI runs in, or an
+// empty string when there is none.
+func DetectMultiplexer() string {
+	switch {
+	case os.Getenv(tmuxEnvVar) != "":
+		return MultiplexerTmux
+	case os.Getenv(screenEnvVar) != "":
+		return MultiplexerScreen
+	default:
+		return ""
+	}
+}
+
+func ParseMultiplexerMode(value string) (string, error) {
+	switch value {
+	case MultiplexerModeInline, MultiplexerModeWindow, MultiplexerModeSplit:
+		return value, nil
+	default:
+		return "", fmt.Errorf("invalid multiplexer mode %q, valid values are '%s', '%s' or '%s'", value, MultiplexerModeInline, MultiplexerModeWindow, MultiplexerModeSplit)
+	}
+}
+
+func ParseMultiplexerOnExpiry(value string) (string, error) {
+	switch value {
+	case MultiplexerOnExpiryMark, MultiplexerOnExpiryClose:
+		return value, nil
+	default:
+		return "", fmt.Errorf("invalid multiplexer expiry action %q, valid values are '%s' or '%s'", value, MultiplexerOnExpiryMark, MultiplexerOnExpiryClose)
+	}
+}
+
+// ResolveMultiplexerLaunch returns how to open the session under tmux or
+// screen, or nil when it should run in the current pane. modeOverride comes
+// from --window/--pane and takes precedence over the multiplexer.mode config.
+func ResolveMultiplexerLaunch(cmd *cobra.Command, modeOverride, sessionID, sessionName string) (*MultiplexerLaunch, error) {
+	cfg := config.GetMultiplexerConfig()
+	multiplexer := DetectMultiplexer()
+
+	mode := modeOverride
+	if mode == "" {
+		mode = cfg.Mode
+	}
+	if mode == "" || mode == MultiplexerModeInline {
+		return nil, nil
+	}
+	if multiplexer == "" {
+		if modeOverride != "" {
+			return nil, fmt.Errorf("opening the session in a new %s requires running inside tmux or screen", modeOverride)
+		}
+		return nil, nil
+	}
+
+	onExpiry := cfg.OnExpiry
+	if onExpiry == "" {
+		onExpiry = MultiplexerOnExpiryMark
+	}
+
+	watchCommand, err := buildWatchCommand(cmd, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := sessionName
+	if name == "" {
+		name = sessionID
+	}
+
+	return &MultiplexerLaunch{
+		Multiplexer:  multiplexer,
+		Mode:         mode,
+		OnExpiry:     onExpiry,
+		Name:         multiplexerWindowName(name),
+		WatchCommand: watchCommand,
+	}, nil
+}
+
+// BuildCommand writes the command to a launch script and returns the shell
+// command that opens it in the multiplexer.
+func (l *MultiplexerLaunch) BuildCommand(command string) (string, error) {
+	scriptPath, err := terminal.WriteBashLaunchScript(command)
+	if err != nil {
+		return "", fmt.Errorf("write launch script: %w", err)
+	}
+	return l.buildCommand(scriptPath), nil
+}
+
+func (l *MultiplexerLaunch) buildCommand(scriptPath string) string {
+	watch := escapeDoubleQuoted(l.WatchCommand)
+	run := fmt.Sprintf("%s -il %s", multiplexShell, utils.ShellQuote(scriptPath))
+	name := utils.ShellQuote(l.Name)
+	expiredName := utils.ShellQuote(l.Name + expiredSuffix)
+
+	if l.Multiplexer == MultiplexerScreen {
+		open := fmt.Sprintf("screen -X screen -t %s %s", name, run)
+		if l.Mode == MultiplexerModeSplit {
+			open = "screen -X split && screen -X focus && " + open
+		}
+		onExpiry := fmt.Sprintf("screen -p %s -X title %s", name, expiredName)
+		if l.OnExpiry == MultiplexerOnExpiryClose {
+			onExpiry = fmt.Sprintf("screen -p %s -X kill", name)
+		}
+		return fmt.Sprintf(`%s && (nohup sh -c "%s >/dev/null 2>&1 && %s" >/dev/null 2>&1 &)`, open, watch, onExpiry)
+	}
+
+	open := fmt.Sprintf("tmux new-window -P -F '#{window_id}' -n %s %s", name, run)
+	title := ""
+	onExpiry := fmt.Sprintf("tmux rename-window -t $target %s", expiredName)
+	closeCommand := "tmux kill-window -t $target"
+	if l.Mode == MultiplexerModeSplit {
+		open = fmt.Sprintf("tmux split-window -P -F '#{pane_id}' %s", run)
+		title = fmt.Sprintf(" && tmux select-pane -t $target -T %s", name)
+		onExpiry = fmt.Sprintf("tmux select-pane -t $target -T %s", expiredName)
+		closeCommand = "tmux kill-pane -t $target"
+	}
+	if l.OnExpiry == MultiplexerOnExpiryClose {
+		onExpiry = closeCommand
+	}
+	return fmt.Sprintf(`target=$(%s)%s && tmux run-shell -b "%s >/dev/null 2>&1 && %s"`, open, title, watch, onExpiry)
+}
+
+func buildWatchCommand(cmd *cobra.Command, sessionID string) (string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("resolve apono executable: %w", err)
+	}
+
+	var env []string
+	for _, name := range watchEnvVars {
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+utils.ShellQuote(value))
+		}
+	}
+
+	watch := fmt.Sprintf("%s access watch %s", utils.ShellQuote(executable), utils.ShellQuote(sessionID))
+	if len(env) > 0 {
+		watch = "env " + strings.Join(env, " ") + " " + watch
+	}
+	if profile, _ := cmd.Flags().GetString(profileFlag); profile != "" {
+		watch += " --profile " + utils.ShellQuote(profile)
+	}
+	return watch, nil
+}
+
+// escapeDoubleQuoted escapes the characters the shell still interprets inside
+// double quotes, as the watch command is nested in the double quoted command
+// the multiplexer runs.
+func escapeDoubleQuoted(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if strings.ContainsRune("\\\"$`", r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// multiplexerWindowName keeps the characters that are safe inside the double
+// quoted commands the multiplexer runs after the session expires.
+func multiplexerWindowName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case strings.ContainsRune(" ._-/:@", r):
+			return r
+		default:
+			return '-'
+		}
+	}, name)
+}
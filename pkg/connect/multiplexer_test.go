@@ -0,0 +1,169 @@
+package connect
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/config"
+)
+
+const testWatchCommand = "'/usr/local/bin/apono' access watch 'sess-1'"
+
+func TestDetectMultiplexer(t *testing.T) {
+	tests := map[string]struct {
+		tmux, sty string
+		want      string
+	}{
+		"none":   {want: ""},
+		"tmux":   {tmux: "/tmp/tmux-1000/default,1,0", want: MultiplexerTmux},
+		"screen": {sty: "1234.pts-0.host", want: MultiplexerScreen},
+		"both":   {tmux: "/tmp/tmux", sty: "1234", want: MultiplexerTmux},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(tmuxEnvVar, tt.tmux)
+			t.Setenv(screenEnvVar, tt.sty)
+			if got := DetectMultiplexer(); got != tt.want {
+				t.Errorf("DetectMultiplexer() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMultiplexerLaunchBuildCommand(t *testing.T) {
+	tests := map[string]struct {
+		launch MultiplexerLaunch
+		want   []string
+	}{
+		"tmux window marked on expiry": {
+			launch: MultiplexerLaunch{Multiplexer: MultiplexerTmux, Mode: MultiplexerModeWindow, OnExpiry: MultiplexerOnExpiryMark},
+			want: []string{
+				`target=$(tmux new-window -P -F '#{window_id}' -n 'prod-db' bash -il '/tmp/launch.sh')`,
+				`tmux run-shell -b "` + testWatchCommand + ` >/dev/null 2>&1 && tmux rename-window -t $target 'prod-db (expired)'"`,
+			},
+		},
+		"tmux split closed on expiry": {
+			launch: MultiplexerLaunch{Multiplexer: MultiplexerTmux, Mode: MultiplexerModeSplit, OnExpiry: MultiplexerOnExpiryClose},
+			want: []string{
+				`target=$(tmux split-window -P -F '#{pane_id}' bash -il '/tmp/launch.sh')`,
+				`tmux select-pane -t $target -T 'prod-db'`,
+				`&& tmux kill-pane -t $target"`,
+			},
+		},
+		"screen window marked on expiry": {
+			launch: MultiplexerLaunch{Multiplexer: MultiplexerScreen, Mode: MultiplexerModeWindow, OnExpiry: MultiplexerOnExpiryMark},
+			want: []string{
+				`screen -X screen -t 'prod-db' bash -il '/tmp/launch.sh'`,
+				`nohup sh -c "` + testWatchCommand + ` >/dev/null 2>&1 && screen -p 'prod-db' -X title 'prod-db (expired)'"`,
+			},
+		},
+		"screen split closed on expiry": {
+			launch: MultiplexerLaunch{Multiplexer: MultiplexerScreen, Mode: MultiplexerModeSplit, OnExpiry: MultiplexerOnExpiryClose},
+			want:   []string{`screen -X split && screen -X focus && screen -X screen -t 'prod-db'`, `screen -p 'prod-db' -X kill`},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tt.launch.Name = "prod-db"
+			tt.launch.WatchCommand = testWatchCommand
+			got := tt.launch.buildCommand("/tmp/launch.sh")
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("command missing %q, got:\n%s", want, got)
+				}
+			}
+			if out, err := exec.Command("sh", "-n", "-c", got).CombinedOutput(); err != nil {
+				t.Errorf("generated command is not valid shell: %v: %s", err, out)
+			}
+		})
+	}
+}
+
+func TestMultiplexerWindowName_replacesShellSpecialCharacters(t *testing.T) {
+	got := multiplexerWindowName(`db "prod"/$USER's`)
+	if got != "db -prod-/-USER-s" {
+		t.Errorf("multiplexerWindowName() = %q", got)
+	}
+}
+
+func TestResolveMultiplexerLaunch_overrideOutsideMultiplexer_errors(t *testing.T) {
+	t.Setenv(tmuxEnvVar, "")
+	t.Setenv(screenEnvVar, "")
+
+	_, err := ResolveMultiplexerLaunch(&cobra.Command{}, MultiplexerModeWindow, "sess-1", "prod-db")
+	if err == nil || !strings.Contains(err.Error(), "tmux or screen") {
+		t.Errorf("expected error about tmux or screen, got %v", err)
+	}
+}
+
+func TestResolveMultiplexerLaunch_overrideInsideTmux(t *testing.T) {
+	t.Setenv(tmuxEnvVar, "/tmp/tmux-1000/default,1,0")
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String(profileFlag, "", "")
+	_ = cmd.Flags().Set(profileFlag, "work")
+
+	launch, err := ResolveMultiplexerLaunch(cmd, MultiplexerModeSplit, "sess-1", "")
+	if err != nil {
+		t.Fatalf("ResolveMultiplexerLaunch: %v", err)
+	}
+	if launch.Multiplexer != MultiplexerTmux || launch.Mode != MultiplexerModeSplit || launch.Name != "sess-1" {
+		t.Errorf("unexpected launch %+v", launch)
+	}
+	if !strings.Contains(launch.WatchCommand, "access watch 'sess-1' --profile 'work'") {
+		t.Errorf("watch command should keep the profile, got %q", launch.WatchCommand)
+	}
+}
+
+func TestResolveMultiplexerLaunch_passesAponoEnvironmentToWatcher(t *testing.T) {
+	t.Setenv(tmuxEnvVar, "/tmp/tmux-1000/default,1,0")
+	for _, name := range watchEnvVars {
+		t.Setenv(name, "")
+		_ = os.Unsetenv(name)
+	}
+	t.Setenv(config.TokenEnvVar, `tok$en"'x`)
+	t.Setenv(config.APIURLEnvVar, "https://api.example.com")
+
+	launch, err := ResolveMultiplexerLaunch(&cobra.Command{}, MultiplexerModeWindow, "sess-1", "prod-db")
+	if err != nil {
+		t.Fatalf("ResolveMultiplexerLaunch: %v", err)
+	}
+	wantEnv := `env APONO_TOKEN='tok$en"'\''x' APONO_API_URL='https://api.example.com' `
+	if !strings.HasPrefix(launch.WatchCommand, wantEnv) {
+		t.Errorf("watch command should set the APONO environment, got %q", launch.WatchCommand)
+	}
+
+	// The watch command has to survive the double quotes it is nested in.
+	nested := `printf '%s' "` + escapeDoubleQuoted(launch.WatchCommand) + `"`
+	out, err := exec.Command("sh", "-c", nested).Output()
+	if err != nil {
+		t.Fatalf("sh: %v", err)
+	}
+	if string(out) != launch.WatchCommand {
+		t.Errorf("nested watch command = %q, want %q", out, launch.WatchCommand)
+	}
+}
+
+func TestStart_TUI_TTY_withMultiplexer_opensNewWindow(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+
+	clients := []clientapi.LauncherClientModel{newClientModel("k9s", ClientKindTUI, "", "k9s --context prod")}
+	s, runs, wraps := testClientStarter(true, clients, aponoapi.ConsumedByAponoCli, nil)
+	s.Multiplexer = &MultiplexerLaunch{Multiplexer: MultiplexerTmux, Mode: MultiplexerModeWindow, OnExpiry: MultiplexerOnExpiryMark, Name: "prod", WatchCommand: testWatchCommand}
+
+	if err := s.Start(newCobraCmd(), nil, "sess-1", "k9s", nil); err != nil {
+		t.Fatalf("Start returned error: %v", err)
+	}
+	if len(*runs) != 1 || !strings.HasPrefix((*runs)[0].combined, "target=$(tmux new-window") {
+		t.Errorf("expected a single tmux launch, got %+v", *runs)
+	}
+	if len(*wraps) != 0 {
+		t.Errorf("expected no terminal window wrap, got %v", *wraps)
+	}
+}
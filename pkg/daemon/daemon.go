@@ -0,0 +1,121 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/config"
+	"github.com/apono-io/apono-cli/pkg/services"
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+const (
+	DefaultInterval      = 30 * time.Second
+	DefaultExpiryWarning = 10 * time.Minute
+
+	// requestsDaysOffset bounds how far back the daemon lists requests. Older
+	// requests the tracker still follows are fetched by their IDs.
+	requestsDaysOffset = 30
+)
+
+type Options struct {
+	Interval             time.Duration
+	ExpiryWarning        time.Duration
+	DesktopNotifications bool
+	Hooks                []config.HookConfig
+	Out                  io.Writer
+}
+
+// Run polls the access requests of the current profile until ctx is done and
+// dispatches an event for every state change. Failed polls are logged and
+// retried on the next tick, so the daemon survives network hiccups.
+func Run(ctx context.Context, client *aponoapi.AponoClient, opts Options) error {
+	tracker := NewTracker(opts.ExpiryWarning)
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	logf(opts.Out, "watching access requests every %s, warning %s before expiry", opts.Interval, opts.ExpiryWarning)
+	for {
+		requests, err := listRequests(ctx, client, tracker)
+		if err != nil && ctx.Err() == nil {
+			logf(opts.Out, "failed to list access requests: %v", err)
+		}
+		if err == nil {
+			for _, event := range tracker.Update(requests, time.Now()) {
+				dispatch(ctx, client, opts, event)
+			}
+		}
+		// Listing the sessions keeps the `apono prompt` cache fresh.
+		_, _ = services.ListAccessSessions(ctx, client, nil, nil, nil)
+
+		select {
+		case <-ctx.Done():
+			logf(opts.Out, "stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// listRequests lists the recent requests, and the tracked requests that are
+// older than the listing window, such as long grants that will still expire.
+func listRequests(ctx context.Context, client *aponoapi.AponoClient, tracker *Tracker) ([]clientapi.AccessRequestClientModel, error) {
+	requests, err := services.ListRequests(ctx, client, requestsDaysOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	listed := make(map[string]bool)
+	for _, request := range requests {
+		listed[request.Id] = true
+	}
+	var missing []string
+	for _, id := range tracker.Tracked() {
+		if !listed[id] {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return requests, nil
+	}
+
+	older, err := services.ListRequestsByIDs(ctx, client, missing)
+	if err != nil {
+		return nil, err
+	}
+	return append(requests, older...), nil
+}
+
+func dispatch(ctx context.Context, client *aponoapi.AponoClient, opts Options, event Event) {
+	if event.Type == EventGranted {
+		sessions, err := services.ListAccessSessions(ctx, client, nil, nil, []string{event.RequestID})
+		if err != nil {
+			logf(opts.Out, "failed to list sessions of request %s: %v", event.RequestID, err)
+		}
+		for _, session := range sessions {
+			event.Sessions = append(event.Sessions, SessionRef{ID: session.Id, Name: session.Name})
+		}
+	}
+
+	logf(opts.Out, "%s: %s", event.Type, event.Message)
+
+	if opts.DesktopNotifications {
+		if err := Notify(ctx, event.Message); err != nil {
+			logf(opts.Out, "failed to send desktop notification: %v", err)
+		}
+	}
+
+	for _, hook := range hooksForEvent(opts.Hooks, event.Type) {
+		if err := RunHook(ctx, hook, event); err != nil {
+			logf(opts.Out, "%v", err)
+		}
+	}
+}
+
+func logf(out io.Writer, format string, args ...any) {
+	_, _ = fmt.Fprintf(out, "%s %s\n", utils.DisplayTime(time.Now()), fmt.Sprintf(format, args...))
+}
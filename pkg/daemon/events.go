@@ -0,0 +1,103 @@
+package daemon
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/services"
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+const (
+	EventGranted     = "granted"
+	EventExpiring    = "expiring"
+	EventExpired     = "expired"
+	EventRejected    = "rejected"
+	EventMFARequired = "mfa_required"
+)
+
+var EventTypes = []string{EventGranted, EventExpiring, EventExpired, EventRejected, EventMFARequired}
+
+// Event is passed as JSON on stdin to the hooks of its type.
+type Event struct {
+	Type             string       `json:"type"`
+	Time             time.Time    `json:"time"`
+	RequestID        string       `json:"request_id"`
+	Status           string       `json:"status"`
+	Integrations     []string     `json:"integrations,omitempty"`
+	Resources        []string     `json:"resources,omitempty"`
+	Justification    string       `json:"justification,omitempty"`
+	ExpiresAt        *time.Time   `json:"expires_at,omitempty"`
+	MinutesRemaining *int         `json:"minutes_remaining,omitempty"`
+	Sessions         []SessionRef `json:"sessions,omitempty"`
+	Message          string       `json:"message"`
+}
+
+type SessionRef struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func ParseEventType(value string) (string, error) {
+	for _, eventType := range EventTypes {
+		if value == eventType {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("invalid event %q, valid events are: %s", value, strings.Join(EventTypes, ", "))
+}
+
+func newEvent(eventType string, request clientapi.AccessRequestClientModel, now time.Time) Event {
+	event := Event{
+		Type:          eventType,
+		Time:          now,
+		RequestID:     request.Id,
+		Status:        request.Status.Status,
+		Resources:     request.DistinctResourceNames,
+		Justification: utils.FromNullableString(request.Justification),
+		ExpiresAt:     requestExpiry(request),
+	}
+	for _, accessGroup := range request.AccessGroups {
+		event.Integrations = append(event.Integrations, accessGroup.Integration.Name)
+	}
+
+	if event.ExpiresAt != nil && eventType == EventExpiring {
+		minutes := int(event.ExpiresAt.Sub(now).Round(time.Minute) / time.Minute)
+		event.MinutesRemaining = &minutes
+	}
+	event.Message = eventMessage(event)
+
+	return event
+}
+
+func eventMessage(event Event) string {
+	target := event.RequestID
+	if len(event.Integrations) > 0 {
+		target = fmt.Sprintf("%s (%s)", event.RequestID, strings.Join(event.Integrations, ", "))
+	}
+
+	switch event.Type {
+	case EventGranted:
+		return fmt.Sprintf("Access granted for %s", target)
+	case EventExpiring:
+		return fmt.Sprintf("Access for %s expires in %s", target, services.CompactDuration(event.ExpiresAt.Sub(event.Time)))
+	case EventExpired:
+		return fmt.Sprintf("Access for %s expired", target)
+	case EventRejected:
+		return fmt.Sprintf("Request %s was rejected", target)
+	case EventMFARequired:
+		return fmt.Sprintf("Request %s requires MFA, complete it in the Apono portal", target)
+	default:
+		return target
+	}
+}
+
+func requestExpiry(request clientapi.AccessRequestClientModel) *time.Time {
+	if !request.RevocationTime.IsSet() || request.RevocationTime.Get() == nil {
+		return nil
+	}
+	expiry := utils.ConvertUnixTimeToTime(*request.RevocationTime.Get())
+	return &expiry
+}
@@ -0,0 +1,52 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/apono-io/apono-cli/pkg/config"
+)
+
+const (
+	hookTimeout    = time.Minute
+	hookEventEnv   = "APONO_EVENT"
+	hookRequestEnv = "APONO_REQUEST_ID"
+)
+
+// RunHook runs the hook command with sh, passing the event as JSON on stdin
+// and its type and request id as environment variables.
+func RunHook(ctx context.Context, hook config.HookConfig, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, hookTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", hook.Command)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(), hookEventEnv+"="+event.Type, hookRequestEnv+"="+event.RequestID)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("hook %q: %w: %s", hook.Name, err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+func hooksForEvent(hooks []config.HookConfig, eventType string) []config.HookConfig {
+	var matching []config.HookConfig
+	for _, hook := range hooks {
+		for _, hookEvent := range hook.Events {
+			if hookEvent == eventType {
+				matching = append(matching, hook)
+				break
+			}
+		}
+	}
+	return matching
+}
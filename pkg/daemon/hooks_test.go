@@ -0,0 +1,60 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apono-io/apono-cli/pkg/config"
+)
+
+func TestRunHookPassesEventOnStdin(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "event.json")
+	hook := config.HookConfig{
+		Name:    "capture",
+		Events:  []string{EventGranted},
+		Command: `cat > "$OUT" && test "$APONO_EVENT" = granted && test "$APONO_REQUEST_ID" = r-1`,
+	}
+	t.Setenv("OUT", out)
+
+	if err := RunHook(context.Background(), hook, Event{Type: EventGranted, RequestID: "r-1", Message: "Access granted for r-1"}); err != nil {
+		t.Fatalf("RunHook() error = %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read hook output: %v", err)
+	}
+	var event Event
+	if err = json.Unmarshal(data, &event); err != nil {
+		t.Fatalf("hook stdin is not an event: %v", err)
+	}
+	if event.RequestID != "r-1" || event.Message != "Access granted for r-1" {
+		t.Errorf("hook stdin = %+v", event)
+	}
+}
+
+func TestRunHookReportsFailures(t *testing.T) {
+	hook := config.HookConfig{Name: "broken", Command: "echo boom >&2; exit 3"}
+	err := RunHook(context.Background(), hook, Event{Type: EventExpired})
+	if err == nil {
+		t.Fatal("RunHook() error = nil, want the hook failure")
+	}
+	if got := err.Error(); got != `hook "broken": exit status 3: boom` {
+		t.Errorf("RunHook() error = %q", got)
+	}
+}
+
+func TestHooksForEvent(t *testing.T) {
+	hooks := []config.HookConfig{
+		{Name: "a", Events: []string{EventGranted, EventExpired}},
+		{Name: "b", Events: []string{EventExpiring}},
+	}
+
+	matching := hooksForEvent(hooks, EventExpired)
+	if len(matching) != 1 || matching[0].Name != "a" {
+		t.Errorf("hooksForEvent() = %+v, want hook a", matching)
+	}
+}
@@ -0,0 +1,41 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+const notificationTitle = "Apono"
+
+// Notify shows a desktop notification with notify-send on Linux or osascript
+// on macOS.
+func Notify(ctx context.Context, message string) error {
+	name, args, err := notificationCommand(runtime.GOOS, message)
+	if err != nil {
+		return err
+	}
+	if _, err = exec.LookPath(name); err != nil {
+		return fmt.Errorf("%s not found on PATH", name)
+	}
+	if out, err := exec.CommandContext(ctx, name, args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %w: %s", name, err, string(out))
+	}
+	return nil
+}
+
+func notificationCommand(goos, message string) (string, []string, error) {
+	switch goos {
+	case utils.DarwinOS:
+		script := fmt.Sprintf("display notification %s with title %s", strconv.Quote(message), strconv.Quote(notificationTitle))
+		return "osascript", []string{"-e", script}, nil
+	case utils.LinuxOS:
+		return "notify-send", []string{"--app-name=apono", notificationTitle, message}, nil
+	default:
+		return "", nil, fmt.Errorf("desktop notifications are not supported on %s", goos)
+	}
+}
@@ -0,0 +1,118 @@
+package daemon
+
+import (
+	"sort"
+	"time"
+
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/services"
+)
+
+// Tracker turns polled access requests into events by comparing them with
+// the previous poll. The first poll only records the current state, so
+// starting the daemon does not replay old grants and rejections. Requests
+// missing from a poll are forgotten, so every poll has to include the
+// requests Tracked returns.
+type Tracker struct {
+	ExpiryWarning time.Duration
+
+	statuses    map[string]string
+	warned      map[string]bool
+	initialized bool
+}
+
+func NewTracker(expiryWarning time.Duration) *Tracker {
+	return &Tracker{
+		ExpiryWarning: expiryWarning,
+		statuses:      make(map[string]string),
+		warned:        make(map[string]bool),
+	}
+}
+
+// Tracked returns the IDs of the requests whose status can still change,
+// which the next poll has to include even when they are no longer listed.
+func (t *Tracker) Tracked() []string {
+	var ids []string
+	for id, status := range t.statuses {
+		if !isSettledStatus(status) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (t *Tracker) Update(requests []clientapi.AccessRequestClientModel, now time.Time) []Event {
+	var events []Event
+	polled := make(map[string]bool)
+	for _, request := range requests {
+		polled[request.Id] = true
+		status := request.Status.Status
+		previous, seen := t.statuses[request.Id]
+		t.statuses[request.Id] = status
+
+		if t.initialized && previous != status {
+			if eventType := transitionEvent(previous, seen, status); eventType != "" {
+				events = append(events, newEvent(eventType, request, now))
+			}
+		}
+
+		if status == services.AccessRequestActiveStatus && !t.warned[request.Id] && t.expiresWithinWarning(request, now) {
+			t.warned[request.Id] = true
+			events = append(events, newEvent(EventExpiring, request, now))
+		}
+		if status != services.AccessRequestActiveStatus {
+			delete(t.warned, request.Id)
+		}
+	}
+	t.initialized = true
+
+	// Settled requests that left the listing window cannot change anymore.
+	for id := range t.statuses {
+		if !polled[id] {
+			delete(t.statuses, id)
+			delete(t.warned, id)
+		}
+	}
+
+	return events
+}
+
+func (t *Tracker) expiresWithinWarning(request clientapi.AccessRequestClientModel, now time.Time) bool {
+	expiry := requestExpiry(request)
+	return expiry != nil && expiry.After(now) && expiry.Sub(now) <= t.ExpiryWarning
+}
+
+func transitionEvent(previous string, seen bool, status string) string {
+	switch status {
+	case services.AccessRequestActiveStatus:
+		return EventGranted
+	case services.AccessRequestRejectedStatus:
+		return EventRejected
+	case services.AccessRequestPendingMFAStatus, services.AccessRequestWaitingForMFAStatus:
+		// The API reports both, so moving from one to the other is the same
+		// MFA step.
+		if seen && isMFAStatus(previous) {
+			return ""
+		}
+		return EventMFARequired
+	case services.AccessRequestRevokingStatus, services.AccessRequestRevokedStatus:
+		if seen && previous == services.AccessRequestActiveStatus {
+			return EventExpired
+		}
+	}
+	return ""
+}
+
+func isMFAStatus(status string) bool {
+	return status == services.AccessRequestPendingMFAStatus || status == services.AccessRequestWaitingForMFAStatus
+}
+
+func isSettledStatus(status string) bool {
+	switch status {
+	case services.AccessRequestRejectedStatus, services.AccessRequestRevokedStatus, services.AccessRequestFailedStatus:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,137 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/services"
+)
+
+func testRequest(id, status string, expiresAt *time.Time) clientapi.AccessRequestClientModel {
+	request := clientapi.AccessRequestClientModel{
+		Id:     id,
+		Status: clientapi.RequestStatusClientModel{Status: status},
+	}
+	if expiresAt != nil {
+		request.RevocationTime = *clientapi.NewNullableFloat64(clientapi.PtrFloat64(float64(expiresAt.Unix())))
+	}
+	return request
+}
+
+func eventTypes(events []Event) []string {
+	var types []string
+	for _, event := range events {
+		types = append(types, event.Type+":"+event.RequestID)
+	}
+	return types
+}
+
+func assertEvents(t *testing.T, got []Event, want ...string) {
+	t.Helper()
+	types := eventTypes(got)
+	if len(types) != len(want) {
+		t.Fatalf("events = %v, want %v", types, want)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Fatalf("events = %v, want %v", types, want)
+		}
+	}
+}
+
+func TestTrackerUpdate(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(2 * time.Hour)
+	soon := now.Add(5 * time.Minute)
+	tracker := NewTracker(10 * time.Minute)
+
+	// The first poll is a baseline and does not replay old transitions.
+	assertEvents(t, tracker.Update([]clientapi.AccessRequestClientModel{
+		testRequest("r-1", services.AccessRequestPendingStatus, nil),
+		testRequest("r-2", services.AccessRequestActiveStatus, &later),
+		testRequest("r-3", services.AccessRequestRejectedStatus, nil),
+		testRequest("r-4", services.AccessRequestPendingStatus, nil),
+	}, now))
+
+	assertEvents(t, tracker.Update([]clientapi.AccessRequestClientModel{
+		testRequest("r-1", services.AccessRequestActiveStatus, &later),
+		testRequest("r-2", services.AccessRequestActiveStatus, &soon),
+		testRequest("r-3", services.AccessRequestRejectedStatus, nil),
+		testRequest("r-4", services.AccessRequestPendingMFAStatus, nil),
+		testRequest("r-5", services.AccessRequestRejectedStatus, nil),
+	}, now), "granted:r-1", "expiring:r-2", "mfa_required:r-4", "rejected:r-5")
+
+	// Warnings fire once per request.
+	assertEvents(t, tracker.Update([]clientapi.AccessRequestClientModel{
+		testRequest("r-1", services.AccessRequestActiveStatus, &later),
+		testRequest("r-2", services.AccessRequestActiveStatus, &soon),
+	}, now.Add(time.Minute)))
+
+	assertEvents(t, tracker.Update([]clientapi.AccessRequestClientModel{
+		testRequest("r-1", services.AccessRequestRevokingStatus, &later),
+		testRequest("r-2", services.AccessRequestRevokedStatus, &soon),
+	}, now.Add(6*time.Minute)), "expired:r-1", "expired:r-2")
+
+	// Moving on from Revoking is not another expiry.
+	assertEvents(t, tracker.Update([]clientapi.AccessRequestClientModel{
+		testRequest("r-1", services.AccessRequestRevokedStatus, &later),
+	}, now.Add(7*time.Minute)))
+}
+
+func TestTrackerMFAStatuses(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tracker := NewTracker(10 * time.Minute)
+
+	tracker.Update([]clientapi.AccessRequestClientModel{testRequest("r-1", services.AccessRequestPendingStatus, nil)}, now)
+	assertEvents(t, tracker.Update([]clientapi.AccessRequestClientModel{
+		testRequest("r-1", services.AccessRequestPendingMFAStatus, nil),
+	}, now), "mfa_required:r-1")
+
+	// Both MFA statuses are the same step.
+	assertEvents(t, tracker.Update([]clientapi.AccessRequestClientModel{
+		testRequest("r-1", services.AccessRequestWaitingForMFAStatus, nil),
+	}, now))
+}
+
+func TestTrackerForgetsSettledRequests(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(2 * time.Hour)
+	tracker := NewTracker(10 * time.Minute)
+
+	tracker.Update([]clientapi.AccessRequestClientModel{
+		testRequest("r-1", services.AccessRequestActiveStatus, &later),
+		testRequest("r-2", services.AccessRequestRejectedStatus, nil),
+		testRequest("r-3", services.AccessRequestPendingStatus, nil),
+	}, now)
+	if got := tracker.Tracked(); len(got) != 2 || got[0] != "r-1" || got[1] != "r-3" {
+		t.Fatalf("Tracked() = %v, want the requests that can still change", got)
+	}
+
+	// r-2 left the listing window.
+	assertEvents(t, tracker.Update([]clientapi.AccessRequestClientModel{
+		testRequest("r-1", services.AccessRequestRevokedStatus, &later),
+		testRequest("r-3", services.AccessRequestPendingStatus, nil),
+	}, now), "expired:r-1")
+	if len(tracker.statuses) != 2 {
+		t.Errorf("statuses = %v, want the request outside the window dropped", tracker.statuses)
+	}
+	if got := tracker.Tracked(); len(got) != 1 || got[0] != "r-3" {
+		t.Errorf("Tracked() = %v, want only the pending request", got)
+	}
+}
+
+func TestExpiringEventPayload(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	soon := now.Add(5 * time.Minute)
+	request := testRequest("r-1", services.AccessRequestActiveStatus, &soon)
+	request.AccessGroups = []clientapi.AccessGroupClientModel{{Integration: clientapi.IntegrationClientModel{Name: "prod-db"}}}
+
+	event := newEvent(EventExpiring, request, now)
+	if event.MinutesRemaining == nil || *event.MinutesRemaining != 5 {
+		t.Errorf("MinutesRemaining = %v, want 5", event.MinutesRemaining)
+	}
+	if want := "Access for r-1 (prod-db) expires in 5m"; event.Message != want {
+		t.Errorf("Message = %q, want %q", event.Message, want)
+	}
+}
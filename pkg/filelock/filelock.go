@@ -0,0 +1,30 @@
+// Package filelock takes advisory locks that serialize access to files
+// shared by concurrent apono processes.
+package filelock
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const lockFilePerm os.FileMode = 0o600
+
+// Lock blocks until it holds an exclusive lock on the lock file at path,
+// creating it if needed, and returns the function that releases it.
+func Lock(path string) (func(), error) {
+	lockFile, err := os.OpenFile(filepath.Clean(path), os.O_CREATE|os.O_RDWR, lockFilePerm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lock file: %w", err)
+	}
+
+	if err = lockFileExclusive(lockFile); err != nil {
+		_ = lockFile.Close()
+		return nil, fmt.Errorf("failed to lock %s: %w", filepath.Base(path), err)
+	}
+
+	return func() {
+		_ = unlockFile(lockFile)
+		_ = lockFile.Close()
+	}, nil
+}
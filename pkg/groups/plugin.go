@@ -0,0 +1,8 @@
+package groups
+
+import "github.com/spf13/cobra"
+
+var PluginCommandsGroup = &cobra.Group{
+	ID:    "plugin",
+	Title: "Plugin Commands",
+}
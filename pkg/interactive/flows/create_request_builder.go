@@ -1,8 +1,8 @@
 package flows
 
 import (
+	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
@@ -20,9 +20,17 @@ type CreateAccessRequestWithFullModels struct {
 	Integrations []clientapi.IntegrationClientModel
 	Resources    []clientapi.ResourceClientModel
 	Duration     *time.Duration
+	Grantee      *clientapi.GranteeClientModel
 }
 
-func StartRequestBuilderInteractiveMode(cmd *cobra.Command, client *aponoapi.AponoClient) (*clientapi.CreateAccessRequestClientModel, error) {
+// StartRequestBuilderInteractiveMode builds a request step by step. When
+// grantee is nil the user is asked who the access is for.
+func StartRequestBuilderInteractiveMode(cmd *cobra.Command, client *aponoapi.AponoClient, grantee *clientapi.GranteeClientModel) (*clientapi.CreateAccessRequestClientModel, error) {
+	grantee, err := resolveGrantee(cmd.Context(), client, grantee)
+	if err != nil {
+		return nil, err
+	}
+
 	requestType, err := selectors.RunRequestTypeSelector()
 	if err != nil {
 		return nil, err
@@ -31,12 +39,12 @@ func StartRequestBuilderInteractiveMode(cmd *cobra.Command, client *aponoapi.Apo
 	var request *clientapi.CreateAccessRequestClientModel
 	switch requestType {
 	case selectors.BundleRequestType:
-		request, err = StartBundleRequestBuilderInteractiveMode(cmd, client, "", "", nil)
+		request, err = StartBundleRequestBuilderInteractiveMode(cmd, client, "", "", nil, grantee)
 		if err != nil {
 			return nil, err
 		}
 	case selectors.IntegrationRequestType:
-		request, err = StartIntegrationRequestBuilderInteractiveMode(cmd, client, "", "", []string{}, []string{}, "", nil)
+		request, err = StartIntegrationRequestBuilderInteractiveMode(cmd, client, "", "", []string{}, []string{}, "", nil, grantee)
 		if err != nil {
 			return nil, err
 		}
@@ -47,15 +55,27 @@ func StartRequestBuilderInteractiveMode(cmd *cobra.Command, client *aponoapi.Apo
 	return request, nil
 }
 
+// resolveGrantee asks who the access is for unless grantee is already known.
+// The question is optional, so it never stops the builder on its own.
+func resolveGrantee(ctx context.Context, client *aponoapi.AponoClient, grantee *clientapi.GranteeClientModel) (*clientapi.GranteeClientModel, error) {
+	if grantee != nil {
+		return grantee, nil
+	}
+
+	return selectors.RunGranteeSelector(ctx, client)
+}
+
 func StartBundleRequestBuilderInteractiveMode(
 	cmd *cobra.Command,
 	client *aponoapi.AponoClient,
 	bundleID string,
 	justification string,
 	accessDuration *time.Duration,
+	grantee *clientapi.GranteeClientModel,
 ) (*clientapi.CreateAccessRequestClientModel, error) {
 	request := services.GetEmptyNewRequestAPIModel()
 	requestModels := &CreateAccessRequestWithFullModels{}
+	setRequestGrantee(request, requestModels, grantee)
 
 	if bundleID == "" {
 		bundle, err := selectors.RunBundleSelector(cmd.Context(), client)
@@ -133,9 +153,11 @@ func StartIntegrationRequestBuilderInteractiveMode(
 	permissionIDs []string,
 	justification string,
 	accessDuration *time.Duration,
+	grantee *clientapi.GranteeClientModel,
 ) (*clientapi.CreateAccessRequestClientModel, error) {
 	request := services.GetEmptyNewRequestAPIModel()
 	requestModels := &CreateAccessRequestWithFullModels{}
+	setRequestGrantee(request, requestModels, grantee)
 
 	integration, err := resolveIntegration(cmd, client, integrationID)
 	if err != nil {
@@ -226,7 +248,7 @@ func GenerateAndPrintCreateRequestCommand(cmd *cobra.Command, request *clientapi
 			bundleFlagValue = request.FilterBundleIds[0]
 		}
 
-		return printCreateBundleRequestCommand(cmd, bundleFlagValue, request.Justification.Get(), models.Duration, request.CustomFields)
+		return printCreateBundleRequestCommand(cmd, bundleFlagValue, request.Justification.Get(), models.Duration, request.CustomFields, models.Grantee)
 	}
 
 	var integrationFlagValue string
@@ -246,7 +268,16 @@ func GenerateAndPrintCreateRequestCommand(cmd *cobra.Command, request *clientapi
 			resourcesFlagValues = append(resourcesFlagValues, resourceFilter.Value)
 		}
 	}
-	return printCreateIntegrationRequestCommand(cmd, integrationFlagValue, request.FilterResourceTypeIds[0], resourcesFlagValues, request.FilterPermissionIds, request.Justification.Get(), models.Duration, request.CustomFields)
+	return printCreateIntegrationRequestCommand(cmd, integrationFlagValue, request.FilterResourceTypeIds[0], resourcesFlagValues, request.FilterPermissionIds, request.Justification.Get(), models.Duration, request.CustomFields, models.Grantee)
+}
+
+func setRequestGrantee(request *clientapi.CreateAccessRequestClientModel, models *CreateAccessRequestWithFullModels, grantee *clientapi.GranteeClientModel) {
+	if grantee == nil {
+		return
+	}
+
+	request.GranteeId = *clientapi.NewNullableString(&grantee.Id)
+	models.Grantee = grantee
 }
 
 func resolveIntegration(cmd *cobra.Command, client *aponoapi.AponoClient, integrationID string) (*clientapi.IntegrationClientModel, error) {
@@ -315,34 +346,18 @@ func resolveJustification(userJustification string, isJustificationOptional bool
 	return &result, nil
 }
 
-func printCreateIntegrationRequestCommand(cmd *cobra.Command, integration string, resourceType string, resourceIDs []string, permissionIDs []string, justification *string, duration *time.Duration, customFields map[string]string) error {
-	createCommand := fmt.Sprintf("apono requests create --integration \"%s\" --resource-type \"%s\"", integration, resourceType)
-
-	var permissionFlags []string
-	for _, permissionID := range permissionIDs {
-		permissionFlags = append(permissionFlags, fmt.Sprintf("--permissions \"%s\"", permissionID))
-	}
-	createCommand += " " + strings.Join(permissionFlags, " ")
-
-	var resourceFlags []string
-	for _, resourceID := range resourceIDs {
-		resourceFlags = append(resourceFlags, fmt.Sprintf("--resources \"%s\"", resourceID))
-	}
-	createCommand += " " + strings.Join(resourceFlags, " ")
-
-	if duration != nil {
-		createCommand += fmt.Sprintf(" --duration %s", duration)
-	}
-
-	if justification != nil && *justification != "" {
-		createCommand += fmt.Sprintf(" --justification \"%s\"", *justification)
-	}
+func printCreateIntegrationRequestCommand(cmd *cobra.Command, integration string, resourceType string, resourceIDs []string, permissionIDs []string, justification *string, duration *time.Duration, customFields map[string]string, grantee *clientapi.GranteeClientModel) error {
+	createCommand := services.CreateIntegrationRequestCommand(integration, resourceType, resourceIDs, permissionIDs)
+	return printCreateCommand(cmd, createCommand+services.CreateRequestCommandOptions(justification, duration, customFields, grantee))
+}
 
-	for id, value := range customFields {
-		createCommand += fmt.Sprintf(" --custom-field \"%s=%s\"", id, value)
-	}
+func printCreateBundleRequestCommand(cmd *cobra.Command, bundle string, justification *string, duration *time.Duration, customFields map[string]string, grantee *clientapi.GranteeClientModel) error {
+	createCommand := services.CreateBundleRequestCommand(bundle)
+	return printCreateCommand(cmd, createCommand+services.CreateRequestCommandOptions(justification, duration, customFields, grantee))
+}
 
-	err := printCreateCommand(cmd, createCommand)
+func printCreateCommand(cmd *cobra.Command, commandString string) error {
+	_, err := fmt.Fprintf(cmd.OutOrStdout(), "\n%s Use the following command to request this access again or create an alias for it: %s\n", styles.NoticeMsgPrefix, color.Green.Sprint(commandString))
 	if err != nil {
 		return err
 	}
@@ -350,32 +365,22 @@ func printCreateIntegrationRequestCommand(cmd *cobra.Command, integration string
 	return nil
 }
 
-func printCreateBundleRequestCommand(cmd *cobra.Command, bundle string, justification *string, duration *time.Duration, customFields map[string]string) error {
-	createCommand := fmt.Sprintf("apono requests create --bundle \"%s\"", bundle)
-	if duration != nil {
-		createCommand += fmt.Sprintf(" --duration %s", duration)
-	}
-	if justification != nil && *justification != "" {
-		createCommand += fmt.Sprintf(" --justification \"%s\"", *justification)
+// StartInventorySearchHitRequestBuilderInteractiveMode opens the request
+// builder pre-filled with what the search hit points to.
+func StartInventorySearchHitRequestBuilderInteractiveMode(cmd *cobra.Command, client *aponoapi.AponoClient, hit *services.InventorySearchHit) (*clientapi.CreateAccessRequestClientModel, error) {
+	if hit.Kind == services.InventoryBundleKind {
+		return StartBundleRequestBuilderInteractiveMode(cmd, client, hit.BundleID, "", nil, nil)
 	}
 
-	for id, value := range customFields {
-		createCommand += fmt.Sprintf(" --custom-field \"%s=%s\"", id, value)
+	resourceIDs := []string{}
+	if hit.ResourceID != "" {
+		resourceIDs = []string{hit.ResourceID}
 	}
 
-	err := printCreateCommand(cmd, createCommand)
-	if err != nil {
-		return err
+	permissionIDs := []string{}
+	if hit.PermissionIDs != nil {
+		permissionIDs = hit.PermissionIDs
 	}
 
-	return nil
-}
-
-func printCreateCommand(cmd *cobra.Command, commandString string) error {
-	_, err := fmt.Fprintf(cmd.OutOrStdout(), "\n%s Use the following command to request this access again or create an alias for it: %s\n", styles.NoticeMsgPrefix, color.Green.Sprint(commandString))
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return StartIntegrationRequestBuilderInteractiveMode(cmd, client, hit.IntegrationID, hit.ResourceTypeID, resourceIDs, permissionIDs, "", nil, nil)
 }
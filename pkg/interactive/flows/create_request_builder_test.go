@@ -0,0 +1,59 @@
+package flows
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+)
+
+func TestResolveGranteeWhenGranteesFail(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "forbidden", status: http.StatusForbidden},
+		{name: "not found", status: http.StatusNotFound},
+		{name: "server error", status: http.StatusInternalServerError, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/client/v1/inventory/grantees" {
+					http.NotFound(w, r)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(`{"message":"failed"}`))
+			}))
+			t.Cleanup(server.Close)
+
+			client, err := aponoapi.CreateClientWithPersonalToken(server.URL, "test-token")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			grantee, err := resolveGrantee(context.Background(), client, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveGrantee() error = %v, want error %v", err, tt.wantErr)
+			}
+			if grantee != nil {
+				t.Errorf("resolveGrantee() = %+v, want nil", grantee)
+			}
+		})
+	}
+}
+
+func TestResolveGranteeKeepsGivenGrantee(t *testing.T) {
+	given := &clientapi.GranteeClientModel{Id: "grantee-id"}
+
+	grantee, err := resolveGrantee(context.Background(), nil, given)
+	if err != nil || grantee != given {
+		t.Errorf("resolveGrantee() = %+v, %v, want the given grantee", grantee, err)
+	}
+}
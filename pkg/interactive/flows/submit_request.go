@@ -0,0 +1,71 @@
+package flows
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+	requestloader "github.com/apono-io/apono-cli/pkg/interactive/inputs/request_loader"
+	"github.com/apono-io/apono-cli/pkg/services"
+	"github.com/apono-io/apono-cli/pkg/utils"
+
+	"github.com/gookit/color"
+	"github.com/spf13/cobra"
+)
+
+const (
+	requestWaitTime = 90 * time.Second
+)
+
+// RunSubmitRequestInteractiveFlow creates the request, waits for it to be
+// granted and then continues to the use session flow.
+func RunSubmitRequestInteractiveFlow(cmd *cobra.Command, client *aponoapi.AponoClient, req *clientapi.CreateAccessRequestClientModel) error {
+	createResp, resp, err := client.ClientAPI.AccessRequestsAPI.CreateUserAccessRequest(cmd.Context()).
+		CreateAccessRequestClientModel(*req).
+		Execute()
+	if err != nil {
+		apiError := utils.ReturnAPIResponseError(resp)
+		if apiError != nil {
+			return apiError
+		}
+
+		return err
+	}
+
+	if len(createResp.RequestIds) == 0 {
+		return fmt.Errorf("failed to create access request, no request IDs returned from the API")
+	}
+
+	requestID := createResp.RequestIds[0]
+	newAccessRequest, err := requestloader.RunRequestLoader(cmd.Context(), client, requestID, requestWaitTime, false)
+	if err != nil {
+		return err
+	}
+
+	if newAccessRequest.Status.Status != services.AccessRequestActiveStatus {
+		fmt.Println()
+
+		err = services.PrintAccessRequests(cmd, []clientapi.AccessRequestClientModel{*newAccessRequest}, utils.Format{Kind: utils.TableFormat}, false)
+		if err != nil {
+			return err
+		}
+
+		if services.IsRequestWaitingForMFA(newAccessRequest) {
+			err = services.PrintAccessRequestMFALink(cmd, &newAccessRequest.Id)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	accessGrantedMsg := fmt.Sprintf("\nAccess request %s granted\n", color.Green.Sprintf("%s", newAccessRequest.Id))
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), accessGrantedMsg)
+	if err != nil {
+		return err
+	}
+
+	return RunUseSessionInteractiveFlow(cmd, client, newAccessRequest.Id)
+}
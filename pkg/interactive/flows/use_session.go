@@ -2,7 +2,6 @@ package flows
 
 import (
 	"fmt"
-	"runtime"
 
 	"github.com/apono-io/apono-cli/pkg/analytics"
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
@@ -23,11 +22,15 @@ const (
 )
 
 func RunUseSessionInteractiveFlow(cmd *cobra.Command, client *aponoapi.AponoClient, requestIDFilter string) error {
-	session, err := selectors.RunSessionsSelector(cmd.Context(), client, requestIDFilter)
+	session, customAccessDetails, err := selectSession(cmd, client, requestIDFilter)
 	if err != nil {
 		return err
 	}
 
+	if err = services.PrintCustomAccessDetails(cmd, customAccessDetails); err != nil {
+		return err
+	}
+
 	if len(session.ConnectionMethods) == 0 {
 		return fmt.Errorf("no connection methods found for session %s", session.Id)
 	}
@@ -49,9 +52,9 @@ func RunUseSessionInteractiveFlow(cmd *cobra.Command, client *aponoapi.AponoClie
 
 	connectWithAppAvailable := false
 	var guiTuiInstalled []clientapi.LauncherClientModel
-	if runtime.GOOS == utils.DarwinOS {
+	if utils.SupportsClientLaunchers() {
 		for _, c := range result.Clients {
-			if (c.LauncherType == connect.ClientKindGUI || c.LauncherType == connect.ClientKindTUI) && connect.IsInstalled(c) {
+			if result.IsLocal(c.Id) || ((c.LauncherType == connect.ClientKindGUI || c.LauncherType == connect.ClientKindTUI) && connect.IsInstalled(c)) {
 				guiTuiInstalled = append(guiTuiInstalled, c)
 			}
 		}
@@ -70,13 +73,13 @@ func RunUseSessionInteractiveFlow(cmd *cobra.Command, client *aponoapi.AponoClie
 			return err
 		}
 
-		return services.ExecuteCliCommand(cmd, session, details.GetCli())
+		return executeSessionCliCommand(cmd, session, details.GetCli())
 
 	case selectors.PrintOption:
 		return printSessionInstructions(cmd, client, session, details)
 
 	case selectors.ExecuteWithAppOption:
-		selectedID, err := selectors.RunLauncherClientSelector(guiTuiInstalled)
+		selectedID, err := selectors.RunLauncherClientSelector(guiTuiInstalled, result.LocalClientIDs)
 		if err != nil {
 			return err
 		}
@@ -88,7 +91,12 @@ func RunUseSessionInteractiveFlow(cmd *cobra.Command, client *aponoapi.AponoClie
 		if err != nil {
 			return err
 		}
-		err = connect.NewClientStarter().Start(cmd, client, session.Id, selectedID, result)
+		starter := connect.NewClientStarter()
+		starter.Multiplexer, err = connect.ResolveMultiplexerLaunch(cmd, "", session.Id, session.Name)
+		if err != nil {
+			return err
+		}
+		err = starter.Start(cmd, client, session.Id, selectedID, result)
 		if err != nil {
 			return err
 		}
@@ -100,6 +108,60 @@ func RunUseSessionInteractiveFlow(cmd *cobra.Command, client *aponoapi.AponoClie
 	}
 }
 
+// selectSession asks for the integration group and then for one of its
+// sessions, or only for the session when the request is given. It returns the
+// custom access details of the group.
+func selectSession(cmd *cobra.Command, client *aponoapi.AponoClient, requestIDFilter string) (*clientapi.AccessSessionClientModel, string, error) {
+	if requestIDFilter != "" {
+		session, err := selectors.RunSessionsSelector(cmd.Context(), client, nil, requestIDFilter)
+		return session, "", err
+	}
+
+	group, err := selectors.RunSessionsGroupSelector(cmd.Context(), client)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// The "Other" group has no integration to filter by, so its own sessions
+	// are offered instead of every session, and listed again when the group
+	// only holds the first of them.
+	var session *clientapi.AccessSessionClientModel
+	if integration, ok := group.GetIntegrationOk(); ok && integration != nil {
+		session, err = selectors.RunSessionsSelector(cmd.Context(), client, []string{integration.Id}, "")
+	} else {
+		sessions := group.GetSessions()
+		if int(group.GetTotal()) > len(sessions) {
+			sessions, err = services.ListOtherAccessSessions(cmd.Context(), client)
+			if err != nil {
+				return nil, "", err
+			}
+		}
+		session, err = selectors.RunSessionSelector(sessions)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	return session, group.GetCustomAccessDetails(), nil
+}
+
+// executeSessionCliCommand runs the session CLI command in the current pane,
+// or in a new tmux or screen window when multiplexer.mode is configured.
+func executeSessionCliCommand(cmd *cobra.Command, session *clientapi.AccessSessionClientModel, command string) error {
+	muxLaunch, err := connect.ResolveMultiplexerLaunch(cmd, "", session.Id, session.Name)
+	if err != nil {
+		return err
+	}
+	if muxLaunch != nil {
+		command, err = muxLaunch.BuildCommand(command)
+		if err != nil {
+			return err
+		}
+	}
+
+	return services.ExecuteCliCommand(cmd, session, command)
+}
+
 func printSessionInstructions(cmd *cobra.Command, client *aponoapi.AponoClient, session *clientapi.AccessSessionClientModel, details *clientapi.AccessSessionDetailsClientModel) error {
 	var accessDetails string
 	var customInstructionMessage services.CustomInstructionMessage
@@ -7,6 +7,7 @@ import (
 
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
 	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/sdk"
 	"github.com/apono-io/apono-cli/pkg/services"
 
 	"github.com/charmbracelet/bubbles/spinner"
@@ -30,7 +31,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case updatedRequestMsg:
 		m.request = (*clientapi.AccessRequestClientModel)(&msg)
-		if m.noWaitForGrant || ShouldStopLoading(m.request) {
+		if m.noWaitForGrant || sdk.IsRequestSettled(m.request) {
 			m.quitting = true
 			return m, tea.Quit
 		}
@@ -5,8 +5,6 @@ import (
 	"time"
 
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
-	"github.com/apono-io/apono-cli/pkg/clientapi"
-	"github.com/apono-io/apono-cli/pkg/services"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -24,19 +22,3 @@ func getRequestByID(ctx context.Context, client *aponoapi.AponoClient, requestID
 func shouldRetryLoading(lastRequestTime time.Time, interval time.Duration) bool {
 	return time.Now().After(lastRequestTime.Add(interval))
 }
-
-func ShouldStopLoading(request *clientapi.AccessRequestClientModel) bool {
-	switch request.Status.Status {
-	case services.AccessRequestActiveStatus, services.AccessRequestFailedStatus, services.AccessRequestRejectedStatus:
-		return true
-
-	case services.AccessRequestPendingStatus:
-		if services.IsRequestWaitingForHumanApproval(request) {
-			return true
-		}
-	case services.AccessRequestPendingMFAStatus:
-		return true
-	}
-
-	return false
-}
@@ -0,0 +1,103 @@
+package selectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	listselect "github.com/apono-io/apono-cli/pkg/interactive/inputs/list_select"
+	textinput "github.com/apono-io/apono-cli/pkg/interactive/inputs/text_input"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/services"
+)
+
+const (
+	myselfGranteeOptionID = "myself"
+	granteeSelectorLimit  = 100
+)
+
+// RunGranteeSelector asks who the access is requested for. It returns nil when
+// the request is for the caller, and skips the question when the caller
+// cannot request access for anyone else, or may not list the grantees. When
+// there are more grantees than fit in the list, it asks for a name or email
+// and lists the matching grantees instead.
+func RunGranteeSelector(ctx context.Context, client *aponoapi.AponoClient) (*clientapi.GranteeClientModel, error) {
+	grantees, hasMore, err := services.ListGranteesPage(ctx, client, "", granteeSelectorLimit)
+	if err != nil {
+		if errors.Is(err, services.ErrGranteesNotAvailable) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(grantees) <= 1 && !hasMore {
+		return nil, nil
+	}
+
+	title := "Request access for"
+	if hasMore {
+		searchInput := textinput.TextInput{
+			Title:       "Search who to request access for",
+			PostTitle:   "Grantee search",
+			Placeholder: "Name or email, leave empty for yourself",
+			Optional:    true,
+		}
+		search, err := textinput.LaunchTextInput(searchInput)
+		if err != nil {
+			return nil, err
+		}
+		if search == "" {
+			return nil, nil
+		}
+
+		grantees, hasMore, err = services.ListGranteesPage(ctx, client, search, granteeSelectorLimit)
+		if err != nil {
+			return nil, err
+		}
+		if hasMore {
+			title = fmt.Sprintf("Request access for (first %d matches)", granteeSelectorLimit)
+		}
+	}
+
+	granteeByID := make(map[string]clientapi.GranteeClientModel)
+	var options []listselect.SelectOption
+	for _, grantee := range grantees {
+		options = append(options, listselect.SelectOption{
+			ID:    grantee.Id,
+			Label: services.GranteeLabel(&grantee),
+		})
+		granteeByID[grantee.Id] = grantee
+	}
+
+	sort.Slice(options, func(i, j int) bool {
+		return options[i].Label < options[j].Label
+	})
+	options = append([]listselect.SelectOption{{ID: myselfGranteeOptionID, Label: "Myself"}}, options...)
+
+	granteeInput := listselect.SelectInput{
+		Title:         title,
+		PostTitle:     "Requesting access for",
+		Options:       options,
+		ShowHelp:      true,
+		EnableFilter:  true,
+		ShowItemCount: true,
+	}
+
+	selectedItems, err := listselect.LaunchSelector(granteeInput)
+	if err != nil {
+		return nil, err
+	}
+
+	if selectedItems[0].ID == myselfGranteeOptionID {
+		return nil, nil
+	}
+
+	selectedGrantee, ok := granteeByID[selectedItems[0].ID]
+	if !ok {
+		return nil, fmt.Errorf("grantee not found")
+	}
+
+	return &selectedGrantee, nil
+}
@@ -0,0 +1,45 @@
+package selectors
+
+import (
+	"fmt"
+	"strconv"
+
+	listselect "github.com/apono-io/apono-cli/pkg/interactive/inputs/list_select"
+	"github.com/apono-io/apono-cli/pkg/services"
+)
+
+func RunInventorySearchHitSelector(hits []services.InventorySearchHit) (*services.InventorySearchHit, error) {
+	if len(hits) == 0 {
+		return nil, fmt.Errorf("no results found")
+	}
+
+	var options []listselect.SelectOption
+	for i, hit := range hits {
+		options = append(options, listselect.SelectOption{
+			ID:    strconv.Itoa(i),
+			Label: fmt.Sprintf("%s %s (%s)", hit.Kind, hit.Name, hit.Location),
+		})
+	}
+
+	hitsInput := listselect.SelectInput{
+		Title:                "Select what to request",
+		PostTitle:            "Selected",
+		Options:              options,
+		ShowHelp:             true,
+		EnableFilter:         true,
+		ShowItemCount:        true,
+		AutoSelectSingleItem: true,
+	}
+
+	selectedItems, err := listselect.LaunchSelector(hitsInput)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := strconv.Atoi(selectedItems[0].ID)
+	if err != nil || index >= len(hits) {
+		return nil, fmt.Errorf("result not found")
+	}
+
+	return &hits[index], nil
+}
@@ -3,24 +3,32 @@ package selectors
 import (
 	"fmt"
 
+	"github.com/gookit/color"
+
 	"github.com/apono-io/apono-cli/pkg/clientapi"
 	listselect "github.com/apono-io/apono-cli/pkg/interactive/inputs/list_select"
+	"github.com/apono-io/apono-cli/pkg/utils"
 )
 
 // RunLauncherClientSelector shows an interactive picker over the provided
-// launcher clients and returns the selected client's id. Errors if the
+// launcher clients and returns the selected client's id. Clients listed in
+// localIDs come from the CLI config and are labeled as such. Errors if the
 // list is empty or no TTY is available (the latter surfaces from the
 // underlying listselect.LaunchSelector).
-func RunLauncherClientSelector(clients []clientapi.LauncherClientModel) (string, error) {
+func RunLauncherClientSelector(clients []clientapi.LauncherClientModel, localIDs []string) (string, error) {
 	if len(clients) == 0 {
 		return "", fmt.Errorf("no launcher clients available for this session")
 	}
 
 	options := make([]listselect.SelectOption, 0, len(clients))
 	for _, c := range clients {
+		label := c.DisplayName
+		if utils.Contains(localIDs, c.Id) {
+			label = fmt.Sprintf("%s %s", label, color.Gray.Sprint("(local)"))
+		}
 		options = append(options, listselect.SelectOption{
 			ID:    c.Id,
-			Label: c.DisplayName,
+			Label: label,
 		})
 	}
 
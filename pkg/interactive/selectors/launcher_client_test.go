@@ -8,7 +8,7 @@ import (
 )
 
 func TestRunLauncherClientSelector_emptyInput_returnsError(t *testing.T) {
-	_, err := RunLauncherClientSelector(nil)
+	_, err := RunLauncherClientSelector(nil, nil)
 	if err == nil {
 		t.Fatal("expected error for nil input, got nil")
 	}
@@ -16,7 +16,7 @@ func TestRunLauncherClientSelector_emptyInput_returnsError(t *testing.T) {
 		t.Errorf("expected error to mention no launcher clients, got %q", err.Error())
 	}
 
-	_, err = RunLauncherClientSelector([]clientapi.LauncherClientModel{})
+	_, err = RunLauncherClientSelector([]clientapi.LauncherClientModel{}, nil)
 	if err == nil {
 		t.Fatal("expected error for empty slice, got nil")
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
 	"github.com/apono-io/apono-cli/pkg/clientapi"
@@ -11,16 +12,67 @@ import (
 	"github.com/apono-io/apono-cli/pkg/services"
 )
 
-func RunSessionsSelector(ctx context.Context, client *aponoapi.AponoClient, requestID string) (*clientapi.AccessSessionClientModel, error) {
+func RunSessionsGroupSelector(ctx context.Context, client *aponoapi.AponoClient) (*clientapi.AccessSessionsGroupClientModel, error) {
+	groups, err := services.ListAccessSessionsGroups(ctx, client, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("no active access found, create a new request by running this command: apono request create")
+	}
+
+	var options []listselect.SelectOption
+	for i, group := range groups {
+		options = append(options, listselect.SelectOption{
+			ID:    strconv.Itoa(i),
+			Label: fmt.Sprintf("%s (%d)", services.AccessSessionsGroupName(group), group.Total),
+		})
+	}
+
+	sort.Slice(options, func(i, j int) bool {
+		return options[i].Label < options[j].Label
+	})
+
+	groupsInput := listselect.SelectInput{
+		Title:                "Select integration",
+		PostTitle:            "Selected integration",
+		Options:              options,
+		ShowHelp:             true,
+		EnableFilter:         true,
+		ShowItemCount:        true,
+		AutoSelectSingleItem: true,
+	}
+
+	selectedItems, err := listselect.LaunchSelector(groupsInput)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := strconv.Atoi(selectedItems[0].ID)
+	if err != nil || index >= len(groups) {
+		return nil, fmt.Errorf("integration not found")
+	}
+
+	return &groups[index], nil
+}
+
+func RunSessionsSelector(ctx context.Context, client *aponoapi.AponoClient, integrationIDs []string, requestID string) (*clientapi.AccessSessionClientModel, error) {
 	var requestIdsFilter []string
 	if requestID != "" {
 		requestIdsFilter = []string{requestID}
 	}
 
-	sessions, err := services.ListAccessSessions(ctx, client, nil, nil, requestIdsFilter)
+	sessions, err := services.ListAccessSessions(ctx, client, integrationIDs, nil, requestIdsFilter)
 	if err != nil {
 		return nil, err
 	}
+
+	return RunSessionSelector(sessions)
+}
+
+// RunSessionSelector asks for one of the given sessions, such as the sessions
+// of a group without an integration, which cannot be listed by a filter.
+func RunSessionSelector(sessions []clientapi.AccessSessionClientModel) (*clientapi.AccessSessionClientModel, error) {
 	if len(sessions) == 0 {
 		return nil, fmt.Errorf("no active access found, create a new request by running this command: apono request create")
 	}
@@ -0,0 +1,170 @@
+package mockserver
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFixtures is the commented fixtures file the server uses when none is
+// given.
+//
+//go:embed fixtures.yaml
+var DefaultFixtures []byte
+
+// Fixtures is the tenant the mock server simulates.
+type Fixtures struct {
+	Token                string        `yaml:"token"`
+	User                 User          `yaml:"user"`
+	Account              Account       `yaml:"account"`
+	Lifecycle            Lifecycle     `yaml:"lifecycle"`
+	RequireJustification bool          `yaml:"require_justification"`
+	Integrations         []Integration `yaml:"integrations"`
+	Bundles              []Bundle      `yaml:"bundles"`
+	AccessDetails        AccessDetails `yaml:"access_details"`
+}
+
+type User struct {
+	ID    string `yaml:"id"`
+	Name  string `yaml:"name"`
+	Email string `yaml:"email"`
+}
+
+type Account struct {
+	ID   string `yaml:"id"`
+	Name string `yaml:"name"`
+}
+
+type Lifecycle struct {
+	Pending         time.Duration `yaml:"pending"`
+	Granting        time.Duration `yaml:"granting"`
+	Revoking        time.Duration `yaml:"revoking"`
+	DefaultDuration time.Duration `yaml:"default_duration"`
+}
+
+type Integration struct {
+	ID              string         `yaml:"id"`
+	Name            string         `yaml:"name"`
+	Type            string         `yaml:"type"`
+	TypeDisplayName string         `yaml:"type_display_name"`
+	ResourceTypes   []ResourceType `yaml:"resource_types"`
+}
+
+type ResourceType struct {
+	ID          string     `yaml:"id"`
+	Name        string     `yaml:"name"`
+	Permissions []string   `yaml:"permissions"`
+	Resources   []Resource `yaml:"resources"`
+}
+
+type Resource struct {
+	ID   string `yaml:"id"`
+	Name string `yaml:"name"`
+	Path string `yaml:"path"`
+}
+
+// Bundle grants access to all resources of its integration.
+type Bundle struct {
+	ID          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	Integration string `yaml:"integration"`
+}
+
+type AccessDetails struct {
+	CLI          string                 `yaml:"cli"`
+	Instructions string                 `yaml:"instructions"`
+	Link         string                 `yaml:"link"`
+	JSON         map[string]interface{} `yaml:"json"`
+}
+
+// LoadFixtures reads a fixtures file over the defaults. An empty path returns
+// the defaults.
+func LoadFixtures(path string) (*Fixtures, error) {
+	if path == "" {
+		return ParseFixtures(nil)
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixtures: %w", err)
+	}
+
+	fixtures, err := ParseFixtures(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return fixtures, nil
+}
+
+func ParseFixtures(data []byte) (*Fixtures, error) {
+	fixtures := new(Fixtures)
+	if err := yaml.Unmarshal(DefaultFixtures, fixtures); err != nil {
+		return nil, fmt.Errorf("invalid default fixtures: %w", err)
+	}
+	if err := yaml.Unmarshal(data, fixtures); err != nil {
+		return nil, fmt.Errorf("invalid fixtures: %w", err)
+	}
+
+	if err := fixtures.validate(); err != nil {
+		return nil, err
+	}
+	return fixtures, nil
+}
+
+func (f *Fixtures) validate() error {
+	integrations := make(map[string]bool)
+	for _, integration := range f.Integrations {
+		if integration.ID == "" {
+			return fmt.Errorf("integration %q has no id", integration.Name)
+		}
+		if integrations[integration.ID] {
+			return fmt.Errorf("duplicate integration id %q", integration.ID)
+		}
+		integrations[integration.ID] = true
+	}
+
+	for _, bundle := range f.Bundles {
+		if bundle.ID == "" {
+			return fmt.Errorf("bundle %q has no id", bundle.Name)
+		}
+		if !integrations[bundle.Integration] {
+			return fmt.Errorf("bundle %q refers to unknown integration %q", bundle.ID, bundle.Integration)
+		}
+	}
+
+	if f.Lifecycle.DefaultDuration <= 0 {
+		return fmt.Errorf("lifecycle.default_duration must be positive")
+	}
+	return nil
+}
+
+func (f *Fixtures) integration(id string) *Integration {
+	for i := range f.Integrations {
+		if f.Integrations[i].ID == id {
+			return &f.Integrations[i]
+		}
+	}
+	return nil
+}
+
+func (f *Fixtures) bundle(id string) *Bundle {
+	for i := range f.Bundles {
+		if f.Bundles[i].ID == id {
+			return &f.Bundles[i]
+		}
+	}
+	return nil
+}
+
+func (i *Integration) resourceType(id string) *ResourceType {
+	for j := range i.ResourceTypes {
+		if i.ResourceTypes[j].ID == id {
+			return &i.ResourceTypes[j]
+		}
+	}
+	return nil
+}
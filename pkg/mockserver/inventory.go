@@ -0,0 +1,163 @@
+package mockserver
+
+import (
+	"net/http"
+
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+)
+
+func integrationModel(integration *Integration) clientapi.IntegrationClientModel {
+	return clientapi.IntegrationClientModel{
+		Id:              integration.ID,
+		Type:            integration.Type,
+		TypeDisplayName: integration.TypeDisplayName,
+		Name:            integration.Name,
+	}
+}
+
+func resourceTypeModel(resourceType *ResourceType) clientapi.ResourceTypeClientModel {
+	return clientapi.ResourceTypeClientModel{
+		Id:                       resourceType.ID,
+		Name:                     resourceType.Name,
+		DisplayPath:              resourceType.Name,
+		AllowMultiplePermissions: true,
+	}
+}
+
+// inventoryIntegration returns the integration of the integration-id
+// parameter, writing a 404 when it is unknown.
+func (s *Server) inventoryIntegration(w http.ResponseWriter, r *http.Request) *Integration {
+	id := r.URL.Query().Get("integration-id")
+	integration := s.fixtures.integration(id)
+	if integration == nil {
+		writeError(w, http.StatusNotFound, "integration "+id+" not found")
+	}
+	return integration
+}
+
+func (s *Server) getUserSession(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+	writeJSON(w, http.StatusOK, clientapi.UserSessionClientModel{
+		User: clientapi.UserClientModel{
+			Id:    s.fixtures.User.ID,
+			Name:  s.fixtures.User.Name,
+			Email: s.fixtures.User.Email,
+		},
+		Account: clientapi.AccountClientModel{
+			Id:   s.fixtures.Account.ID,
+			Name: s.fixtures.Account.Name,
+			Plan: "mock",
+		},
+		Settings: clientapi.UserSettingsClientModel{
+			RequestCustomFields: []clientapi.RequestCustomFieldModel{},
+		},
+	})
+}
+
+func (s *Server) listIntegrations(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	integrations := make([]clientapi.IntegrationClientModel, 0, len(s.fixtures.Integrations))
+	for i := range s.fixtures.Integrations {
+		integration := &s.fixtures.Integrations[i]
+		if matchesSearch(r, integration.Name, integration.Type) {
+			integrations = append(integrations, integrationModel(integration))
+		}
+	}
+
+	writeJSON(w, http.StatusOK, page(r, integrations))
+}
+
+func (s *Server) listBundles(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	bundles := make([]clientapi.BundleClientModel, 0, len(s.fixtures.Bundles))
+	for _, bundle := range s.fixtures.Bundles {
+		if matchesSearch(r, bundle.Name) {
+			bundles = append(bundles, clientapi.BundleClientModel{Id: bundle.ID, Name: bundle.Name, Labels: map[string]string{}})
+		}
+	}
+
+	writeJSON(w, http.StatusOK, page(r, bundles))
+}
+
+func (s *Server) listResourceTypes(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	integration := s.inventoryIntegration(w, r)
+	if integration == nil {
+		return
+	}
+
+	resourceTypes := make([]clientapi.ResourceTypeClientModel, 0, len(integration.ResourceTypes))
+	for i := range integration.ResourceTypes {
+		resourceType := &integration.ResourceTypes[i]
+		if matchesSearch(r, resourceType.ID, resourceType.Name) {
+			resourceTypes = append(resourceTypes, resourceTypeModel(resourceType))
+		}
+	}
+
+	writeJSON(w, http.StatusOK, page(r, resourceTypes))
+}
+
+func (s *Server) listResources(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	integration := s.inventoryIntegration(w, r)
+	if integration == nil {
+		return
+	}
+
+	resourceTypeIDs := queryValues(r, "resource-type-id")
+	sourceIDs := queryValues(r, "source-id")
+
+	resources := make([]clientapi.ResourceClientModel, 0)
+	for i := range integration.ResourceTypes {
+		resourceType := &integration.ResourceTypes[i]
+		if !matchesFilter(resourceTypeIDs, resourceType.ID) {
+			continue
+		}
+
+		for _, resource := range resourceType.Resources {
+			if matchesFilter(sourceIDs, resource.ID) && matchesSearch(r, resource.Name, resource.Path) {
+				resources = append(resources, clientapi.ResourceClientModel{
+					Id:          resource.ID,
+					SourceId:    resource.ID,
+					Name:        resource.Name,
+					Path:        resource.Path,
+					Type:        resourceTypeModel(resourceType),
+					Integration: integrationModel(integration),
+				})
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, page(r, resources))
+}
+
+func (s *Server) listPermissions(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	integration := s.inventoryIntegration(w, r)
+	if integration == nil {
+		return
+	}
+
+	resourceType := integration.resourceType(r.URL.Query().Get("resource-type-id"))
+	if resourceType == nil {
+		writeError(w, http.StatusNotFound, "resource type "+r.URL.Query().Get("resource-type-id")+" not found")
+		return
+	}
+
+	permissions := make([]clientapi.PermissionClientModel, 0, len(resourceType.Permissions))
+	for _, permission := range resourceType.Permissions {
+		if matchesSearch(r, permission) {
+			permissions = append(permissions, clientapi.PermissionClientModel{Id: permission, Name: permission})
+		}
+	}
+
+	writeJSON(w, http.StatusOK, page(r, permissions))
+}
+
+func (s *Server) listGrantees(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	grantees := []clientapi.GranteeClientModel{{
+		Id:          s.fixtures.User.ID,
+		SourceId:    s.fixtures.User.Email,
+		DisplayName: s.fixtures.User.Name,
+		Type:        "user",
+	}}
+	if !matchesSearch(r, s.fixtures.User.Name, s.fixtures.User.Email) {
+		grantees = grantees[:0]
+	}
+
+	writeJSON(w, http.StatusOK, page(r, grantees))
+}
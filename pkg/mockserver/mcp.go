@@ -0,0 +1,166 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+)
+
+const mcpProtocolVersion = "2024-11-05"
+
+type mcpRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type mcpResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *mcpError       `json:"error,omitempty"`
+}
+
+type mcpError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type mcpTool struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	InputSchema map[string]interface{} `json:"inputSchema"`
+}
+
+var mcpTools = []mcpTool{
+	{
+		Name:        "list_integrations",
+		Description: "List the integrations you can request access to",
+		InputSchema: map[string]interface{}{"type": "object", "properties": map[string]interface{}{}},
+	},
+	{
+		Name:        "list_bundles",
+		Description: "List the bundles you can request access to",
+		InputSchema: map[string]interface{}{"type": "object", "properties": map[string]interface{}{}},
+	},
+	{
+		Name:        "list_access_requests",
+		Description: "List your access requests and their status",
+		InputSchema: map[string]interface{}{"type": "object", "properties": map[string]interface{}{}},
+	},
+	{
+		Name:        "request_bundle_access",
+		Description: "Request access to a bundle",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"bundle_id":     map[string]interface{}{"type": "string"},
+				"justification": map[string]interface{}{"type": "string"},
+			},
+			"required": []string{"bundle_id"},
+		},
+	},
+}
+
+// handleMCP answers the JSON-RPC messages `apono mcp` forwards, with a few
+// tools over the fixtures.
+func (s *Server) handleMCP(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	request := new(mcpRequest)
+	if err = json.Unmarshal(body, request); err != nil {
+		writeJSON(w, http.StatusOK, mcpResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &mcpError{Code: -32700, Message: "parse error"}})
+		return
+	}
+
+	// Notifications have no response.
+	if len(request.ID) == 0 {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	response := mcpResponse{JSONRPC: "2.0", ID: request.ID}
+	switch request.Method {
+	case "initialize":
+		response.Result = map[string]interface{}{
+			"protocolVersion": mcpProtocolVersion,
+			"capabilities":    map[string]interface{}{"tools": map[string]interface{}{}},
+			"serverInfo":      map[string]interface{}{"name": "apono-mock", "version": "0.0.0"},
+		}
+	case "ping":
+		response.Result = map[string]interface{}{}
+	case "tools/list":
+		response.Result = map[string]interface{}{"tools": mcpTools}
+	case "tools/call":
+		text, isError := s.callMCPTool(request.Params)
+		response.Result = map[string]interface{}{
+			"content": []interface{}{map[string]interface{}{"type": "text", "text": text}},
+			"isError": isError,
+		}
+	default:
+		response.Error = &mcpError{Code: -32601, Message: fmt.Sprintf("method %s not found", request.Method)}
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) callMCPTool(rawParams json.RawMessage) (string, bool) {
+	var params struct {
+		Name      string            `json:"name"`
+		Arguments map[string]string `json:"arguments"`
+	}
+	if err := json.Unmarshal(rawParams, &params); err != nil {
+		return fmt.Sprintf("invalid params: %v", err), true
+	}
+
+	var lines []string
+	switch params.Name {
+	case "list_integrations":
+		for _, integration := range s.fixtures.Integrations {
+			lines = append(lines, fmt.Sprintf("%s (%s/%s)", integration.ID, integration.Type, integration.Name))
+		}
+	case "list_bundles":
+		for _, bundle := range s.fixtures.Bundles {
+			lines = append(lines, fmt.Sprintf("%s (%s)", bundle.ID, bundle.Name))
+		}
+	case "list_access_requests":
+		s.mu.Lock()
+		for i := len(s.requests) - 1; i >= 0; i-- {
+			status, _ := s.status(s.requests[i])
+			lines = append(lines, fmt.Sprintf("%s: %s", s.requests[i].id, status))
+		}
+		s.mu.Unlock()
+	case "request_bundle_access":
+		body := &clientapi.CreateAccessRequestClientModel{FilterBundleIds: []string{params.Arguments["bundle_id"]}}
+		if justification, ok := params.Arguments["justification"]; ok {
+			body.Justification = *clientapi.NewNullableString(&justification)
+		}
+
+		request, err := s.newRequest(body)
+		if err != nil {
+			return err.Error(), true
+		}
+		if s.fixtures.RequireJustification && request.justification == nil {
+			return "justification is required", true
+		}
+
+		s.addRequest(request)
+		lines = append(lines, fmt.Sprintf("Created access request %s", request.id))
+	default:
+		return fmt.Sprintf("tool %s not found", params.Name), true
+	}
+
+	if len(lines) == 0 {
+		return "No results", false
+	}
+	return strings.Join(lines, "\n"), false
+}
@@ -0,0 +1,329 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+)
+
+const (
+	statusPending  = "Pending"
+	statusGranting = "Granting"
+	statusActive   = "Active"
+	statusRevoking = "Revoking"
+	statusRevoked  = "Revoked"
+)
+
+type mockRequest struct {
+	id            string
+	created       time.Time
+	duration      time.Duration
+	justification *string
+	customFields  map[string]string
+	integration   *Integration
+	resourceType  *ResourceType
+	resources     []Resource
+	permissions   []string
+	bundle        *Bundle
+	revokedAt     *time.Time
+}
+
+// status follows the lifecycle of the fixtures: pending, granting, active
+// until the duration ends or the request is revoked, then revoking and
+// revoked.
+func (s *Server) status(request *mockRequest) (string, *time.Time) {
+	now := s.now()
+	lifecycle := s.fixtures.Lifecycle
+
+	granted := request.created.Add(lifecycle.Pending + lifecycle.Granting)
+	end := granted.Add(request.duration)
+	if request.revokedAt != nil && request.revokedAt.Before(end) {
+		end = *request.revokedAt
+	}
+
+	switch {
+	case now.Before(end) && now.Before(request.created.Add(lifecycle.Pending)):
+		return statusPending, nil
+	case now.Before(end) && now.Before(granted):
+		return statusGranting, nil
+	case now.Before(end):
+		return statusActive, &end
+	case now.Before(end.Add(lifecycle.Revoking)):
+		return statusRevoking, &end
+	default:
+		return statusRevoked, &end
+	}
+}
+
+func (s *Server) requestModel(request *mockRequest) clientapi.AccessRequestClientModel {
+	status, revocationTime := s.status(request)
+
+	model := clientapi.AccessRequestClientModel{
+		Id: request.id,
+		Requestor: clientapi.UserClientModel{
+			Id:    s.fixtures.User.ID,
+			Name:  s.fixtures.User.Name,
+			Email: s.fixtures.User.Email,
+		},
+		CreationTime:          float64(request.created.Unix()),
+		Status:                clientapi.RequestStatusClientModel{Status: status, Metadata: map[string]string{}},
+		AccessGroups:          []clientapi.AccessGroupClientModel{},
+		CustomFields:          request.customFields,
+		DistinctResourceNames: []string{},
+		AccessUnitsSummary:    []clientapi.AccessUnitSummaryClientModel{},
+	}
+	if revocationTime != nil {
+		model.RevocationTime = *clientapi.NewNullableFloat64(clientapi.PtrFloat64(float64(revocationTime.Unix())))
+	}
+	if request.justification != nil {
+		model.Justification = *clientapi.NewNullableString(request.justification)
+	}
+	durationInSec := int32(request.duration / time.Second)
+	model.DurationInSec = *clientapi.NewNullableInt32(&durationInSec)
+
+	if request.bundle != nil {
+		model.Bundle = *clientapi.NewNullableAccessRequestClientModelBundle(&clientapi.AccessRequestClientModelBundle{
+			Id:     request.bundle.ID,
+			Name:   request.bundle.Name,
+			Labels: map[string]string{},
+		})
+	}
+
+	resourceTypes := make([]clientapi.ResourceTypeClientModel, 0, len(request.integration.ResourceTypes))
+	for i := range request.integration.ResourceTypes {
+		resourceType := &request.integration.ResourceTypes[i]
+		if request.resourceType == nil || request.resourceType.ID == resourceType.ID {
+			resourceTypes = append(resourceTypes, resourceTypeModel(resourceType))
+		}
+	}
+	model.AccessGroups = append(model.AccessGroups, clientapi.AccessGroupClientModel{
+		Id:            request.id + "-group",
+		Integration:   integrationModel(request.integration),
+		ResourceTypes: resourceTypes,
+		Status:        clientapi.AccessGroupStatusClientModel{Status: clientapi.ACCESSGROUPSTATUS_SUCCESS},
+	})
+
+	for _, resource := range request.resources {
+		model.DistinctResourceNames = append(model.DistinctResourceNames, resource.Name)
+		for _, permission := range request.permissions {
+			model.AccessUnitsSummary = append(model.AccessUnitsSummary, clientapi.AccessUnitSummaryClientModel{
+				ResourceName:   resource.Name,
+				PermissionName: permission,
+			})
+		}
+	}
+
+	return model
+}
+
+// newRequest resolves the filters of a request against the fixtures.
+func (s *Server) newRequest(body *clientapi.CreateAccessRequestClientModel) (*mockRequest, error) {
+	request := &mockRequest{
+		created:      s.now(),
+		duration:     s.fixtures.Lifecycle.DefaultDuration,
+		customFields: body.CustomFields,
+	}
+	if body.DurationInSec.IsSet() && body.DurationInSec.Get() != nil {
+		request.duration = time.Duration(*body.DurationInSec.Get()) * time.Second
+	}
+	if body.Justification.IsSet() && body.Justification.Get() != nil {
+		justification := *body.Justification.Get()
+		request.justification = &justification
+	}
+
+	switch {
+	case len(body.FilterBundleIds) > 0:
+		request.bundle = s.fixtures.bundle(body.FilterBundleIds[0])
+		if request.bundle == nil {
+			return nil, fmt.Errorf("bundle %s not found", body.FilterBundleIds[0])
+		}
+
+		request.integration = s.fixtures.integration(request.bundle.Integration)
+		for _, resourceType := range request.integration.ResourceTypes {
+			request.resources = append(request.resources, resourceType.Resources...)
+			request.permissions = append(request.permissions, resourceType.Permissions...)
+		}
+
+	case len(body.FilterIntegrationIds) > 0:
+		request.integration = s.fixtures.integration(body.FilterIntegrationIds[0])
+		if request.integration == nil {
+			return nil, fmt.Errorf("integration %s not found", body.FilterIntegrationIds[0])
+		}
+		if len(body.FilterResourceTypeIds) == 0 {
+			return nil, fmt.Errorf("filter_resource_type_ids is required")
+		}
+
+		request.resourceType = request.integration.resourceType(body.FilterResourceTypeIds[0])
+		if request.resourceType == nil {
+			return nil, fmt.Errorf("resource type %s not found", body.FilterResourceTypeIds[0])
+		}
+
+		resourceIDs := body.FilterResourceIds
+		for _, filter := range body.FilterResources {
+			if filter.Type == clientapi.RESOURCEFILTERTYPE_ID {
+				resourceIDs = append(resourceIDs, filter.Value)
+			}
+		}
+		for _, id := range resourceIDs {
+			resource := findResource(request.resourceType, id)
+			if resource == nil {
+				return nil, fmt.Errorf("resource %s not found", id)
+			}
+			request.resources = append(request.resources, *resource)
+		}
+
+		for _, id := range body.FilterPermissionIds {
+			if !matchesFilter(request.resourceType.Permissions, id) {
+				return nil, fmt.Errorf("permission %s not found", id)
+			}
+		}
+		request.permissions = body.FilterPermissionIds
+
+		if len(request.resources) == 0 || len(request.permissions) == 0 {
+			return nil, fmt.Errorf("a request needs resources and permissions")
+		}
+
+	default:
+		return nil, fmt.Errorf("a request needs filter_bundle_ids or filter_integration_ids")
+	}
+
+	return request, nil
+}
+
+func (s *Server) addRequest(request *mockRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	request.id = fmt.Sprintf("AR-%04d", len(s.requests)+1)
+	s.requests = append(s.requests, request)
+}
+
+func findResource(resourceType *ResourceType, id string) *Resource {
+	for i := range resourceType.Resources {
+		if resourceType.Resources[i].ID == id {
+			return &resourceType.Resources[i]
+		}
+	}
+	return nil
+}
+
+func decodeBody(r *http.Request, body interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) createRequest(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	body := new(clientapi.CreateAccessRequestClientModel)
+	if err := decodeBody(r, body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	request, err := s.newRequest(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if s.fixtures.RequireJustification && request.justification == nil {
+		writeError(w, http.StatusBadRequest, "justification is required")
+		return
+	}
+
+	s.addRequest(request)
+	writeJSON(w, http.StatusOK, clientapi.AccessRequestSubmittedClientResponse{RequestIds: []string{request.id}})
+}
+
+func (s *Server) dryRunRequest(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	body := new(clientapi.CreateAccessRequestClientModel)
+	if err := decodeBody(r, body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := clientapi.DryRunClientResponse{Status: "Valid", Errors: []clientapi.DryRunAccessRequestError{}}
+	if _, err := s.newRequest(body); err != nil {
+		message := err.Error()
+		response.Errors = append(response.Errors, clientapi.DryRunAccessRequestError{
+			Code:    "INVALID_REQUEST",
+			Field:   "filters",
+			Message: *clientapi.NewNullableString(&message),
+			Details: map[string]interface{}{},
+		})
+	}
+	if s.fixtures.RequireJustification && !body.Justification.IsSet() {
+		response.Errors = append(response.Errors, clientapi.DryRunAccessRequestError{
+			Code:    "FIELD_MISSING",
+			Field:   "justification",
+			Details: map[string]interface{}{},
+		})
+	}
+	if len(response.Errors) > 0 {
+		response.Status = "Invalid"
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (s *Server) findRequest(id string) *mockRequest {
+	for _, request := range s.requests {
+		if request.id == id {
+			return request
+		}
+	}
+	return nil
+}
+
+func (s *Server) getRequest(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	request := s.findRequest(params["id"])
+	if request == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("access request %s not found", params["id"]))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.requestModel(request))
+}
+
+func (s *Server) listRequests(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	// Newest first, as the client API lists requests.
+	requestIDs := queryValues(r, "request_ids")
+	statuses := queryValues(r, "statuses")
+
+	s.mu.Lock()
+	models := make([]clientapi.AccessRequestClientModel, 0, len(s.requests))
+	for i := len(s.requests) - 1; i >= 0; i-- {
+		model := s.requestModel(s.requests[i])
+		if matchesFilter(requestIDs, model.Id) && matchesFilter(statuses, model.Status.Status) {
+			models = append(models, model)
+		}
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, page(r, models))
+}
+
+func (s *Server) revokeRequest(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	request := s.findRequest(params["id"])
+	if request == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("access request %s not found", params["id"]))
+		return
+	}
+	if status, _ := s.status(request); status == statusRevoking || status == statusRevoked {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("access request %s is already %s", request.id, status))
+		return
+	}
+
+	revokedAt := s.now()
+	request.revokedAt = &revokedAt
+	writeJSON(w, http.StatusOK, clientapi.MessageResponse{Message: fmt.Sprintf("Revoking access request %s", request.id)})
+}
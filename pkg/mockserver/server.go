@@ -0,0 +1,181 @@
+// Package mockserver simulates the Apono client API for development and
+// tests. Requests go through pending, granting, active and revoked on the
+// timing of the fixtures, and operations of the OpenAPI spec without a
+// simulation answer with the smallest response their schema accepts.
+package mockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StubHeader marks responses generated from the spec rather than simulated.
+const StubHeader = "X-Apono-Mock-Stub"
+
+type handlerFunc func(w http.ResponseWriter, r *http.Request, params map[string]string)
+
+type Server struct {
+	fixtures *Fixtures
+	router   *router
+	handlers map[string]handlerFunc
+	now      func() time.Time
+
+	mu       sync.Mutex
+	requests []*mockRequest
+	sessions map[string]*sessionState
+}
+
+func New(fixtures *Fixtures) (*Server, error) {
+	r, err := loadRouter()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		fixtures: fixtures,
+		router:   r,
+		now:      time.Now,
+		sessions: make(map[string]*sessionState),
+	}
+	s.handlers = map[string]handlerFunc{
+		"getUserSession": s.getUserSession,
+
+		"listIntegration":   s.listIntegrations,
+		"listAccessBundles": s.listBundles,
+		"listResourceTypes": s.listResourceTypes,
+		"listResources":     s.listResources,
+		"listPermissions":   s.listPermissions,
+		"listGrantees":      s.listGrantees,
+		"listCliNotifications": func(w http.ResponseWriter, _ *http.Request, _ map[string]string) {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"notifications": []interface{}{}})
+		},
+
+		"createUserAccessRequest":       s.createRequest,
+		"dryRunCreateUserAccessRequest": s.dryRunRequest,
+		"getAccessRequest":              s.getRequest,
+		"listAccessRequests":            s.listRequests,
+		"revokeAccessRequest":           s.revokeRequest,
+
+		"listAccessSessions":            s.listSessions,
+		"listAccessSessionsGroups":      s.listSessionGroups,
+		"getAccessSession":              s.getSession,
+		"getAccessSessionAccessDetails": s.getAccessDetails,
+		"resetAccessSessionCredentials": s.resetCredentials,
+
+		"handleMcpMethod": s.handleMCP,
+	}
+	return s, nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.fixtures.Token != "" && r.Header.Get("Authorization") != "Bearer "+s.fixtures.Token {
+		writeError(w, http.StatusUnauthorized, "invalid token")
+		return
+	}
+
+	route, params := s.router.match(r.Method, r.URL.Path)
+	if route == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s is not part of the client API", r.Method, r.URL.Path))
+		return
+	}
+
+	if handler, ok := s.handlers[route.operationID]; ok {
+		handler(w, r, params)
+		return
+	}
+
+	w.Header().Set(StubHeader, route.operationID)
+	if route.response == nil {
+		w.WriteHeader(route.status)
+		return
+	}
+	writeJSON(w, route.status, s.router.stub(route.response, 0))
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(data)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"message": message})
+}
+
+// queryValues returns the values of a query parameter, whether the client
+// repeats it or joins the values with commas.
+func queryValues(r *http.Request, name string) []string {
+	var values []string
+	for _, value := range r.URL.Query()[name] {
+		for _, part := range strings.Split(value, ",") {
+			if part != "" {
+				values = append(values, part)
+			}
+		}
+	}
+	return values
+}
+
+func matchesFilter(filter []string, value string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, allowed := range filter {
+		if allowed == value {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesSearch(r *http.Request, values ...string) bool {
+	search := strings.ToLower(r.URL.Query().Get("search"))
+	if search == "" {
+		return true
+	}
+	for _, value := range values {
+		if strings.Contains(strings.ToLower(value), search) {
+			return true
+		}
+	}
+	return false
+}
+
+// page slices the items by the skip and limit parameters into the paginated
+// response of the client API.
+func page[T any](r *http.Request, items []T) map[string]interface{} {
+	skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = defaultPageLimit
+	}
+
+	total := len(items)
+	skip = min(max(skip, 0), total)
+	end := min(skip+limit, total)
+
+	data := items[skip:end]
+	if data == nil {
+		data = []T{}
+	}
+	return map[string]interface{}{
+		"data": data,
+		"pagination": map[string]interface{}{
+			"limit":    limit,
+			"offset":   skip,
+			"total":    total,
+			"has_more": end < total,
+		},
+	}
+}
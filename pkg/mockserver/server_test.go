@@ -0,0 +1,222 @@
+package mockserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apono-io/apono-cli/pkg/sdk"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestServer(t *testing.T, fixtures string) (*httptest.Server, *fakeClock) {
+	t.Helper()
+
+	parsed, err := ParseFixtures([]byte(fixtures))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := New(parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	server.now = clock.Now
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	return httpServer, clock
+}
+
+func TestRequestLifecycle(t *testing.T) {
+	httpServer, clock := newTestServer(t, "token: secret")
+	ctx := context.Background()
+
+	client, err := sdk.NewClient(ctx, sdk.WithToken("secret", httpServer.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request, err := client.CreateRequest(ctx, sdk.IntegrationRequest("postgresql/staging", "postgresql-database").
+		WithResources("orders").
+		WithPermissions("READ_ONLY").
+		WithJustification("testing").
+		WithDuration(time.Hour))
+	if err != nil {
+		t.Fatalf("CreateRequest() error = %v", err)
+	}
+
+	steps := []struct {
+		advance time.Duration
+		status  string
+	}{
+		{0, statusPending},
+		{2 * time.Second, statusGranting},
+		{time.Second, statusActive},
+	}
+	for _, step := range steps {
+		clock.Advance(step.advance)
+		request, err = client.GetRequest(ctx, request.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if request.Status.Status != step.status {
+			t.Fatalf("status = %q, want %q", request.Status.Status, step.status)
+		}
+	}
+
+	sessions, err := client.ListSessions(ctx, request.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 1 {
+		t.Fatalf("ListSessions() = %d sessions, want 1", len(sessions))
+	}
+
+	credentials, err := client.Credentials(ctx, sessions[0].Id, sdk.CredentialsCLI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(credentials, "psql ") {
+		t.Errorf("Credentials() = %q, want the cli access details of the fixtures", credentials)
+	}
+
+	if err = client.Revoke(ctx, request.Id); err != nil {
+		t.Fatal(err)
+	}
+	clock.Advance(time.Second)
+	request, err = client.GetRequest(ctx, request.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if request.Status.Status != statusRevoked || !request.RevocationTime.IsSet() {
+		t.Errorf("status = %q, want %q with a revocation time", request.Status.Status, statusRevoked)
+	}
+
+	if sessions, _ = client.ListSessions(ctx, request.Id); len(sessions) != 0 {
+		t.Errorf("ListSessions() after revoke = %d sessions, want none", len(sessions))
+	}
+}
+
+func TestRequestExpires(t *testing.T) {
+	httpServer, clock := newTestServer(t, "")
+	ctx := context.Background()
+
+	client, err := sdk.NewClient(ctx, sdk.WithToken("any", httpServer.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request, err := client.CreateRequest(ctx, sdk.BundleRequest("db-admins").WithDuration(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clock.Advance(3*time.Second + time.Minute + time.Second)
+	request, err = client.GetRequest(ctx, request.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if request.Status.Status != statusRevoked {
+		t.Errorf("status = %q, want %q", request.Status.Status, statusRevoked)
+	}
+}
+
+func TestRequireJustification(t *testing.T) {
+	httpServer, _ := newTestServer(t, "require_justification: true")
+	ctx := context.Background()
+
+	client, err := sdk.NewClient(ctx, sdk.WithToken("any", httpServer.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = client.CreateRequest(ctx, sdk.BundleRequest("db-admins")); !errors.Is(err, sdk.ErrJustificationRequired) {
+		t.Errorf("CreateRequest() error = %v, want %v", err, sdk.ErrJustificationRequired)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	httpServer, _ := newTestServer(t, "token: secret")
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		token      string
+		wantStatus int
+		wantStub   bool
+	}{
+		{name: "wrong token", method: http.MethodGet, path: "/api/client/v1/session", token: "other", wantStatus: http.StatusUnauthorized},
+		{name: "simulated", method: http.MethodGet, path: "/api/client/v1/session", token: "secret", wantStatus: http.StatusOK},
+		{name: "stub", method: http.MethodGet, path: "/api/client/v1/access-request-templates", token: "secret", wantStatus: http.StatusOK, wantStub: true},
+		{name: "unknown request", method: http.MethodGet, path: "/api/client/v1/access-requests/AR-9999", token: "secret", wantStatus: http.StatusNotFound},
+		{name: "not in the spec", method: http.MethodGet, path: "/api/v2/unknown", token: "secret", wantStatus: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, httpServer.URL+tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if stub := resp.Header.Get(StubHeader) != ""; stub != tt.wantStub {
+				t.Errorf("stub = %v, want %v", stub, tt.wantStub)
+			}
+		})
+	}
+}
+
+func TestParseFixtures(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixtures string
+		wantErr  bool
+	}{
+		{name: "defaults", fixtures: ""},
+		{name: "overrides", fixtures: "lifecycle:\n  pending: 0s\n"},
+		{name: "unknown bundle integration", fixtures: "bundles:\n  - id: b\n    integration: missing\n", wantErr: true},
+		{name: "duplicate integration", fixtures: "bundles: []\nintegrations:\n  - id: a\n  - id: a\n", wantErr: true},
+		{name: "invalid duration", fixtures: "lifecycle:\n  pending: soon\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFixtures([]byte(tt.fixtures))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseFixtures() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+)
+
+const sessionIDSuffix = "-session"
+
+type sessionState struct {
+	consumedBy         string
+	credentialsRotated bool
+}
+
+// activeSession is the session of a request, which exists while the request
+// is active.
+type activeSession struct {
+	request *mockRequest
+	state   *sessionState
+}
+
+func (s *Server) activeSessions() []activeSession {
+	var sessions []activeSession
+	for i := len(s.requests) - 1; i >= 0; i-- {
+		request := s.requests[i]
+		if status, _ := s.status(request); status != statusActive {
+			continue
+		}
+
+		state, ok := s.sessions[request.id]
+		if !ok {
+			state = new(sessionState)
+			s.sessions[request.id] = state
+		}
+		sessions = append(sessions, activeSession{request: request, state: state})
+	}
+	return sessions
+}
+
+func (s *Server) findSession(id string) *activeSession {
+	for _, session := range s.activeSessions() {
+		if session.request.id+sessionIDSuffix == id {
+			return &session
+		}
+	}
+	return nil
+}
+
+func (s *Server) sessionModel(session activeSession) clientapi.AccessSessionClientModel {
+	request := session.request
+	name := request.integration.Name
+	if request.bundle != nil {
+		name = request.bundle.Name
+	}
+
+	sessionType := clientapi.SessionTypeClientModel{Id: request.integration.Type, Name: request.integration.TypeDisplayName}
+	if request.resourceType != nil {
+		sessionType = clientapi.SessionTypeClientModel{Id: request.resourceType.ID, Name: request.resourceType.Name}
+	}
+
+	model := clientapi.AccessSessionClientModel{
+		Id:                 request.id + sessionIDSuffix,
+		Name:               name,
+		Type:               sessionType,
+		Integration:        integrationModel(request.integration),
+		ConnectionMethods:  s.connectionMethods(),
+		Status:             statusActive,
+		CredentialsRotated: session.state.credentialsRotated,
+		Launchers:          []clientapi.LauncherSummaryClientModel{},
+	}
+	model.Credentials = *clientapi.NewNullableAccessSessionClientModelCredentials(&clientapi.AccessSessionClientModelCredentials{
+		Id:       request.id + "-credentials",
+		Status:   "Valid",
+		CanReset: true,
+	})
+	model.HasLinkAccessDetails = *clientapi.NewNullableBool(clientapi.PtrBool(s.fixtures.AccessDetails.Link != ""))
+	if session.state.consumedBy != "" {
+		model.ConsumedBy = *clientapi.NewNullableString(clientapi.PtrString(session.state.consumedBy))
+	}
+	return model
+}
+
+func (s *Server) connectionMethods() []string {
+	details := s.fixtures.AccessDetails
+	methods := []string{}
+	if details.CLI != "" {
+		methods = append(methods, "cli")
+	}
+	if details.Link != "" {
+		methods = append(methods, "link")
+	}
+	if details.Instructions != "" {
+		methods = append(methods, "instructions")
+	}
+	if details.JSON != nil {
+		methods = append(methods, "json")
+	}
+	return methods
+}
+
+func (s *Server) listSessions(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	integrationIDs := queryValues(r, "integration_id")
+	bundleIDs := queryValues(r, "bundle_id")
+	requestIDs := queryValues(r, "request_id")
+
+	s.mu.Lock()
+	models := make([]clientapi.AccessSessionClientModel, 0)
+	for _, session := range s.activeSessions() {
+		request := session.request
+		bundleID := ""
+		if request.bundle != nil {
+			bundleID = request.bundle.ID
+		}
+		if matchesFilter(integrationIDs, request.integration.ID) && matchesFilter(requestIDs, request.id) && matchesFilter(bundleIDs, bundleID) {
+			model := s.sessionModel(session)
+			if matchesSearch(r, model.Name, model.Integration.Name) {
+				models = append(models, model)
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, page(r, models))
+}
+
+func (s *Server) listSessionGroups(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	integrationIDs := queryValues(r, "integration_id")
+
+	s.mu.Lock()
+	groups := make([]clientapi.AccessSessionsGroupClientModel, 0)
+	indexes := make(map[string]int)
+	for _, session := range s.activeSessions() {
+		integration := session.request.integration
+		if !matchesFilter(integrationIDs, integration.ID) {
+			continue
+		}
+
+		index, ok := indexes[integration.ID]
+		if !ok {
+			index = len(groups)
+			indexes[integration.ID] = index
+			groups = append(groups, clientapi.AccessSessionsGroupClientModel{
+				Integration: *clientapi.NewNullableAccessSessionsGroupClientModelIntegration(&clientapi.AccessSessionsGroupClientModelIntegration{
+					Id:              integration.ID,
+					Type:            integration.Type,
+					TypeDisplayName: integration.TypeDisplayName,
+					Name:            integration.Name,
+				}),
+				Sessions: []clientapi.AccessSessionClientModel{},
+			})
+		}
+		groups[index].Sessions = append(groups[index].Sessions, s.sessionModel(session))
+		groups[index].Total++
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, page(r, groups))
+}
+
+func (s *Server) getSession(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session := s.findSession(params["id"])
+	if session == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("access session %s not found", params["id"]))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.sessionModel(*session))
+}
+
+func (s *Server) getAccessDetails(w http.ResponseWriter, r *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session := s.findSession(params["id"])
+	if session == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("access session %s not found", params["id"]))
+		return
+	}
+	if consumedBy := r.URL.Query().Get("consumed_by"); consumedBy != "" {
+		session.state.consumedBy = consumedBy
+	}
+
+	fixture := s.fixtures.AccessDetails
+	details := clientapi.AccessSessionDetailsClientModel{
+		Instructions: clientapi.InstructionClientModel{
+			Plain:    fixture.Instructions,
+			Markdown: fixture.Instructions,
+		},
+		Json:               fixture.JSON,
+		CredentialsRotated: session.state.credentialsRotated,
+		Launchers:          []clientapi.LauncherClientModel{},
+	}
+	if fixture.CLI != "" {
+		details.Cli = *clientapi.NewNullableString(clientapi.PtrString(fixture.CLI))
+	}
+	if fixture.Link != "" {
+		details.Link = *clientapi.NewNullableAccessSessionDetailsClientModelLink(&clientapi.AccessSessionDetailsClientModelLink{Url: fixture.Link})
+	}
+	if session.state.consumedBy != "" {
+		details.ConsumedBy = *clientapi.NewNullableString(clientapi.PtrString(session.state.consumedBy))
+	}
+
+	writeJSON(w, http.StatusOK, details)
+}
+
+func (s *Server) resetCredentials(w http.ResponseWriter, _ *http.Request, params map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session := s.findSession(params["id"])
+	if session == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("access session %s not found", params["id"]))
+		return
+	}
+	session.state.credentialsRotated = true
+
+	writeJSON(w, http.StatusOK, clientapi.MessageResponse{
+		Message: fmt.Sprintf("Resetting credentials of session %s", params["id"]),
+	})
+}
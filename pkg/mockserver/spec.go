@@ -0,0 +1,215 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/apono-io/apono-cli/pkg/clientapi/api"
+)
+
+const (
+	stubMaxDepth     = 12
+	defaultPageLimit = 100
+)
+
+type openAPISpec struct {
+	Paths      map[string]map[string]specOperation `json:"paths"`
+	Components struct {
+		Schemas map[string]*schema `json:"schemas"`
+	} `json:"components"`
+}
+
+type specOperation struct {
+	OperationID string `json:"operationId"`
+	Responses   map[string]struct {
+		Content map[string]struct {
+			Schema *schema `json:"schema"`
+		} `json:"content"`
+	} `json:"responses"`
+}
+
+type schema struct {
+	Ref        string             `json:"$ref"`
+	Type       string             `json:"type"`
+	Format     string             `json:"format"`
+	Properties map[string]*schema `json:"properties"`
+	Required   []string           `json:"required"`
+	Items      *schema            `json:"items"`
+	Enum       []interface{}      `json:"enum"`
+	Default    interface{}        `json:"default"`
+	AllOf      []*schema          `json:"allOf"`
+	OneOf      []*schema          `json:"oneOf"`
+	AnyOf      []*schema          `json:"anyOf"`
+}
+
+// route is an operation of the spec, with its path split into segments where
+// "{...}" segments match any value.
+type route struct {
+	operationID string
+	method      string
+	segments    []string
+	status      int
+	response    *schema
+}
+
+type router struct {
+	routes  []route
+	schemas map[string]*schema
+}
+
+func loadRouter() (*router, error) {
+	spec := new(openAPISpec)
+	if err := json.Unmarshal(api.OpenAPISpec, spec); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI spec: %w", err)
+	}
+
+	r := &router{schemas: spec.Components.Schemas}
+	for path, operations := range spec.Paths {
+		for method, operation := range operations {
+			status, response := successResponse(operation)
+			r.routes = append(r.routes, route{
+				operationID: operation.OperationID,
+				method:      strings.ToUpper(method),
+				segments:    strings.Split(strings.Trim(path, "/"), "/"),
+				status:      status,
+				response:    response,
+			})
+		}
+	}
+
+	// Literal segments win over parameters, so /access-requests/dry-run is
+	// not matched as /access-requests/{id}.
+	sort.SliceStable(r.routes, func(i, j int) bool {
+		return r.routes[i].literalSegments() > r.routes[j].literalSegments()
+	})
+	return r, nil
+}
+
+func successResponse(operation specOperation) (int, *schema) {
+	codes := make([]string, 0, len(operation.Responses))
+	for code := range operation.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return http.StatusOK, nil
+	}
+	sort.Strings(codes)
+
+	var status int
+	_, _ = fmt.Sscanf(codes[0], "%d", &status)
+	content, ok := operation.Responses[codes[0]].Content["application/json"]
+	if !ok {
+		return status, nil
+	}
+	return status, content.Schema
+}
+
+func (r route) literalSegments() int {
+	count := 0
+	for _, segment := range r.segments {
+		if !isPathParameter(segment) {
+			count++
+		}
+	}
+	return count
+}
+
+func isPathParameter(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// match returns the route of the request and its path parameters.
+func (r *router) match(method, path string) (*route, map[string]string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := range r.routes {
+		candidate := &r.routes[i]
+		if candidate.method != method || len(candidate.segments) != len(segments) {
+			continue
+		}
+
+		params := make(map[string]string)
+		matched := true
+		for j, segment := range candidate.segments {
+			switch {
+			case isPathParameter(segment):
+				params[strings.Trim(segment, "{}")] = segments[j]
+			case segment != segments[j]:
+				matched = false
+			}
+			if !matched {
+				break
+			}
+		}
+		if matched {
+			return candidate, params
+		}
+	}
+	return nil, nil
+}
+
+// stub builds the smallest value the schema accepts: required properties
+// only, empty arrays and zero values, preferring defaults and the first enum
+// value.
+func (r *router) stub(s *schema, depth int) interface{} {
+	if s == nil || depth > stubMaxDepth {
+		return nil
+	}
+	if s.Ref != "" {
+		return r.stub(r.schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")], depth+1)
+	}
+	if s.Default != nil {
+		return s.Default
+	}
+	if len(s.Enum) > 0 {
+		return s.Enum[0]
+	}
+	if len(s.AllOf) > 0 {
+		merged := make(map[string]interface{})
+		for _, part := range s.AllOf {
+			if object, ok := r.stub(part, depth+1).(map[string]interface{}); ok {
+				for key, value := range object {
+					merged[key] = value
+				}
+			}
+		}
+		return merged
+	}
+	if len(s.OneOf) > 0 {
+		return r.stub(s.OneOf[0], depth+1)
+	}
+	if len(s.AnyOf) > 0 {
+		return r.stub(s.AnyOf[0], depth+1)
+	}
+
+	switch s.Type {
+	case "object":
+		object := make(map[string]interface{})
+		for _, name := range s.Required {
+			object[name] = r.stub(s.Properties[name], depth+1)
+		}
+		// A zero page limit makes paginating clients request the next page
+		// forever.
+		if _, ok := object["limit"]; ok && s.Properties["offset"] != nil {
+			object["limit"] = defaultPageLimit
+		}
+		return object
+	case "array":
+		return []interface{}{}
+	case "string":
+		if s.Format == "date-time" {
+			return "1970-01-01T00:00:00Z"
+		}
+		return ""
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	default:
+		return nil
+	}
+}
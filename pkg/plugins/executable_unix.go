@@ -0,0 +1,17 @@
+//go:build !windows
+
+package plugins
+
+import "os"
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return info.Mode().IsRegular() && info.Mode().Perm()&0o111 != 0
+}
+
+func trimExecutableExtension(name string) string {
+	return name
+}
@@ -0,0 +1,36 @@
+//go:build windows
+
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func executableExtensions() []string {
+	pathExt := os.Getenv("PATHEXT")
+	if pathExt == "" {
+		pathExt = ".com;.exe;.bat;.cmd"
+	}
+	return strings.Split(strings.ToLower(pathExt), ";")
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, executableExt := range executableExtensions() {
+		if ext != "" && ext == executableExt {
+			return true
+		}
+	}
+	return false
+}
+
+func trimExecutableExtension(name string) string {
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
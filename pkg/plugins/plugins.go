@@ -0,0 +1,98 @@
+// Package plugins finds the executables that extend the CLI. A plugin is an
+// executable named apono-<name> on PATH, which runs as `apono <name>`.
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/apono-io/apono-cli/pkg/config"
+)
+
+const (
+	Prefix = "apono-"
+
+	// TokenExpiryEnvVar is the RFC 3339 expiry of the token passed to a
+	// plugin, unset for personal tokens.
+	TokenExpiryEnvVar = "APONO_TOKEN_EXPIRY"
+)
+
+type Plugin struct {
+	Name string `json:"name" yaml:"name"`
+	Path string `json:"path" yaml:"path"`
+	// ShadowedBy is the path of the plugin with the same name that comes
+	// earlier on PATH and runs instead of this one.
+	ShadowedBy string `json:"shadowed_by,omitempty" yaml:"shadowed_by,omitempty"`
+}
+
+// Discover lists the plugins on PATH in PATH order, including the shadowed
+// ones.
+func Discover() []Plugin {
+	var plugins []Plugin
+	paths := make(map[string]string)
+	seenDirs := make(map[string]bool)
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" || seenDirs[dir] {
+			continue
+		}
+		seenDirs[dir] = true
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok {
+				continue
+			}
+
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+
+			plugin := Plugin{Name: name, Path: path}
+			if first, exists := paths[name]; exists {
+				plugin.ShadowedBy = first
+			} else {
+				paths[name] = path
+			}
+			plugins = append(plugins, plugin)
+		}
+	}
+
+	return plugins
+}
+
+func pluginName(fileName string) (string, bool) {
+	if !strings.HasPrefix(fileName, Prefix) {
+		return "", false
+	}
+
+	name := trimExecutableExtension(strings.TrimPrefix(fileName, Prefix))
+	return name, name != ""
+}
+
+// Env returns the environment variables that let a plugin call the API, or
+// the CLI, as the resolved profile. profileName is empty when the CLI is
+// configured by APONO_TOKEN alone.
+func Env(profileName config.ProfileName, apiURL string, token *oauth2.Token) []string {
+	env := []string{
+		config.APIURLEnvVar + "=" + apiURL,
+		config.TokenEnvVar + "=" + token.AccessToken,
+	}
+	if profileName != "" {
+		env = append(env, config.ProfileEnvVar+"="+string(profileName))
+	}
+	if !token.Expiry.IsZero() {
+		env = append(env, TokenExpiryEnvVar+"="+token.Expiry.UTC().Format(time.RFC3339))
+	}
+	return env
+}
@@ -0,0 +1,83 @@
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/apono-io/apono-cli/pkg/config"
+)
+
+func writeFile(t *testing.T, path string, mode os.FileMode) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"), mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are found by the executable bit")
+	}
+
+	first, second := t.TempDir(), t.TempDir()
+	writeFile(t, filepath.Join(first, "apono-tag"), 0o755)
+	writeFile(t, filepath.Join(first, "apono-notes"), 0o644)
+	writeFile(t, filepath.Join(first, "other-tool"), 0o755)
+	writeFile(t, filepath.Join(second, "apono-tag"), 0o755)
+	writeFile(t, filepath.Join(second, "apono-link"), 0o755)
+	t.Setenv("PATH", first+string(os.PathListSeparator)+second+string(os.PathListSeparator)+first)
+
+	want := []Plugin{
+		{Name: "tag", Path: filepath.Join(first, "apono-tag")},
+		{Name: "link", Path: filepath.Join(second, "apono-link")},
+		{Name: "tag", Path: filepath.Join(second, "apono-tag"), ShadowedBy: filepath.Join(first, "apono-tag")},
+	}
+
+	if got := Discover(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Discover() = %+v, want %+v", got, want)
+	}
+}
+
+func TestEnv(t *testing.T) {
+	expiry := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		profileName config.ProfileName
+		token       *oauth2.Token
+		want        []string
+	}{
+		{
+			name:        "oauth profile",
+			profileName: "prod",
+			token:       &oauth2.Token{AccessToken: "access", Expiry: expiry},
+			want: []string{
+				"APONO_API_URL=https://api.example.com",
+				"APONO_TOKEN=access",
+				"APONO_PROFILE=prod",
+				"APONO_TOKEN_EXPIRY=2026-01-02T03:04:05Z",
+			},
+		},
+		{
+			name:  "environment token",
+			token: &oauth2.Token{AccessToken: "personal"},
+			want: []string{
+				"APONO_API_URL=https://api.example.com",
+				"APONO_TOKEN=personal",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Env(tt.profileName, "https://api.example.com", tt.token); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Env() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
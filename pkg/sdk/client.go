@@ -0,0 +1,79 @@
+// Package sdk embeds the Apono access flows of the CLI in other Go programs:
+// requesting access, waiting for it to be granted, fetching the credentials
+// and revoking it.
+//
+//	client, err := sdk.NewClient(ctx, sdk.WithProfile("prod"))
+//	request, err := client.CreateRequest(ctx, sdk.BundleRequest("DB admins").WithJustification("incident"))
+//	request, err = client.WaitForGrant(ctx, request.Id, sdk.WaitOptions{Timeout: 5 * time.Minute})
+//	sessions, err := client.ListSessions(ctx, request.Id)
+//	credentials, err := client.Credentials(ctx, sessions[0].Id, sdk.CredentialsJSON)
+package sdk
+
+import (
+	"context"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+)
+
+// Client runs the access flows with the credentials it was created with.
+type Client struct {
+	api *aponoapi.AponoClient
+}
+
+type clientOptions struct {
+	profile       string
+	personalToken string
+	apiURL        string
+}
+
+type Option func(*clientOptions)
+
+// WithProfile selects a profile of the CLI config, as --profile does.
+func WithProfile(name string) Option {
+	return func(o *clientOptions) {
+		o.profile = name
+	}
+}
+
+// WithToken authenticates with a personal token instead of the CLI config.
+// An empty apiURL selects the default Apono API.
+func WithToken(personalToken, apiURL string) Option {
+	return func(o *clientOptions) {
+		o.personalToken = personalToken
+		o.apiURL = apiURL
+	}
+}
+
+// NewClient creates a client the way the CLI commands do. Without options it
+// honours APONO_TOKEN, APONO_PROFILE and the other environment variables of
+// the CLI, and falls back to the active profile.
+func NewClient(ctx context.Context, opts ...Option) (*Client, error) {
+	options := new(clientOptions)
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if options.personalToken != "" {
+		api, err := aponoapi.CreateClientWithPersonalToken(options.apiURL, options.personalToken)
+		if err != nil {
+			return nil, err
+		}
+		return NewClientFromAPI(api), nil
+	}
+
+	api, err := aponoapi.CreateClient(ctx, options.profile)
+	if err != nil {
+		return nil, err
+	}
+	return NewClientFromAPI(api), nil
+}
+
+// NewClientFromAPI wraps an existing API client.
+func NewClientFromAPI(api *aponoapi.AponoClient) *Client {
+	return &Client{api: api}
+}
+
+// API returns the underlying API client, for calls the SDK does not cover.
+func (c *Client) API() *aponoapi.AponoClient {
+	return c.api
+}
@@ -0,0 +1,54 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/services"
+)
+
+// Credentials formats, as in `apono access use --output`.
+const (
+	CredentialsCLI          = services.CliOutputFormat
+	CredentialsLink         = services.LinkOutputFormat
+	CredentialsInstructions = services.InstructionsOutputFormat
+	CredentialsJSON         = services.JSONOutputFormat
+)
+
+// ListSessions returns the access sessions of a request, or all active
+// sessions when requestID is empty.
+func (c *Client) ListSessions(ctx context.Context, requestID string) ([]clientapi.AccessSessionClientModel, error) {
+	var requestIDs []string
+	if requestID != "" {
+		requestIDs = []string{requestID}
+	}
+
+	return services.ListAccessSessions(ctx, c.api, nil, nil, requestIDs)
+}
+
+// AccessDetails fetches the credentials of a session in all formats. The
+// session is marked as used by the CLI.
+func (c *Client) AccessDetails(ctx context.Context, sessionID string) (*clientapi.AccessSessionDetailsClientModel, error) {
+	details, _, err := c.api.ClientAPI.AccessSessionsAPI.GetAccessSessionAccessDetails(ctx, sessionID).
+		ConsumedBy(aponoapi.ConsumedByAponoCli).
+		Execute()
+	if err != nil {
+		return nil, fmt.Errorf("error getting access details for session id %s: %w", sessionID, err)
+	}
+
+	return details, nil
+}
+
+// Credentials fetches the credentials of a session rendered in format, one of
+// the Credentials* constants.
+func (c *Client) Credentials(ctx context.Context, sessionID, format string) (string, error) {
+	details, err := c.AccessDetails(ctx, sessionID)
+	if err != nil {
+		return "", err
+	}
+
+	credentials, _, err := services.RenderAccessDetails(details, format)
+	return credentials, err
+}
@@ -0,0 +1,235 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/services"
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+var (
+	ErrJustificationRequired = errors.New("justification is required for this request")
+	ErrDurationRequired      = errors.New("duration is required for this request")
+)
+
+// DurationTooLongError is returned when the requested duration exceeds the
+// maximum the access flow allows.
+type DurationTooLongError struct {
+	Maximum time.Duration
+}
+
+func (e *DurationTooLongError) Error() string {
+	return fmt.Sprintf("duration is too long, maximum duration is %.2f hours", e.Maximum.Hours())
+}
+
+// RequestBuilder describes an access request by names, which BuildRequest
+// resolves to ids.
+type RequestBuilder struct {
+	integration   string
+	resourceType  string
+	resources     []string
+	permissions   []string
+	bundle        string
+	justification string
+	duration      *time.Duration
+	customFields  map[string]string
+	grantee       string
+}
+
+// IntegrationRequest requests permissions on resources of an integration,
+// given by id or type/name, for example "aws-account/My AWS integration".
+func IntegrationRequest(integration, resourceType string) *RequestBuilder {
+	return &RequestBuilder{integration: integration, resourceType: resourceType}
+}
+
+// BundleRequest requests a bundle by id or name.
+func BundleRequest(bundle string) *RequestBuilder {
+	return &RequestBuilder{bundle: bundle}
+}
+
+// WithResources adds resources by their source ids.
+func (b *RequestBuilder) WithResources(sourceIDs ...string) *RequestBuilder {
+	b.resources = append(b.resources, sourceIDs...)
+	return b
+}
+
+func (b *RequestBuilder) WithPermissions(permissions ...string) *RequestBuilder {
+	b.permissions = append(b.permissions, permissions...)
+	return b
+}
+
+func (b *RequestBuilder) WithJustification(justification string) *RequestBuilder {
+	b.justification = justification
+	return b
+}
+
+func (b *RequestBuilder) WithDuration(duration time.Duration) *RequestBuilder {
+	b.duration = &duration
+	return b
+}
+
+func (b *RequestBuilder) WithCustomField(fieldID, value string) *RequestBuilder {
+	if b.customFields == nil {
+		b.customFields = make(map[string]string)
+	}
+	b.customFields[fieldID] = value
+	return b
+}
+
+// WithGrantee requests the access on behalf of another user or group, by
+// email or id.
+func (b *RequestBuilder) WithGrantee(grantee string) *RequestBuilder {
+	b.grantee = grantee
+	return b
+}
+
+// BuildRequest resolves the names of the builder to the API model.
+func (c *Client) BuildRequest(ctx context.Context, b *RequestBuilder) (*clientapi.CreateAccessRequestClientModel, error) {
+	req := services.GetEmptyNewRequestAPIModel()
+
+	if b.justification != "" {
+		req.Justification = *clientapi.NewNullableString(&b.justification)
+	}
+
+	if b.duration != nil {
+		if *b.duration <= 0 {
+			return nil, fmt.Errorf("duration must be greater than 0")
+		}
+
+		durationInSec := int32(b.duration.Seconds())
+		req.DurationInSec = *clientapi.NewNullableInt32(&durationInSec)
+	}
+
+	req.CustomFields = make(map[string]string)
+	for fieldID, value := range b.customFields {
+		req.CustomFields[fieldID] = value
+	}
+
+	if b.grantee != "" {
+		grantee, err := services.GetGranteeByIDOrEmail(ctx, c.api, b.grantee)
+		if err != nil {
+			return nil, err
+		}
+
+		req.GranteeId = *clientapi.NewNullableString(&grantee.Id)
+	}
+
+	switch {
+	case b.integration != "" && b.bundle != "":
+		return nil, fmt.Errorf("a request is either for an integration or for a bundle")
+
+	case b.integration != "":
+		if b.resourceType == "" || len(b.resources) == 0 || len(b.permissions) == 0 {
+			return nil, fmt.Errorf("an integration request needs a resource type, resources and permissions")
+		}
+
+		integration, err := services.GetIntegrationByIDOrByTypeAndName(ctx, c.api, b.integration)
+		if err != nil {
+			return nil, err
+		}
+
+		resources, err := services.ListResourcesBySourceIDs(ctx, c.api, integration.Id, b.resourceType, b.resources)
+		if err != nil {
+			return nil, err
+		}
+
+		var resourceIDs []string
+		for _, resource := range resources {
+			resourceIDs = append(resourceIDs, resource.Id)
+		}
+
+		req.FilterIntegrationIds = []string{integration.Id}
+		req.FilterResourceTypeIds = []string{b.resourceType}
+		req.FilterResources = services.ListResourceFiltersFromResourcesIDs(resourceIDs)
+		req.FilterPermissionIds = b.permissions
+
+	case b.bundle != "":
+		bundle, err := services.GetBundleByNameOrID(ctx, c.api, b.bundle)
+		if err != nil {
+			return nil, err
+		}
+
+		req.FilterBundleIds = []string{bundle.Id}
+
+	default:
+		return nil, fmt.Errorf("a request needs an integration or a bundle")
+	}
+
+	return req, nil
+}
+
+// ValidateRequest checks the justification and duration the access flow of
+// the request requires. A failing dry run is not an error, the server
+// validates the request again when it is created.
+func (c *Client) ValidateRequest(ctx context.Context, req *clientapi.CreateAccessRequestClientModel) error {
+	dryRunResp, err := services.DryRunRequest(ctx, c.api, req)
+	if err != nil {
+		return nil
+	}
+
+	if !services.IsJustificationOptionalForRequest(dryRunResp) && !req.Justification.IsSet() {
+		return ErrJustificationRequired
+	}
+
+	if services.IsDurationRequiredForRequest(dryRunResp) {
+		if !req.DurationInSec.IsSet() {
+			return ErrDurationRequired
+		}
+
+		maximum := services.GetMaximumRequestDuration(dryRunResp)
+		if time.Duration(*req.DurationInSec.Get())*time.Second > maximum {
+			return &DurationTooLongError{Maximum: maximum}
+		}
+	}
+
+	return nil
+}
+
+// SubmitRequest creates the request and returns its id.
+func (c *Client) SubmitRequest(ctx context.Context, req *clientapi.CreateAccessRequestClientModel) (string, error) {
+	createResp, resp, err := c.api.ClientAPI.AccessRequestsAPI.CreateUserAccessRequest(ctx).
+		CreateAccessRequestClientModel(*req).
+		Execute()
+	if err != nil {
+		if resp != nil {
+			if apiError := utils.ReturnAPIResponseError(resp); apiError != nil {
+				return "", apiError
+			}
+		}
+
+		return "", err
+	}
+
+	if len(createResp.RequestIds) == 0 {
+		return "", fmt.Errorf("failed to create access request, no request IDs returned from the API")
+	}
+
+	return createResp.RequestIds[0], nil
+}
+
+// CreateRequest builds, validates and submits the request.
+func (c *Client) CreateRequest(ctx context.Context, b *RequestBuilder) (*clientapi.AccessRequestClientModel, error) {
+	req, err := c.BuildRequest(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.ValidateRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	requestID, err := c.SubmitRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetRequest(ctx, requestID)
+}
+
+func (c *Client) GetRequest(ctx context.Context, requestID string) (*clientapi.AccessRequestClientModel, error) {
+	return services.GetRequestByID(ctx, c.api, requestID)
+}
@@ -0,0 +1,33 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/services"
+)
+
+var (
+	ErrRevokeWaitTimeout = errors.New("timeout while waiting for request to be revoked")
+	ErrRevokeFailed      = errors.New("request failed to revoke")
+)
+
+// Revoke starts revoking the request. Use WaitForRevoke to wait until the
+// access is removed.
+func (c *Client) Revoke(ctx context.Context, requestID string) error {
+	return services.RevokeRequest(ctx, c.api, requestID)
+}
+
+// WaitForRevoke polls the request until it is revoked.
+func (c *Client) WaitForRevoke(ctx context.Context, requestID string, opts WaitOptions) (*clientapi.AccessRequestClientModel, error) {
+	return c.pollRequest(ctx, requestID, opts, ErrRevokeWaitTimeout, func(request *clientapi.AccessRequestClientModel) (bool, error) {
+		switch request.Status.Status {
+		case services.AccessRequestRevokedStatus:
+			return true, nil
+		case services.AccessRequestFailedStatus:
+			return true, ErrRevokeFailed
+		}
+		return false, nil
+	})
+}
@@ -0,0 +1,128 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/services"
+)
+
+// newTestClient serves the request AR-1 with the given statuses, one per
+// poll, repeating the last one.
+func newTestClient(t *testing.T, statuses ...string) *Client {
+	t.Helper()
+
+	var mu sync.Mutex
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/client/v1/access-requests/AR-1" || r.Header.Get("Authorization") != "Bearer test-token" {
+			http.NotFound(w, r)
+			return
+		}
+
+		mu.Lock()
+		status := statuses[min(polls, len(statuses)-1)]
+		polls++
+		mu.Unlock()
+
+		request := clientapi.AccessRequestClientModel{
+			Id:           "AR-1",
+			Requestor:    clientapi.UserClientModel{Name: "Jane", Email: "jane@example.com"},
+			CreationTime: 1700000000,
+			Status:       clientapi.RequestStatusClientModel{Status: status, Metadata: map[string]string{}},
+			AccessGroups: []clientapi.AccessGroupClientModel{},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(request)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(context.Background(), WithToken("test-token", server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestWaitForGrant(t *testing.T) {
+	client := newTestClient(t, services.AccessRequestPendingStatus, services.AccessRequestGrantingStatus, services.AccessRequestActiveStatus)
+
+	var progress []string
+	request, err := client.WaitForGrant(context.Background(), "AR-1", WaitOptions{
+		Timeout:      time.Second,
+		PollInterval: time.Millisecond,
+		OnProgress: func(request *clientapi.AccessRequestClientModel) {
+			progress = append(progress, request.Status.Status)
+		},
+	})
+	if err != nil {
+		t.Fatalf("WaitForGrant() error = %v", err)
+	}
+	if request.Status.Status != services.AccessRequestActiveStatus {
+		t.Errorf("status = %q, want %q", request.Status.Status, services.AccessRequestActiveStatus)
+	}
+	if len(progress) != 3 {
+		t.Errorf("OnProgress got %v, want every polled status", progress)
+	}
+}
+
+func TestWaitForGrantRejected(t *testing.T) {
+	client := newTestClient(t, services.AccessRequestRejectedStatus)
+
+	_, err := client.WaitForGrant(context.Background(), "AR-1", WaitOptions{Timeout: time.Second, PollInterval: time.Millisecond})
+	if !errors.Is(err, ErrRequestNotGranted) {
+		t.Errorf("WaitForGrant() error = %v, want %v", err, ErrRequestNotGranted)
+	}
+}
+
+func TestWaitForRevokeTimeout(t *testing.T) {
+	client := newTestClient(t, services.AccessRequestRevokingStatus)
+
+	request, err := client.WaitForRevoke(context.Background(), "AR-1", WaitOptions{Timeout: 10 * time.Millisecond, PollInterval: time.Millisecond})
+	if !errors.Is(err, ErrRevokeWaitTimeout) {
+		t.Errorf("WaitForRevoke() error = %v, want %v", err, ErrRevokeWaitTimeout)
+	}
+	if request == nil || request.Status.Status != services.AccessRequestRevokingStatus {
+		t.Errorf("WaitForRevoke() = %+v, want the last polled request", request)
+	}
+}
+
+func TestWaitWithoutTimeoutEndsWithContext(t *testing.T) {
+	client := newTestClient(t, services.AccessRequestGrantingStatus)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.WaitForGrant(ctx, "AR-1", WaitOptions{PollInterval: time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("WaitForGrant() error = %v, want the context error rather than %v", err, ErrWaitTimeout)
+	}
+}
+
+func TestBuildRequestValidation(t *testing.T) {
+	client := newTestClient(t, services.AccessRequestActiveStatus)
+
+	tests := []struct {
+		name    string
+		builder *RequestBuilder
+	}{
+		{name: "no target", builder: &RequestBuilder{}},
+		{name: "integration without resources", builder: IntegrationRequest("aws-account/prod", "aws-s3-bucket").WithPermissions("READ")},
+		{name: "integration and bundle", builder: &RequestBuilder{integration: "aws-account/prod", bundle: "admins"}},
+		{name: "non positive duration", builder: BundleRequest("admins").WithDuration(0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := client.BuildRequest(context.Background(), tt.builder); err == nil {
+				t.Error("BuildRequest() succeeded, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/services"
+)
+
+const DefaultPollInterval = 1 * time.Second
+
+var (
+	ErrWaitTimeout       = errors.New("timeout waiting for request to be granted")
+	ErrRequestNotGranted = errors.New("request was not granted")
+)
+
+// WaitOptions controls how long and how often a request is polled. A Timeout
+// of zero or less sets no deadline, and the wait then only ends with ctx.
+// OnProgress is called with every fetched state of the request.
+type WaitOptions struct {
+	Timeout      time.Duration
+	PollInterval time.Duration
+	OnProgress   func(request *clientapi.AccessRequestClientModel)
+}
+
+// IsRequestSettled reports whether the request needs nothing more from the
+// access flow: it is active, failed or rejected, or it waits for a human
+// approval or an MFA that polling cannot complete.
+func IsRequestSettled(request *clientapi.AccessRequestClientModel) bool {
+	switch request.Status.Status {
+	case services.AccessRequestActiveStatus, services.AccessRequestFailedStatus, services.AccessRequestRejectedStatus:
+		return true
+
+	case services.AccessRequestPendingStatus:
+		if services.IsRequestWaitingForHumanApproval(request) {
+			return true
+		}
+	case services.AccessRequestPendingMFAStatus:
+		return true
+	}
+
+	return false
+}
+
+// WaitForRequest polls the request until it settles, see IsRequestSettled.
+// On timeout it returns the last state of the request with ErrWaitTimeout.
+func (c *Client) WaitForRequest(ctx context.Context, requestID string, opts WaitOptions) (*clientapi.AccessRequestClientModel, error) {
+	return c.pollRequest(ctx, requestID, opts, ErrWaitTimeout, func(request *clientapi.AccessRequestClientModel) (bool, error) {
+		return IsRequestSettled(request), nil
+	})
+}
+
+// WaitForGrant polls the request until it is active, including while it
+// waits for approval. A request that ends in any other way returns
+// ErrRequestNotGranted.
+func (c *Client) WaitForGrant(ctx context.Context, requestID string, opts WaitOptions) (*clientapi.AccessRequestClientModel, error) {
+	return c.pollRequest(ctx, requestID, opts, ErrWaitTimeout, func(request *clientapi.AccessRequestClientModel) (bool, error) {
+		switch request.Status.Status {
+		case services.AccessRequestActiveStatus:
+			return true, nil
+		case services.AccessRequestFailedStatus, services.AccessRequestRejectedStatus, services.AccessRequestRevokingStatus, services.AccessRequestRevokedStatus:
+			return true, fmt.Errorf("%w, its status is %s", ErrRequestNotGranted, request.Status.Status)
+		}
+		return false, nil
+	})
+}
+
+func (c *Client) pollRequest(ctx context.Context, requestID string, opts WaitOptions, timeoutErr error, done func(*clientapi.AccessRequestClientModel) (bool, error)) (*clientapi.AccessRequestClientModel, error) {
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	var deadline time.Time
+	if opts.Timeout > 0 {
+		deadline = time.Now().Add(opts.Timeout)
+	}
+
+	for {
+		request, err := c.GetRequest(ctx, requestID)
+		if err != nil {
+			return nil, err
+		}
+
+		if opts.OnProgress != nil {
+			opts.OnProgress(request)
+		}
+
+		isDone, err := done(request)
+		if isDone {
+			return request, err
+		}
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return request, timeoutErr
+		}
+
+		select {
+		case <-ctx.Done():
+			return request, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
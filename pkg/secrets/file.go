@@ -0,0 +1,263 @@
+package secrets
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/apono-io/apono-cli/pkg/filelock"
+)
+
+const (
+	fileFormatVersion = 1
+	kdfPBKDF2         = "pbkdf2-sha256"
+	kdfKeyFile        = "key-file-sha256"
+	pbkdf2Iterations  = 600000
+	keyLength         = 32
+	saltLength        = 16
+
+	fileDirPerm  os.FileMode = 0o700
+	fileFilePerm os.FileMode = 0o600
+)
+
+var ErrWrongKey = errors.New("failed to decrypt the secrets file, check the passphrase or key file")
+
+// FileKey is what the encrypted file is unlocked with: a passphrase, which is
+// stretched with PBKDF2, or the contents of a key file.
+type FileKey struct {
+	Passphrase string
+	KeyFile    []byte
+}
+
+type encryptedFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations,omitempty"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// FileStore keeps secrets in a single AES-256-GCM encrypted file. The file
+// is read on every access, as other processes may change it, and changes are
+// made under a file lock and re-encrypted with a fresh nonce. The derived key
+// is kept for the process, so the passphrase is stretched only once.
+type FileStore struct {
+	path string
+	key  FileKey
+
+	mu      sync.Mutex
+	salt    []byte
+	derived []byte
+}
+
+func NewFileStore(path string, key FileKey) (*FileStore, error) {
+	if key.Passphrase == "" && len(key.KeyFile) == 0 {
+		return nil, fmt.Errorf("the file secrets backend needs a passphrase or a key file")
+	}
+	return &FileStore{path: path, key: key}, nil
+}
+
+func (s *FileStore) Get(key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	secrets, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	value, ok := secrets[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+func (s *FileStore) Set(key, value string) error {
+	return s.update(func(secrets map[string]string) bool {
+		secrets[key] = value
+		return true
+	})
+}
+
+func (s *FileStore) Delete(key string) error {
+	return s.update(func(secrets map[string]string) bool {
+		if _, ok := secrets[key]; !ok {
+			return false
+		}
+		delete(secrets, key)
+		return true
+	})
+}
+
+func (s *FileStore) update(fn func(secrets map[string]string) bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), fileDirPerm); err != nil {
+		return err
+	}
+	unlock, err := filelock.Lock(s.path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	secrets, err := s.load()
+	if err != nil {
+		return err
+	}
+	if !fn(secrets) {
+		return nil
+	}
+	return s.save(secrets)
+}
+
+func (s *FileStore) load() (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Clean(s.path))
+	if os.IsNotExist(err) {
+		return make(map[string]string), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read secrets file: %w", err)
+	}
+
+	var file encryptedFile
+	if err = json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse secrets file: %w", err)
+	}
+	if file.Version != fileFormatVersion {
+		return nil, fmt.Errorf("unsupported secrets file version %d", file.Version)
+	}
+
+	if s.derived == nil || !bytes.Equal(s.salt, file.Salt) {
+		derived, deriveErr := s.deriveKey(file.KDF, file.Salt, file.Iterations)
+		if deriveErr != nil {
+			return nil, deriveErr
+		}
+		s.salt, s.derived = file.Salt, derived
+	}
+
+	gcm, err := newGCM(s.derived)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrWrongKey
+	}
+
+	secrets := make(map[string]string)
+	if err = json.Unmarshal(plaintext, &secrets); err != nil {
+		return nil, fmt.Errorf("parse decrypted secrets: %w", err)
+	}
+	return secrets, nil
+}
+
+func (s *FileStore) save(secrets map[string]string) error {
+	kdf, iterations := s.kdf()
+	if s.derived == nil {
+		s.salt = make([]byte, saltLength)
+		if _, err := rand.Read(s.salt); err != nil {
+			return err
+		}
+		derived, err := s.deriveKey(kdf, s.salt, iterations)
+		if err != nil {
+			return err
+		}
+		s.derived = derived
+	}
+
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+	gcm, err := newGCM(s.derived)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(encryptedFile{
+		Version:    fileFormatVersion,
+		KDF:        kdf,
+		Iterations: iterations,
+		Salt:       s.salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, nil),
+	})
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(s.path, data)
+}
+
+func (s *FileStore) kdf() (string, int) {
+	if len(s.key.KeyFile) > 0 {
+		return kdfKeyFile, 0
+	}
+	return kdfPBKDF2, pbkdf2Iterations
+}
+
+func (s *FileStore) deriveKey(kdf string, salt []byte, iterations int) ([]byte, error) {
+	switch kdf {
+	case kdfKeyFile:
+		if len(s.key.KeyFile) == 0 {
+			return nil, fmt.Errorf("the secrets file is encrypted with a key file, set secrets.key_file")
+		}
+		sum := sha256.Sum256(append(append([]byte{}, salt...), s.key.KeyFile...))
+		return sum[:], nil
+	case kdfPBKDF2:
+		if s.key.Passphrase == "" {
+			return nil, fmt.Errorf("the secrets file is encrypted with a passphrase, set APONO_SECRETS_PASSPHRASE")
+		}
+		return pbkdf2.Key(sha256.New, s.key.Passphrase, salt, iterations, keyLength)
+	default:
+		return nil, fmt.Errorf("unsupported secrets file key derivation %q", kdf)
+	}
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), fileDirPerm); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), fileFilePerm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
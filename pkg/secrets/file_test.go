@@ -0,0 +1,104 @@
+package secrets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+	store, err := NewFileStore(path, FileKey{Passphrase: "correct horse"})
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+
+	if _, err = store.Get("missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get() of a missing key error = %v, want ErrNotFound", err)
+	}
+	if err = store.Set("profile/default/token", `{"access_token":"at"}`); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err = store.Set("session/s-1", "hunter2"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err = store.Delete("session/s-1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read secrets file: %v", err)
+	}
+	if strings.Contains(string(data), "access_token") {
+		t.Error("the secrets file holds the secret in plaintext")
+	}
+	if info, _ := os.Stat(path); info.Mode().Perm() != 0o600 {
+		t.Errorf("secrets file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	reopened, _ := NewFileStore(path, FileKey{Passphrase: "correct horse"})
+	if value, getErr := reopened.Get("profile/default/token"); getErr != nil || value != `{"access_token":"at"}` {
+		t.Errorf("Get() after reopening = %q, %v", value, getErr)
+	}
+	if _, getErr := reopened.Get("session/s-1"); !errors.Is(getErr, ErrNotFound) {
+		t.Errorf("Get() of a deleted key error = %v, want ErrNotFound", getErr)
+	}
+
+	wrong, _ := NewFileStore(path, FileKey{Passphrase: "battery staple"})
+	if _, err = wrong.Get("profile/default/token"); !errors.Is(err, ErrWrongKey) {
+		t.Errorf("Get() with a wrong passphrase error = %v, want ErrWrongKey", err)
+	}
+}
+
+func TestFileStoreKeyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+	store, _ := NewFileStore(path, FileKey{KeyFile: []byte("0123456789abcdef")})
+	if err := store.Set("vault/i-1", "creds"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	passphraseOnly, _ := NewFileStore(path, FileKey{Passphrase: "correct horse"})
+	if _, err := passphraseOnly.Get("vault/i-1"); err == nil || !strings.Contains(err.Error(), "key file") {
+		t.Errorf("Get() without the key file error = %v, want a hint about the key file", err)
+	}
+
+	reopened, _ := NewFileStore(path, FileKey{KeyFile: []byte("0123456789abcdef")})
+	if value, err := reopened.Get("vault/i-1"); err != nil || value != "creds" {
+		t.Errorf("Get() = %q, %v", value, err)
+	}
+}
+
+func TestNewFileStoreRequiresAKey(t *testing.T) {
+	if _, err := NewFileStore(filepath.Join(t.TempDir(), "secrets.enc"), FileKey{}); err == nil {
+		t.Error("NewFileStore() without a passphrase or key file should fail")
+	}
+}
+
+func TestFileStoreSeesChangesOfOtherStores(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+	first, _ := NewFileStore(path, FileKey{KeyFile: []byte("0123456789abcdef")})
+	second, _ := NewFileStore(path, FileKey{KeyFile: []byte("0123456789abcdef")})
+
+	if err := first.Set("profile/default/token", "one"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if _, err := second.Get("profile/default/token"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if err := first.Set("profile/default/token", "two"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := second.Set("profile/other/token", "three"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	if got, _ := second.Get("profile/default/token"); got != "two" {
+		t.Errorf("Get() = %q, want the value written by the other store", got)
+	}
+	if got, _ := first.Get("profile/other/token"); got != "three" {
+		t.Errorf("Get() = %q, want the value written by the other store", got)
+	}
+}
@@ -0,0 +1,162 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+const (
+	keyringService = "apono-cli"
+
+	secretToolBinary = "secret-tool"
+	securityBinary   = "security"
+
+	// security exits with 44 when the keychain item does not exist.
+	securityNotFoundExitCode = 44
+
+	// The config package depends on this one, so it cannot use the OS
+	// constants of the utils package.
+	darwinOS = "darwin"
+	linuxOS  = "linux"
+)
+
+// KeyringStore keeps secrets in the OS keyring: the Secret Service (GNOME
+// Keyring, KWallet) through libsecret's secret-tool on Linux, and the login
+// keychain through security on macOS.
+type KeyringStore struct {
+	goos string
+}
+
+func NewKeyringStore() *KeyringStore {
+	return &KeyringStore{goos: runtime.GOOS}
+}
+
+// KeyringAvailable reports whether the OS keyring can be used, so sessions
+// without a desktop keyring, such as SSH sessions on servers, can fall back.
+func KeyringAvailable() bool {
+	switch runtime.GOOS {
+	case darwinOS:
+		_, err := exec.LookPath(securityBinary)
+		return err == nil
+	case linuxOS:
+		if os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+			return false
+		}
+		_, err := exec.LookPath(secretToolBinary)
+		return err == nil
+	default:
+		return false
+	}
+}
+
+func (s *KeyringStore) Get(key string) (string, error) {
+	name, args := keyringGetCommand(s.goos, key)
+	out, errOut, err := runKeyringCommand(name, args, "")
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && keyringNotFound(s.goos, exitErr.ExitCode(), errOut) {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("read %s from keyring: %w", key, err)
+	}
+
+	value := strings.TrimSuffix(out, "\n")
+	if value == "" && s.goos == linuxOS {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+func (s *KeyringStore) Set(key, value string) error {
+	name, args, stdin := keyringSetCommand(s.goos, key, value)
+	_, errOut, err := runKeyringCommand(name, args, stdin)
+	if err == nil && s.goos == darwinOS && errOut != "" {
+		// security -i reports failed commands on stderr, not in its exit code.
+		err = errors.New(errOut)
+	}
+	if err != nil {
+		return fmt.Errorf("write %s to keyring: %w", key, err)
+	}
+	return nil
+}
+
+func (s *KeyringStore) Delete(key string) error {
+	name, args := keyringDeleteCommand(s.goos, key)
+	_, errOut, err := runKeyringCommand(name, args, "")
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && keyringNotFound(s.goos, exitErr.ExitCode(), errOut) {
+			return nil
+		}
+		return fmt.Errorf("delete %s from keyring: %w", key, err)
+	}
+	return nil
+}
+
+func keyringGetCommand(goos, key string) (string, []string) {
+	if goos == darwinOS {
+		return securityBinary, []string{"find-generic-password", "-s", keyringService, "-a", key, "-w"}
+	}
+	return secretToolBinary, []string{"lookup", "service", keyringService, "account", key}
+}
+
+// keyringSetCommand passes the secret on stdin, so it never shows up in the
+// process list. security only takes it as an argument, so the whole command
+// is written to the interactive mode of security instead.
+func keyringSetCommand(goos, key, value string) (string, []string, string) {
+	if goos == darwinOS {
+		command := []string{"add-generic-password", "-U", "-s", keyringService, "-a", key, "-w", value}
+		for i, arg := range command {
+			command[i] = securityQuote(arg)
+		}
+		return securityBinary, []string{"-i"}, strings.Join(command, " ") + "\n"
+	}
+	label := fmt.Sprintf("Apono CLI (%s)", key)
+	return secretToolBinary, []string{"store", "--label", label, "service", keyringService, "account", key}, value
+}
+
+// securityQuote quotes an argument for a command line of security -i, which
+// splits on spaces outside of double quotes and unescapes backslashes.
+func securityQuote(arg string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+}
+
+func keyringDeleteCommand(goos, key string) (string, []string) {
+	if goos == darwinOS {
+		return securityBinary, []string{"delete-generic-password", "-s", keyringService, "-a", key}
+	}
+	return secretToolBinary, []string{"clear", "service", keyringService, "account", key}
+}
+
+// keyringNotFound tells a missing item apart from a locked or unavailable
+// keyring. secret-tool exits with 1 for both, but only explains the latter
+// on stderr.
+func keyringNotFound(goos string, exitCode int, errOut string) bool {
+	if goos == darwinOS {
+		return exitCode == securityNotFoundExitCode
+	}
+	return exitCode == 1 && errOut == ""
+}
+
+// runKeyringCommand returns the stdout and the trimmed stderr of the command.
+func runKeyringCommand(name string, args []string, stdin string) (string, string, error) {
+	cmd := exec.CommandContext(context.Background(), name, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if stdin != "" {
+		cmd.Stdin = strings.NewReader(stdin)
+	}
+	err := cmd.Run()
+	errOut := strings.TrimSpace(stderr.String())
+	if err != nil && errOut != "" {
+		err = fmt.Errorf("%w: %s", err, errOut)
+	}
+	return stdout.String(), errOut, err
+}
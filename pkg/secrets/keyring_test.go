@@ -0,0 +1,49 @@
+package secrets
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKeyringSetCommand_linuxPassesTheSecretOnStdin(t *testing.T) {
+	name, args, stdin := keyringSetCommand(linuxOS, "session/s-1", "hunter2")
+	if name != secretToolBinary || stdin != "hunter2" {
+		t.Errorf("keyringSetCommand() = %s, stdin %q", name, stdin)
+	}
+	if strings.Contains(strings.Join(args, " "), "hunter2") {
+		t.Errorf("the secret must not be an argument, got %v", args)
+	}
+}
+
+func TestKeyringSetCommand_darwinPassesTheSecretOnStdin(t *testing.T) {
+	name, args, stdin := keyringSetCommand(darwinOS, "profile/default/token", `{"access_token":"a\\b"}`)
+	if name != securityBinary || strings.Join(args, " ") != "-i" {
+		t.Errorf("keyringSetCommand() = %s %v, want %s -i", name, args, securityBinary)
+	}
+
+	want := `"add-generic-password" "-U" "-s" "apono-cli" "-a" "profile/default/token" "-w" "{\"access_token\":\"a\\\\b\"}"` + "\n"
+	if stdin != want {
+		t.Errorf("keyringSetCommand() stdin = %q, want %q", stdin, want)
+	}
+}
+
+func TestKeyringNotFound(t *testing.T) {
+	tests := []struct {
+		goos     string
+		exitCode int
+		errOut   string
+		want     bool
+	}{
+		{goos: darwinOS, exitCode: securityNotFoundExitCode, want: true},
+		{goos: darwinOS, exitCode: 1, want: false},
+		{goos: linuxOS, exitCode: 1, want: true},
+		{goos: linuxOS, exitCode: 1, errOut: "Cannot autolaunch D-Bus without X11 $DISPLAY", want: false},
+		{goos: linuxOS, exitCode: 2, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := keyringNotFound(tt.goos, tt.exitCode, tt.errOut); got != tt.want {
+			t.Errorf("keyringNotFound(%s, %d, %q) = %v, want %v", tt.goos, tt.exitCode, tt.errOut, got, tt.want)
+		}
+	}
+}
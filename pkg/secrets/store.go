@@ -0,0 +1,39 @@
+package secrets
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	BackendKeyring   = "keyring"
+	BackendFile      = "file"
+	BackendPlaintext = "plaintext"
+)
+
+var ErrNotFound = errors.New("secret not found")
+
+// Store keeps secrets such as tokens and cached credentials out of the CLI
+// config and cache files.
+type Store interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+	Delete(key string) error
+}
+
+func ParseBackend(value string) (string, error) {
+	switch value {
+	case BackendKeyring, BackendFile, BackendPlaintext:
+		return value, nil
+	default:
+		return "", fmt.Errorf("invalid secrets backend %q, valid values are '%s', '%s' or '%s'", value, BackendKeyring, BackendFile, BackendPlaintext)
+	}
+}
+
+func VaultCredentialsKey(integrationID string) string {
+	return "vault/" + integrationID
+}
+
+func SessionPasswordKey(sessionID string) string {
+	return "session/" + sessionID
+}
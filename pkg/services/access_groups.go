@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+type AccessGroupDetails struct {
+	ID                  string                            `json:"id" yaml:"id"`
+	CanResetCredentials bool                              `json:"can_reset_credentials" yaml:"can_reset_credentials"`
+	Instructions        string                            `json:"instructions" yaml:"instructions"`
+	AccessUnits         []clientapi.AccessUnitClientModel `json:"access_units" yaml:"access_units"`
+}
+
+// ResolveAccessGroupID finds an access group of the active requests of the
+// user by its ID or by its integration, as <type>/<name> or just <name>. A
+// value that matches no group is used as an ID as is, so groups of other
+// requests can still be looked up.
+func ResolveAccessGroupID(ctx context.Context, client *aponoapi.AponoClient, idOrName string) (string, error) {
+	requests, err := utils.GetAllPages(ctx, client, func(ctx context.Context, client *aponoapi.AponoClient, skip int32) ([]clientapi.AccessRequestClientModel, *clientapi.PaginationClientInfoModel, error) {
+		resp, _, err := client.ClientAPI.AccessRequestsAPI.ListAccessRequests(ctx).
+			Scope(clientapi.ACCESSREQUESTSSCOPEMODEL_MY_REQUESTS).
+			Statuses([]string{AccessRequestActiveStatus}).
+			Skip(skip).
+			Execute()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return resp.Data, &resp.Pagination, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return findAccessGroupID(requests, idOrName)
+}
+
+func findAccessGroupID(requests []clientapi.AccessRequestClientModel, idOrName string) (string, error) {
+	var matches []string
+	for _, request := range requests {
+		for _, accessGroup := range request.AccessGroups {
+			if accessGroup.Id == idOrName {
+				return accessGroup.Id, nil
+			}
+			if strings.EqualFold(accessGroup.Integration.Name, idOrName) || strings.EqualFold(integrationFlagValue(&accessGroup.Integration), idOrName) {
+				matches = append(matches, accessGroup.Id)
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return idOrName, nil
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("%d access groups match %q, use one of the IDs: %s", len(matches), idOrName, strings.Join(matches, ", "))
+	}
+}
+
+func GetAccessGroupDetails(ctx context.Context, client *aponoapi.AponoClient, accessGroupID string) (*AccessGroupDetails, error) {
+	accessDetails, resp, err := client.ClientAPI.AccessGroupsAPI.GetAccessGroupDetails(ctx, accessGroupID).Execute()
+	if resp != nil {
+		if apiError := utils.ReturnAPIResponseError(resp); apiError != nil {
+			return nil, apiError
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access group %s: %w", accessGroupID, err)
+	}
+
+	accessUnits, err := listAccessGroupAccessUnits(ctx, client, accessGroupID)
+	if err != nil {
+		return nil, err
+	}
+	if accessUnits == nil {
+		accessUnits = []clientapi.AccessUnitClientModel{}
+	}
+
+	return &AccessGroupDetails{
+		ID:                  accessGroupID,
+		CanResetCredentials: accessDetails.CanResetCredentials,
+		Instructions:        accessDetails.Plain,
+		AccessUnits:         accessUnits,
+	}, nil
+}
+
+func PrintAccessGroupDetails(cmd *cobra.Command, details *AccessGroupDetails, format utils.Format) error {
+	if !format.IsTable() {
+		return utils.PrintObjects(cmd.OutOrStdout(), format, details, nil)
+	}
+
+	writer := cmd.OutOrStdout()
+	table := uitable.New()
+	table.AddRow("Access Group ID:", details.ID)
+	table.AddRow("Access Units:", len(details.AccessUnits))
+	table.AddRow("Can Reset Credentials:", details.CanResetCredentials)
+	if _, err := fmt.Fprintln(writer, table); err != nil {
+		return err
+	}
+
+	if len(details.AccessUnits) > 0 {
+		if err := printSection(writer, "Access Units", accessUnitsTable(details.AccessUnits)); err != nil {
+			return err
+		}
+	}
+
+	return printAccessGroupInstructions(writer, details.Instructions)
+}
+
+func ResetAccessGroupCredentials(ctx context.Context, client *aponoapi.AponoClient, accessGroupID string) (string, error) {
+	message, resp, err := client.ClientAPI.AccessGroupsAPI.ResetAccessGroupCredentials(ctx, accessGroupID).Execute()
+	if resp != nil {
+		if apiError := utils.ReturnAPIResponseError(resp); apiError != nil {
+			return "", apiError
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to reset credentials of access group %s: %w", accessGroupID, err)
+	}
+
+	return message.Message, nil
+}
+
+func SyncAccessSessions(ctx context.Context, client *aponoapi.AponoClient) (string, error) {
+	message, resp, err := client.ClientAPI.AccessSessionsAPI.SyncAvailableSessions(ctx).Execute()
+	if resp != nil {
+		if apiError := utils.ReturnAPIResponseError(resp); apiError != nil {
+			return "", apiError
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to sync access sessions: %w", err)
+	}
+
+	return message.Message, nil
+}
+
+func printAccessGroupInstructions(writer io.Writer, instructions string) error {
+	if instructions == "" {
+		return nil
+	}
+
+	_, err := fmt.Fprintf(writer, "\n%s\n%s\n", color.Bold.Sprint("Instructions"), instructions)
+	return err
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+)
+
+func TestFindAccessGroupID(t *testing.T) {
+	requests := []clientapi.AccessRequestClientModel{
+		{Id: "AR-1", AccessGroups: []clientapi.AccessGroupClientModel{
+			{Id: "ag-1", Integration: clientapi.IntegrationClientModel{Type: "postgresql", Name: "prod-db"}},
+			{Id: "ag-2", Integration: clientapi.IntegrationClientModel{Type: "aws-account", Name: "shared"}},
+		}},
+		{Id: "AR-2", AccessGroups: []clientapi.AccessGroupClientModel{
+			{Id: "ag-3", Integration: clientapi.IntegrationClientModel{Type: "mysql", Name: "shared"}},
+		}},
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "id", value: "ag-2", want: "ag-2"},
+		{name: "integration name", value: "PROD-DB", want: "ag-1"},
+		{name: "integration type and name", value: "mysql/shared", want: "ag-3"},
+		{name: "ambiguous integration name", value: "shared", wantErr: true},
+		{name: "unknown value is used as an id", value: "ag-9", want: "ag-9"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findAccessGroupID(requests, tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("findAccessGroupID() error = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("findAccessGroupID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
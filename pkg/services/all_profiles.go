@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/config"
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+// ProfileResult holds what a read command returned for one profile of an
+// --all-profiles run.
+type ProfileResult[T any] struct {
+	Profile config.ProfileName
+	Account string
+	Items   []T
+}
+
+// ListFromAllProfiles runs list for every configured profile concurrently.
+// Profiles that fail are reported to errOut and left out, so one expired
+// login does not hide the other accounts.
+func ListFromAllProfiles[T any](ctx context.Context, errOut io.Writer, list func(ctx context.Context, client *aponoapi.AponoClient) ([]T, error)) ([]ProfileResult[T], error) {
+	cfg, err := config.Get()
+	if err != nil {
+		return nil, err
+	}
+	names := config.ProfileNames(cfg)
+	if len(names) == 0 {
+		return nil, config.ErrNoProfiles
+	}
+
+	results := make([]ProfileResult[T], len(names))
+	errs := make([]error, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name config.ProfileName) {
+			defer wg.Done()
+
+			profileCtx := config.CreateProfileContext(ctx, string(name))
+			client, clientErr := aponoapi.CreateClient(profileCtx, string(name))
+			if clientErr != nil {
+				errs[i] = clientErr
+				return
+			}
+
+			items, listErr := list(profileCtx, client)
+			if listErr != nil {
+				errs[i] = listErr
+				return
+			}
+
+			results[i] = ProfileResult[T]{Profile: name, Account: profileAccount(name, cfg.Auth.Profiles[name]), Items: items}
+		}(i, name)
+	}
+	wg.Wait()
+
+	var succeeded []ProfileResult[T]
+	for i, name := range names {
+		if errs[i] != nil {
+			if aponoapi.IsInvalidGrant(errs[i]) {
+				errs[i] = aponoapi.ErrSessionExpired
+			}
+			errs[i] = fmt.Errorf("profile %s: %w", name, errs[i])
+			_, _ = fmt.Fprintln(errOut, "Warning:", errs[i])
+			continue
+		}
+		succeeded = append(succeeded, results[i])
+	}
+	if len(succeeded) == 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return succeeded, nil
+}
+
+func profileAccount(name config.ProfileName, session config.SessionConfig) string {
+	switch {
+	case session.AccountName != "":
+		return session.AccountName
+	case session.AccountID != "":
+		return session.AccountID
+	default:
+		return string(name)
+	}
+}
+
+// CountProfileResults returns the number of items of all profiles.
+func CountProfileResults[T any](results []ProfileResult[T]) int {
+	count := 0
+	for _, result := range results {
+		count += len(result.Items)
+	}
+	return count
+}
+
+func PrintProfilesAccessRequests(cmd *cobra.Command, results []ProfileResult[clientapi.AccessRequestClientModel], format utils.Format) error {
+	return printProfileResults(cmd, format, results, generateRequestsTable)
+}
+
+func PrintProfilesAccessSessions(cmd *cobra.Command, results []ProfileResult[clientapi.AccessSessionClientModel], format utils.Format) error {
+	return printProfileResults(cmd, format, results, generateSessionsTable)
+}
+
+func PrintProfilesIntegrations(cmd *cobra.Command, results []ProfileResult[clientapi.IntegrationClientModel], format utils.Format) error {
+	return printProfileResults(cmd, format, results, generateIntegrationsTable)
+}
+
+func PrintProfilesBundles(cmd *cobra.Command, results []ProfileResult[clientapi.BundleClientModel], format utils.Format) error {
+	return printProfileResults(cmd, format, results, generateBundlesTable)
+}
+
+func PrintProfilesResourceTypes(cmd *cobra.Command, results []ProfileResult[clientapi.ResourceTypeClientModel], format utils.Format) error {
+	return printProfileResults(cmd, format, results, generateResourceTypesTable)
+}
+
+func PrintProfilesResources(cmd *cobra.Command, results []ProfileResult[clientapi.ResourceClientModel], format utils.Format) error {
+	return printProfileResults(cmd, format, results, generateResourcesTable)
+}
+
+func PrintProfilesPermissions(cmd *cobra.Command, results []ProfileResult[clientapi.PermissionClientModel], format utils.Format) error {
+	return printProfileResults(cmd, format, results, generatePermissionsTable)
+}
+
+// printProfileResults merges the results into one table with a leading
+// ACCOUNT column. In the object formats each item gets profile and account
+// fields next to its own.
+func printProfileResults[T any](cmd *cobra.Command, format utils.Format, results []ProfileResult[T], generateTable func([]T) *utils.Table) error {
+	var items []T
+	var accounts []string
+	objects := make([]map[string]any, 0)
+	for _, result := range results {
+		for _, item := range result.Items {
+			object, err := profileResultObject(item, result)
+			if err != nil {
+				return err
+			}
+
+			items = append(items, item)
+			accounts = append(accounts, result.Account)
+			objects = append(objects, object)
+		}
+	}
+
+	table := generateTable(items)
+	table.PrependColumn(utils.Column("ACCOUNT"), accounts)
+
+	return utils.PrintObjects(cmd.OutOrStdout(), format, objects, table)
+}
+
+func profileResultObject[T any](item T, result ProfileResult[T]) (map[string]any, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return nil, err
+	}
+
+	object := make(map[string]any)
+	if err = json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	object["profile"] = result.Profile
+	object["account"] = result.Account
+	return object, nil
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+func TestPrintProfilesIntegrations(t *testing.T) {
+	results := []ProfileResult[clientapi.IntegrationClientModel]{
+		{Profile: "prod", Account: "Prod", Items: []clientapi.IntegrationClientModel{{Id: "1", Type: "aws-account", Name: "AWS"}}},
+		{Profile: "dev", Account: "Dev", Items: []clientapi.IntegrationClientModel{{Id: "2", Type: "postgresql", Name: "DB"}}},
+	}
+
+	out := new(bytes.Buffer)
+	cmd := &cobra.Command{}
+	cmd.SetOut(out)
+	if err := PrintProfilesIntegrations(cmd, results, utils.Format{Kind: utils.TableFormat}); err != nil {
+		t.Fatalf("PrintProfilesIntegrations() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "ACCOUNT") || !strings.HasPrefix(lines[1], "Prod") || !strings.HasPrefix(lines[2], "Dev") {
+		t.Errorf("table output = %q, want a leading ACCOUNT column", out.String())
+	}
+
+	out.Reset()
+	if err := PrintProfilesIntegrations(cmd, results, utils.Format{Kind: utils.JSONFormat}); err != nil {
+		t.Fatalf("PrintProfilesIntegrations() error = %v", err)
+	}
+	var objects []map[string]any
+	if err := json.Unmarshal(out.Bytes(), &objects); err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 2 || objects[1]["profile"] != "dev" || objects[1]["account"] != "Dev" || objects[1]["id"] != "2" {
+		t.Errorf("json output = %v, want the items with profile and account fields", objects)
+	}
+}
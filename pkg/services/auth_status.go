@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/config"
+)
+
+const (
+	AuthTypeOAuth         = "oauth"
+	AuthTypePersonalToken = "personal_token"
+)
+
+// AuthStatus describes the credentials the CLI would use, and whether the
+// server still accepts them.
+type AuthStatus struct {
+	Authenticated   bool       `json:"authenticated" yaml:"authenticated"`
+	Profile         string     `json:"profile,omitempty" yaml:"profile,omitempty"`
+	FromEnvironment bool       `json:"from_environment,omitempty" yaml:"from_environment,omitempty"`
+	AuthType        string     `json:"auth_type,omitempty" yaml:"auth_type,omitempty"`
+	APIURL          string     `json:"api_url,omitempty" yaml:"api_url,omitempty"`
+	AccountID       string     `json:"account_id,omitempty" yaml:"account_id,omitempty"`
+	AccountName     string     `json:"account_name,omitempty" yaml:"account_name,omitempty"`
+	UserID          string     `json:"user_id,omitempty" yaml:"user_id,omitempty"`
+	UserName        string     `json:"user_name,omitempty" yaml:"user_name,omitempty"`
+	UserEmail       string     `json:"user_email,omitempty" yaml:"user_email,omitempty"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty" yaml:"expires_at,omitempty"`
+	Refreshable     bool       `json:"refreshable,omitempty" yaml:"refreshable,omitempty"`
+	Scopes          []string   `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+	Error           string     `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+type tokenClaims struct {
+	Scopes []string `json:"scopes"`
+	Scope  string   `json:"scope"`
+	jwt.RegisteredClaims
+}
+
+// GetAuthStatus resolves the profile like every other command does, and
+// verifies its token by fetching the user session.
+func GetAuthStatus(ctx context.Context, profileName string) *AuthStatus {
+	fromEnvironment := os.Getenv(config.TokenEnvVar) != ""
+	status := &AuthStatus{
+		Profile:         string(config.ResolveActiveProfileName(config.ProfileName(profileName))),
+		FromEnvironment: fromEnvironment,
+	}
+
+	session, err := config.GetProfileByName(config.ProfileName(profileName))
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.fillFromSession(session)
+
+	client, err := aponoapi.CreateClient(ctx, profileName)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	userSession, _, err := client.ClientAPI.UserSessionAPI.GetUserSession(ctx).Execute()
+	if err != nil {
+		if aponoapi.IsInvalidGrant(err) {
+			err = aponoapi.ErrSessionExpired
+		}
+		status.Error = err.Error()
+		return status
+	}
+
+	status.Authenticated = true
+	status.AccountID = userSession.Account.Id
+	status.AccountName = userSession.Account.Name
+	status.UserID = userSession.User.Id
+	status.UserName = userSession.User.Name
+	status.UserEmail = userSession.User.Email
+
+	// The request may have refreshed the OAuth token, so report the new one.
+	if status.AuthType == AuthTypeOAuth {
+		if refreshed, loadErr := config.GetProfileByName(config.ProfileName(profileName)); loadErr == nil {
+			status.fillTokenClaims(refreshed)
+		}
+	}
+
+	return status
+}
+
+func (s *AuthStatus) fillFromSession(session *config.SessionConfig) {
+	s.AuthType = AuthTypeOAuth
+	if session.PersonalToken != "" {
+		s.AuthType = AuthTypePersonalToken
+	}
+	s.APIURL = session.ApiURL
+	s.AccountID = session.AccountID
+	s.AccountName = session.AccountName
+	s.UserID = session.UserID
+	s.UserName = session.UserName
+	s.UserEmail = session.UserEmail
+	s.fillTokenClaims(session)
+}
+
+// fillTokenClaims decodes the token without verifying it, as only the server
+// can tell whether it is valid. Personal tokens that are not JWTs have no
+// expiry or scopes to report.
+func (s *AuthStatus) fillTokenClaims(session *config.SessionConfig) {
+	token := session.PersonalToken
+	if s.AuthType == AuthTypeOAuth {
+		token = session.Token.AccessToken
+		s.Refreshable = session.Token.RefreshToken != ""
+		if !session.Token.Expiry.IsZero() {
+			expiry := session.Token.Expiry
+			s.ExpiresAt = &expiry
+		}
+	}
+
+	claims, err := decodeTokenClaims(token)
+	if err != nil {
+		return
+	}
+	if claims.ExpiresAt != nil {
+		expiry := claims.ExpiresAt.Time
+		s.ExpiresAt = &expiry
+	}
+	s.Scopes = claims.Scopes
+	if len(s.Scopes) == 0 && claims.Scope != "" {
+		s.Scopes = strings.Fields(claims.Scope)
+	}
+}
+
+func decodeTokenClaims(token string) (*tokenClaims, error) {
+	claims := new(tokenClaims)
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
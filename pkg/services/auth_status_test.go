@@ -0,0 +1,74 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+
+	"github.com/apono-io/apono-cli/pkg/config"
+)
+
+func TestAuthStatusTokenClaims(t *testing.T) {
+	expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+	signed := func(claims jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	tests := []struct {
+		name       string
+		session    config.SessionConfig
+		wantType   string
+		wantScopes []string
+		wantExpiry bool
+	}{
+		{
+			name: "oauth token with a scopes claim",
+			session: config.SessionConfig{Token: oauth2.Token{
+				AccessToken:  signed(jwt.MapClaims{"exp": expiry.Unix(), "scopes": []string{"end_user:mcp", "end_user:inventory:read"}}),
+				RefreshToken: "refresh",
+			}},
+			wantType:   AuthTypeOAuth,
+			wantScopes: []string{"end_user:mcp", "end_user:inventory:read"},
+			wantExpiry: true,
+		},
+		{
+			name:       "personal token with a scope claim",
+			session:    config.SessionConfig{PersonalToken: signed(jwt.MapClaims{"exp": expiry.Unix(), "scope": "a b"})},
+			wantType:   AuthTypePersonalToken,
+			wantScopes: []string{"a", "b"},
+			wantExpiry: true,
+		},
+		{
+			name:     "opaque personal token",
+			session:  config.SessionConfig{PersonalToken: "opaque"},
+			wantType: AuthTypePersonalToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := new(AuthStatus)
+			status.fillFromSession(&tt.session)
+
+			if status.AuthType != tt.wantType {
+				t.Errorf("AuthType = %q, want %q", status.AuthType, tt.wantType)
+			}
+			if !reflect.DeepEqual(status.Scopes, tt.wantScopes) {
+				t.Errorf("Scopes = %v, want %v", status.Scopes, tt.wantScopes)
+			}
+			if tt.wantExpiry && (status.ExpiresAt == nil || !status.ExpiresAt.Equal(expiry)) {
+				t.Errorf("ExpiresAt = %v, want %v", status.ExpiresAt, expiry)
+			}
+			if !tt.wantExpiry && status.ExpiresAt != nil {
+				t.Errorf("ExpiresAt = %v, want none", status.ExpiresAt)
+			}
+		})
+	}
+}
@@ -3,6 +3,10 @@ package services
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
 
 	"github.com/apono-io/apono-cli/pkg/utils"
 
@@ -40,3 +44,22 @@ func ListBundles(ctx context.Context, client *aponoapi.AponoClient, search strin
 		return resp.Data, &resp.Pagination, nil
 	})
 }
+
+func PrintBundles(cmd *cobra.Command, bundles []clientapi.BundleClientModel, format utils.Format) error {
+	return utils.PrintObjects(cmd.OutOrStdout(), format, bundles, generateBundlesTable(bundles))
+}
+
+func generateBundlesTable(bundles []clientapi.BundleClientModel) *utils.Table {
+	table := utils.NewTable(utils.Column("ID"), utils.Column("NAME"), utils.WideColumn("LABELS"))
+	for _, bundle := range bundles {
+		var labels []string
+		for key, value := range bundle.Labels {
+			labels = append(labels, key+"="+value)
+		}
+		sort.Strings(labels)
+
+		table.AddRow(bundle.Id, bundle.Name, strings.Join(labels, ", "))
+	}
+
+	return table
+}
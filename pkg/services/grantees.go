@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+// ErrGranteesNotAvailable is returned by ListGranteesPage when the user may
+// not list grantees, and so cannot request access for anyone else.
+var ErrGranteesNotAvailable = errors.New("grantees are not available")
+
+// ListGranteesPage returns the first limit grantees matching search, and
+// whether there are more.
+func ListGranteesPage(ctx context.Context, client *aponoapi.AponoClient, search string, limit int32) ([]clientapi.GranteeClientModel, bool, error) {
+	listGranteesRequest := client.ClientAPI.InventoryAPI.ListGrantees(ctx).Limit(limit)
+	if search != "" {
+		listGranteesRequest = listGranteesRequest.Search(search)
+	}
+
+	resp, httpResp, err := listGranteesRequest.Execute()
+	if err != nil {
+		if httpResp != nil && (httpResp.StatusCode == http.StatusForbidden || httpResp.StatusCode == http.StatusNotFound) {
+			return nil, false, fmt.Errorf("%w: %w", ErrGranteesNotAvailable, err)
+		}
+		return nil, false, err
+	}
+
+	hasMore := len(resp.Data) >= int(resp.Pagination.Limit)
+	if resp.Pagination.HasMore.IsSet() && resp.Pagination.HasMore.Get() != nil {
+		hasMore = *resp.Pagination.HasMore.Get()
+	}
+	return resp.Data, hasMore, nil
+}
+
+func ListGrantees(ctx context.Context, client *aponoapi.AponoClient, search string) ([]clientapi.GranteeClientModel, error) {
+	return utils.GetAllPages(ctx, client, func(ctx context.Context, client *aponoapi.AponoClient, skip int32) ([]clientapi.GranteeClientModel, *clientapi.PaginationClientInfoModel, error) {
+		listGranteesRequest := client.ClientAPI.InventoryAPI.ListGrantees(ctx).Skip(skip)
+		if search != "" {
+			listGranteesRequest = listGranteesRequest.Search(search)
+		}
+
+		resp, _, err := listGranteesRequest.Execute()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return resp.Data, &resp.Pagination, nil
+	})
+}
+
+// GetGranteeByIDOrEmail finds a grantee by its ID, its source ID (the email
+// for users) or its display name.
+func GetGranteeByIDOrEmail(ctx context.Context, client *aponoapi.AponoClient, idOrEmail string) (*clientapi.GranteeClientModel, error) {
+	search := idOrEmail
+	if utils.IsValidUUID(idOrEmail) {
+		search = ""
+	}
+
+	grantees, err := ListGrantees(ctx, client, search)
+	if err != nil {
+		return nil, err
+	}
+
+	if grantee := findGrantee(grantees, idOrEmail); grantee != nil {
+		return grantee, nil
+	}
+
+	return nil, fmt.Errorf("grantee %s not found", idOrEmail)
+}
+
+// GranteeFlagValue returns the value to pass to --grantee to select the
+// grantee again.
+func GranteeFlagValue(grantee *clientapi.GranteeClientModel) string {
+	if grantee.SourceId != "" {
+		return grantee.SourceId
+	}
+
+	return grantee.Id
+}
+
+func GranteeLabel(grantee *clientapi.GranteeClientModel) string {
+	if grantee.SourceId != "" && grantee.SourceId != grantee.DisplayName {
+		return fmt.Sprintf("%s <%s> (%s)", grantee.DisplayName, grantee.SourceId, grantee.Type)
+	}
+
+	return fmt.Sprintf("%s (%s)", grantee.DisplayName, grantee.Type)
+}
+
+func findGrantee(grantees []clientapi.GranteeClientModel, idOrEmail string) *clientapi.GranteeClientModel {
+	for i := range grantees {
+		grantee := &grantees[i]
+		if grantee.Id == idOrEmail ||
+			strings.EqualFold(grantee.SourceId, idOrEmail) ||
+			strings.EqualFold(grantee.DisplayName, idOrEmail) {
+			return grantee
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+)
+
+func TestFindGrantee(t *testing.T) {
+	grantees := []clientapi.GranteeClientModel{
+		{Id: "u-1", SourceId: "jane@example.com", DisplayName: "Jane Doe", Type: "user"},
+		{Id: "g-1", SourceId: "", DisplayName: "Platform Team", Type: "group"},
+	}
+
+	tests := map[string]string{
+		"u-1":              "u-1",
+		"JANE@example.com": "u-1",
+		"platform team":    "g-1",
+	}
+	for value, wantID := range tests {
+		grantee := findGrantee(grantees, value)
+		if grantee == nil || grantee.Id != wantID {
+			t.Errorf("findGrantee(%q) = %v, want %s", value, grantee, wantID)
+		}
+	}
+
+	if grantee := findGrantee(grantees, "john@example.com"); grantee != nil {
+		t.Errorf("findGrantee() = %v, want nil", grantee)
+	}
+
+	if value := GranteeFlagValue(&grantees[1]); value != "g-1" {
+		t.Errorf("GranteeFlagValue() = %q, want the id when there is no source id", value)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/spf13/cobra"
+
 	"github.com/apono-io/apono-cli/pkg/utils"
 
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
@@ -161,3 +163,55 @@ func ListResourcesBySourceIDs(ctx context.Context, client *aponoapi.AponoClient,
 
 	return resourcesInOrder, nil
 }
+
+func PrintIntegrations(cmd *cobra.Command, integrations []clientapi.IntegrationClientModel, format utils.Format) error {
+	return utils.PrintObjects(cmd.OutOrStdout(), format, integrations, generateIntegrationsTable(integrations))
+}
+
+func generateIntegrationsTable(integrations []clientapi.IntegrationClientModel) *utils.Table {
+	table := utils.NewTable(utils.Column("ID"), utils.Column("TYPE"), utils.Column("NAME"), utils.WideColumn("TYPE DISPLAY NAME"))
+	for _, integration := range integrations {
+		table.AddRow(integration.Id, integration.Type, integration.Name, integration.TypeDisplayName)
+	}
+
+	return table
+}
+
+func PrintResourceTypes(cmd *cobra.Command, resourceTypes []clientapi.ResourceTypeClientModel, format utils.Format) error {
+	return utils.PrintObjects(cmd.OutOrStdout(), format, resourceTypes, generateResourceTypesTable(resourceTypes))
+}
+
+func generateResourceTypesTable(resourceTypes []clientapi.ResourceTypeClientModel) *utils.Table {
+	table := utils.NewTable(utils.Column("ID"), utils.Column("NAME"), utils.WideColumn("DISPLAY PATH"), utils.WideColumn("MULTIPLE PERMISSIONS"))
+	for _, resourceType := range resourceTypes {
+		table.AddRow(resourceType.Id, resourceType.Name, resourceType.DisplayPath, resourceType.AllowMultiplePermissions)
+	}
+
+	return table
+}
+
+func PrintResources(cmd *cobra.Command, resources []clientapi.ResourceClientModel, format utils.Format) error {
+	return utils.PrintObjects(cmd.OutOrStdout(), format, resources, generateResourcesTable(resources))
+}
+
+func generateResourcesTable(resources []clientapi.ResourceClientModel) *utils.Table {
+	table := utils.NewTable(utils.Column("ID"), utils.Column("NAME"), utils.WideColumn("PATH"), utils.WideColumn("TYPE"), utils.WideColumn("INTEGRATION"))
+	for _, resource := range resources {
+		table.AddRow(resource.SourceId, resource.Name, resource.Path, resource.Type.Id, resource.Integration.Name)
+	}
+
+	return table
+}
+
+func PrintPermissions(cmd *cobra.Command, permissions []clientapi.PermissionClientModel, format utils.Format) error {
+	return utils.PrintObjects(cmd.OutOrStdout(), format, permissions, generatePermissionsTable(permissions))
+}
+
+func generatePermissionsTable(permissions []clientapi.PermissionClientModel) *utils.Table {
+	table := utils.NewTable(utils.Column("ID"), utils.Column("NAME"))
+	for _, permission := range permissions {
+		table.AddRow(permission.Id, permission.Name)
+	}
+
+	return table
+}
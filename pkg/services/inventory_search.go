@@ -0,0 +1,552 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+)
+
+const (
+	InventoryBundleKind       = "bundle"
+	InventoryIntegrationKind  = "integration"
+	InventoryResourceTypeKind = "resource-type"
+	InventoryResourceKind     = "resource"
+	InventoryPermissionKind   = "permission"
+
+	inventorySearchResourcesLimit   = 200
+	inventorySearchPermissionTypes  = 5
+	inventorySearchConcurrency      = 8
+	inventorySearchRequestedBoost   = 10
+	inventorySearchRequestedLimit   = 100
+	inventorySearchResourceFilterID = "resource"
+)
+
+var InventorySearchKinds = []string{
+	InventoryBundleKind,
+	InventoryIntegrationKind,
+	InventoryResourceTypeKind,
+	InventoryResourceKind,
+	InventoryPermissionKind,
+}
+
+// InventorySearchHit is a requestable item matching a search. The IDs are
+// the values the request builder and the create command expect.
+type InventorySearchHit struct {
+	Kind           string   `json:"kind" yaml:"kind"`
+	Name           string   `json:"name" yaml:"name"`
+	Location       string   `json:"location" yaml:"location"`
+	Score          int      `json:"score" yaml:"score"`
+	Command        string   `json:"command" yaml:"command"`
+	BundleID       string   `json:"bundle_id,omitempty" yaml:"bundle_id,omitempty"`
+	IntegrationID  string   `json:"integration_id,omitempty" yaml:"integration_id,omitempty"`
+	Integration    string   `json:"integration,omitempty" yaml:"integration,omitempty"`
+	ResourceTypeID string   `json:"resource_type_id,omitempty" yaml:"resource_type_id,omitempty"`
+	ResourceID     string   `json:"resource_id,omitempty" yaml:"resource_id,omitempty"`
+	PermissionIDs  []string `json:"permission_ids,omitempty" yaml:"permission_ids,omitempty"`
+}
+
+type InventorySearchOptions struct {
+	Kinds []string
+	Limit int
+}
+
+func (o InventorySearchOptions) includes(kind string) bool {
+	if len(o.Kinds) == 0 {
+		return true
+	}
+
+	for _, k := range o.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+type inventorySearch struct {
+	ctx                   context.Context
+	client                *aponoapi.AponoClient
+	query                 string
+	opts                  InventorySearchOptions
+	requestedIntegrations map[string]bool
+	requestedResources    map[string]bool
+	permissionsByType     map[string][]clientapi.PermissionClientModel
+	hits                  []InventorySearchHit
+	integrations          []clientapi.IntegrationClientModel
+	matchedResourceTypes  []matchedResourceType
+}
+
+type matchedResourceType struct {
+	integrationID  string
+	resourceTypeID string
+	score          int
+}
+
+// SearchInventory searches bundles, integrations, resource types, resources
+// and permissions for the query, and returns the best matches first. Items
+// the user requested before are ranked higher.
+func SearchInventory(ctx context.Context, client *aponoapi.AponoClient, query string, opts InventorySearchOptions) ([]InventorySearchHit, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("search text must not be empty")
+	}
+
+	search := &inventorySearch{
+		ctx:                   ctx,
+		client:                client,
+		query:                 query,
+		opts:                  opts,
+		requestedIntegrations: listRequestedIntegrationIDs(ctx, client),
+		requestedResources:    listRequestedResourceIDs(ctx, client, query),
+		permissionsByType:     make(map[string][]clientapi.PermissionClientModel),
+	}
+
+	steps := []func() error{
+		search.searchBundles,
+		search.searchIntegrations,
+		search.searchResourceTypes,
+		search.searchResources,
+		search.searchPermissions,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+
+	hits := RankInventorySearchHits(search.hits, opts.Limit)
+	for i := range hits {
+		if hits[i].Kind == InventoryResourceKind {
+			search.fillSinglePermission(&hits[i])
+		}
+		hits[i].Command = InventorySearchHitCommand(&hits[i])
+	}
+
+	return hits, nil
+}
+
+// RankInventorySearchHits sorts the hits by score, then by kind and name, and
+// keeps the first limit hits. A limit of 0 keeps all of them.
+func RankInventorySearchHits(hits []InventorySearchHit, limit int) []InventorySearchHit {
+	kindOrder := make(map[string]int)
+	for i, kind := range InventorySearchKinds {
+		kindOrder[kind] = i
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if hits[i].Kind != hits[j].Kind {
+			return kindOrder[hits[i].Kind] < kindOrder[hits[j].Kind]
+		}
+		return hits[i].Name < hits[j].Name
+	})
+
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	return hits
+}
+
+// InventorySearchHitCommand returns the 'apono requests create' command for
+// the hit. Hits that do not pin down resources and permissions get
+// --interactive, so the missing parts are asked for.
+func InventorySearchHitCommand(hit *InventorySearchHit) string {
+	switch {
+	case hit.Kind == InventoryBundleKind:
+		return CreateBundleRequestCommand(hit.Name)
+	case hit.ResourceTypeID == "":
+		return fmt.Sprintf("apono requests create --integration \"%s\" --interactive", hit.Integration)
+	}
+
+	var resourceIDs []string
+	if hit.ResourceID != "" {
+		resourceIDs = []string{hit.ResourceID}
+	}
+
+	command := CreateIntegrationRequestCommand(hit.Integration, hit.ResourceTypeID, resourceIDs, hit.PermissionIDs)
+	if len(resourceIDs) == 0 || len(hit.PermissionIDs) == 0 {
+		command += " --interactive"
+	}
+
+	return command
+}
+
+// FuzzyScore rates how well candidate matches query, from 0 (no match) to
+// 100 (exact match). Every word of a multi-word query has to match.
+func FuzzyScore(query, candidate string) int {
+	query = strings.ToLower(strings.TrimSpace(query))
+	candidate = strings.ToLower(candidate)
+	if query == "" || candidate == "" {
+		return 0
+	}
+
+	if score := termScore(query, candidate); score > 0 {
+		return score
+	}
+
+	terms := strings.Fields(query)
+	if len(terms) < 2 {
+		return 0
+	}
+
+	lowest := 100
+	for _, term := range terms {
+		score := termScore(term, candidate)
+		if score == 0 {
+			return 0
+		}
+		lowest = min(lowest, score)
+	}
+
+	return lowest * 4 / 5
+}
+
+func termScore(term, candidate string) int {
+	switch {
+	case candidate == term:
+		return 100
+	case strings.HasPrefix(candidate, term):
+		return 80
+	case hasWordPrefix(candidate, term):
+		return 65
+	case strings.Contains(candidate, term):
+		return 50
+	case isSubsequence(term, candidate):
+		return 20
+	default:
+		return 0
+	}
+}
+
+func hasWordPrefix(candidate, term string) bool {
+	words := strings.FieldsFunc(candidate, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '_' || r == '/' || r == '.' || r == ':'
+	})
+	for _, word := range words {
+		if strings.HasPrefix(word, term) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSubsequence(term, candidate string) bool {
+	remaining := []rune(term)
+	for _, r := range candidate {
+		if len(remaining) == 0 {
+			break
+		}
+		if r == remaining[0] {
+			remaining = remaining[1:]
+		}
+	}
+
+	return len(remaining) == 0
+}
+
+func bestFuzzyScore(query string, candidates ...string) int {
+	best := 0
+	for _, candidate := range candidates {
+		best = max(best, FuzzyScore(query, candidate))
+	}
+
+	return best
+}
+
+func (s *inventorySearch) addHit(hit InventorySearchHit, requested bool) {
+	if hit.Score == 0 {
+		return
+	}
+	if requested {
+		hit.Score += inventorySearchRequestedBoost
+	}
+
+	s.hits = append(s.hits, hit)
+}
+
+func (s *inventorySearch) searchBundles() error {
+	if !s.opts.includes(InventoryBundleKind) {
+		return nil
+	}
+
+	bundles, err := ListBundles(s.ctx, s.client, "")
+	if err != nil {
+		return err
+	}
+
+	for _, bundle := range bundles {
+		s.addHit(InventorySearchHit{
+			Kind:     InventoryBundleKind,
+			Name:     bundle.Name,
+			Location: "bundle",
+			Score:    FuzzyScore(s.query, bundle.Name),
+			BundleID: bundle.Id,
+		}, false)
+	}
+
+	return nil
+}
+
+func (s *inventorySearch) searchIntegrations() error {
+	integrations, err := ListIntegrations(s.ctx, s.client)
+	if err != nil {
+		return err
+	}
+	s.integrations = integrations
+
+	if !s.opts.includes(InventoryIntegrationKind) {
+		return nil
+	}
+
+	for _, integration := range integrations {
+		s.addHit(InventorySearchHit{
+			Kind:          InventoryIntegrationKind,
+			Name:          integration.Name,
+			Location:      integration.TypeDisplayName,
+			Score:         bestFuzzyScore(s.query, integration.Name, integrationFlagValue(&integration), integration.TypeDisplayName),
+			IntegrationID: integration.Id,
+			Integration:   integrationFlagValue(&integration),
+		}, s.requestedIntegrations[integration.Id])
+	}
+
+	return nil
+}
+
+// searchResourceTypes takes a request per integration, as the client API has
+// no search across the resource types of all integrations. At most
+// inventorySearchConcurrency of them run at once.
+func (s *inventorySearch) searchResourceTypes() error {
+	if !s.opts.includes(InventoryResourceTypeKind) && !s.opts.includes(InventoryPermissionKind) {
+		return nil
+	}
+
+	resourceTypesByIntegration, err := s.listResourceTypes()
+	if err != nil {
+		return err
+	}
+
+	for i, integration := range s.integrations {
+		for _, resourceType := range resourceTypesByIntegration[i] {
+			score := bestFuzzyScore(s.query, resourceType.Name, resourceType.Id, resourceType.DisplayPath)
+			if score == 0 {
+				continue
+			}
+			if s.requestedIntegrations[integration.Id] {
+				score += inventorySearchRequestedBoost
+			}
+
+			s.matchedResourceTypes = append(s.matchedResourceTypes, matchedResourceType{
+				integrationID:  integration.Id,
+				resourceTypeID: resourceType.Id,
+				score:          score,
+			})
+			if !s.opts.includes(InventoryResourceTypeKind) {
+				continue
+			}
+
+			s.addHit(InventorySearchHit{
+				Kind:           InventoryResourceTypeKind,
+				Name:           resourceType.Name,
+				Location:       integrationFlagValue(&integration),
+				Score:          score,
+				IntegrationID:  integration.Id,
+				Integration:    integrationFlagValue(&integration),
+				ResourceTypeID: resourceType.Id,
+			}, s.requestedIntegrations[integration.Id])
+		}
+	}
+
+	return nil
+}
+
+// listResourceTypes returns the resource types of each integration, in the
+// order of the integrations.
+func (s *inventorySearch) listResourceTypes() ([][]clientapi.ResourceTypeClientModel, error) {
+	results := make([][]clientapi.ResourceTypeClientModel, len(s.integrations))
+	errs := make([]error, len(s.integrations))
+	sem := make(chan struct{}, inventorySearchConcurrency)
+	var wg sync.WaitGroup
+	for i := range s.integrations {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i], errs[i] = ListResourceTypes(s.ctx, s.client, s.integrations[i].Id)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+func (s *inventorySearch) searchResources() error {
+	if !s.opts.includes(InventoryResourceKind) {
+		return nil
+	}
+
+	resp, _, err := s.client.ClientAPI.InventoryAPI.ListResources(s.ctx).
+		Search(s.query).
+		Limit(inventorySearchResourcesLimit).
+		Execute()
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range resp.Data {
+		integration := integrationFlagValue(&resource.Integration)
+		s.addHit(InventorySearchHit{
+			Kind:           InventoryResourceKind,
+			Name:           resource.Name,
+			Location:       fmt.Sprintf("%s > %s", integration, resource.Type.Name),
+			Score:          max(bestFuzzyScore(s.query, resource.Name, resource.SourceId, resource.Path), 1),
+			IntegrationID:  resource.Integration.Id,
+			Integration:    integration,
+			ResourceTypeID: resource.Type.Id,
+			ResourceID:     resource.SourceId,
+		}, s.requestedResources[resource.Id] || s.requestedIntegrations[resource.Integration.Id])
+	}
+
+	return nil
+}
+
+// searchPermissions only looks at the permissions of the best matching
+// resource types, listing the permissions of every resource type would take
+// a request per resource type.
+func (s *inventorySearch) searchPermissions() error {
+	if !s.opts.includes(InventoryPermissionKind) {
+		return nil
+	}
+
+	integrationsByID := make(map[string]*clientapi.IntegrationClientModel)
+	for i := range s.integrations {
+		integrationsByID[s.integrations[i].Id] = &s.integrations[i]
+	}
+
+	for _, matched := range bestResourceTypes(s.matchedResourceTypes, inventorySearchPermissionTypes) {
+		integrationID, resourceTypeID := matched.integrationID, matched.resourceTypeID
+		permissions, err := s.listPermissions(integrationID, resourceTypeID)
+		if err != nil {
+			return err
+		}
+
+		integration := integrationFlagValue(integrationsByID[integrationID])
+		for _, permission := range permissions {
+			s.addHit(InventorySearchHit{
+				Kind:           InventoryPermissionKind,
+				Name:           permission.Name,
+				Location:       fmt.Sprintf("%s > %s", integration, resourceTypeID),
+				Score:          FuzzyScore(s.query, permission.Name),
+				IntegrationID:  integrationID,
+				Integration:    integration,
+				ResourceTypeID: resourceTypeID,
+				PermissionIDs:  []string{permission.Id},
+			}, s.requestedIntegrations[integrationID])
+		}
+	}
+
+	return nil
+}
+
+// bestResourceTypes returns the limit resource types with the highest score,
+// keeping the order of the integrations between equal scores.
+func bestResourceTypes(matched []matchedResourceType, limit int) []matchedResourceType {
+	best := make([]matchedResourceType, len(matched))
+	copy(best, matched)
+	sort.SliceStable(best, func(i, j int) bool {
+		return best[i].score > best[j].score
+	})
+
+	if len(best) > limit {
+		best = best[:limit]
+	}
+
+	return best
+}
+
+func (s *inventorySearch) fillSinglePermission(hit *InventorySearchHit) {
+	permissions, err := s.listPermissions(hit.IntegrationID, hit.ResourceTypeID)
+	if err != nil || len(permissions) != 1 {
+		return
+	}
+
+	hit.PermissionIDs = []string{permissions[0].Id}
+}
+
+func (s *inventorySearch) listPermissions(integrationID, resourceTypeID string) ([]clientapi.PermissionClientModel, error) {
+	key := integrationID + "/" + resourceTypeID
+	if permissions, ok := s.permissionsByType[key]; ok {
+		return permissions, nil
+	}
+
+	permissions, err := ListPermissions(s.ctx, s.client, integrationID, resourceTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.permissionsByType[key] = permissions
+	return permissions, nil
+}
+
+// listRequestedIntegrationIDs returns the integrations the user requested
+// access to before. It is only used for ranking, so errors are ignored.
+func listRequestedIntegrationIDs(ctx context.Context, client *aponoapi.AponoClient) map[string]bool {
+	result := make(map[string]bool)
+	resp, _, err := client.ClientAPI.FiltersAPI.ListIntegrationFilterOptions(ctx).
+		Scope(string(clientapi.ACCESSREQUESTSSCOPEMODEL_MY_REQUESTS)).
+		Limit(inventorySearchRequestedLimit).
+		Execute()
+	if err != nil {
+		return result
+	}
+
+	for _, integration := range resp.Data {
+		result[integration.Id] = true
+	}
+
+	return result
+}
+
+func listRequestedResourceIDs(ctx context.Context, client *aponoapi.AponoClient, query string) map[string]bool {
+	result := make(map[string]bool)
+	resp, _, err := client.ClientAPI.FiltersAPI.ListFilterOptions(ctx).
+		Key(inventorySearchResourceFilterID).
+		Scope(clientapi.ACCESSREQUESTSSCOPEMODEL_MY_REQUESTS).
+		Search(query).
+		Limit(inventorySearchRequestedLimit).
+		Execute()
+	if err != nil {
+		return result
+	}
+
+	for _, option := range resp.Data {
+		result[option.Id] = true
+	}
+
+	return result
+}
+
+func integrationFlagValue(integration *clientapi.IntegrationClientModel) string {
+	if integration == nil {
+		return ""
+	}
+
+	return integration.Type + integrationNameSeparator + integration.Name
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"testing"
+)
+
+func TestFuzzyScore(t *testing.T) {
+	tests := []struct {
+		query     string
+		candidate string
+		want      int
+	}{
+		{"prod-db", "prod-db", 100},
+		{"prod", "Prod-DB", 80},
+		{"db", "prod-db", 65},
+		{"od-d", "prod-db", 50},
+		{"pdb", "prod-db", 20},
+		{"billing prod", "prod-billing", 52},
+		{"xyz", "prod-db", 0},
+		{"", "prod-db", 0},
+	}
+
+	for _, tt := range tests {
+		if got := FuzzyScore(tt.query, tt.candidate); got != tt.want {
+			t.Errorf("FuzzyScore(%q, %q) = %d, want %d", tt.query, tt.candidate, got, tt.want)
+		}
+	}
+}
+
+func TestRankInventorySearchHits(t *testing.T) {
+	hits := []InventorySearchHit{
+		{Kind: InventoryResourceKind, Name: "b", Score: 50},
+		{Kind: InventoryBundleKind, Name: "c", Score: 50},
+		{Kind: InventoryResourceKind, Name: "a", Score: 50},
+		{Kind: InventoryPermissionKind, Name: "d", Score: 90},
+	}
+
+	ranked := RankInventorySearchHits(hits, 3)
+	want := []string{"d", "c", "a"}
+	if len(ranked) != len(want) {
+		t.Fatalf("RankInventorySearchHits() returned %d hits, want %d", len(ranked), len(want))
+	}
+	for i, name := range want {
+		if ranked[i].Name != name {
+			t.Errorf("ranked[%d] = %s, want %s", i, ranked[i].Name, name)
+		}
+	}
+}
+
+func TestInventorySearchHitCommand(t *testing.T) {
+	tests := []struct {
+		hit  InventorySearchHit
+		want string
+	}{
+		{
+			hit:  InventorySearchHit{Kind: InventoryBundleKind, Name: "Dev Access"},
+			want: `apono requests create --bundle "Dev Access"`,
+		},
+		{
+			hit:  InventorySearchHit{Kind: InventoryIntegrationKind, Integration: "postgresql/Prod"},
+			want: `apono requests create --integration "postgresql/Prod" --interactive`,
+		},
+		{
+			hit:  InventorySearchHit{Kind: InventoryResourceKind, Integration: "postgresql/Prod", ResourceTypeID: "database", ResourceID: "orders"},
+			want: `apono requests create --integration "postgresql/Prod" --resource-type "database" --resources "orders" --interactive`,
+		},
+		{
+			hit:  InventorySearchHit{Kind: InventoryResourceKind, Integration: "postgresql/Prod", ResourceTypeID: "database", ResourceID: "orders", PermissionIDs: []string{"read"}},
+			want: `apono requests create --integration "postgresql/Prod" --resource-type "database" --permissions "read" --resources "orders"`,
+		},
+	}
+
+	for _, tt := range tests {
+		if got := InventorySearchHitCommand(&tt.hit); got != tt.want {
+			t.Errorf("InventorySearchHitCommand() = %s, want %s", got, tt.want)
+		}
+	}
+}
+
+func TestBestResourceTypes(t *testing.T) {
+	matched := []matchedResourceType{
+		{integrationID: "a", resourceTypeID: "subsequence", score: 20},
+		{integrationID: "a", resourceTypeID: "contains", score: 50},
+		{integrationID: "b", resourceTypeID: "exact", score: 100},
+		{integrationID: "c", resourceTypeID: "contains", score: 50},
+	}
+
+	best := bestResourceTypes(matched, 3)
+	want := []string{"b/exact", "a/contains", "c/contains"}
+	if len(best) != len(want) {
+		t.Fatalf("bestResourceTypes() returned %d resource types, want %d", len(best), len(want))
+	}
+	for i, key := range want {
+		if got := best[i].integrationID + "/" + best[i].resourceTypeID; got != key {
+			t.Errorf("best[%d] = %s, want %s", i, got, key)
+		}
+	}
+	if matched[0].resourceTypeID != "subsequence" {
+		t.Error("bestResourceTypes() reordered its input")
+	}
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/config"
+	"github.com/apono-io/apono-cli/pkg/filelock"
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+const (
+	promptStateFileName                = "prompt-state.json"
+	promptStateDirPerm     os.FileMode = 0o700
+	promptStateFilePerm    os.FileMode = 0o600
+	promptStateAccountless             = "-"
+)
+
+// PromptState is what `apono prompt` knows about an account without calling
+// the API. Commands that already fetch sessions or requests refresh it.
+type PromptState struct {
+	ActiveSessions    int        `json:"active_sessions"`
+	SessionsUpdatedAt *time.Time `json:"sessions_updated_at,omitempty"`
+	PendingRequests   int        `json:"pending_requests"`
+	NextExpiry        *time.Time `json:"next_expiry,omitempty"`
+	RequestsUpdatedAt *time.Time `json:"requests_updated_at,omitempty"`
+}
+
+type promptStateFile struct {
+	Accounts map[string]PromptState `json:"accounts"`
+}
+
+func PromptStatePath() string {
+	return filepath.Join(utils.DefaultCacheDir(), promptStateFileName)
+}
+
+// ReadPromptState returns the cached state of the account, or an empty state
+// when nothing was cached yet.
+func ReadPromptState(path, accountID string) (PromptState, error) {
+	state, err := readPromptStateFile(path)
+	if err != nil {
+		return PromptState{}, err
+	}
+	return state.Accounts[promptStateKey(accountID)], nil
+}
+
+// RecordAccessSessionsState caches the number of active sessions of the
+// current profile. Failures are ignored as the cache is best effort.
+func RecordAccessSessionsState(ctx context.Context, sessions []clientapi.AccessSessionClientModel) {
+	now := time.Now()
+	updatePromptState(ctx, func(state *PromptState) {
+		state.ActiveSessions = len(sessions)
+		state.SessionsUpdatedAt = &now
+	})
+}
+
+// RecordAccessRequestsState caches the number of pending requests and the
+// soonest revocation time of the active requests of the current profile.
+func RecordAccessRequestsState(ctx context.Context, requests []clientapi.AccessRequestClientModel) {
+	now := time.Now()
+	updatePromptState(ctx, func(state *PromptState) {
+		state.PendingRequests = 0
+		state.NextExpiry = nil
+		for _, request := range requests {
+			switch request.Status.Status {
+			case AccessRequestPendingStatus, AccessRequestPendingMFAStatus, AccessRequestWaitingForApprovalStatus, AccessRequestWaitingForMFAStatus:
+				state.PendingRequests++
+			case AccessRequestActiveStatus:
+				if !request.RevocationTime.IsSet() || request.RevocationTime.Get() == nil {
+					continue
+				}
+				expiry := utils.ConvertUnixTimeToTime(*request.RevocationTime.Get())
+				if expiry.After(now) && (state.NextExpiry == nil || expiry.Before(*state.NextExpiry)) {
+					state.NextExpiry = &expiry
+				}
+			}
+		}
+		state.RequestsUpdatedAt = &now
+	})
+}
+
+func updatePromptState(ctx context.Context, update func(state *PromptState)) {
+	accountID := ""
+	if profile, err := config.GetCurrentProfile(ctx); err == nil {
+		accountID = profile.AccountID
+	}
+	_ = updatePromptStateFile(PromptStatePath(), accountID, update)
+}
+
+// updatePromptStateFile applies update to the state of the account under a
+// lock, so concurrent apono processes do not drop each other's updates.
+func updatePromptStateFile(path, accountID string, update func(state *PromptState)) error {
+	if err := os.MkdirAll(filepath.Dir(path), promptStateDirPerm); err != nil {
+		return err
+	}
+	unlock, err := filelock.Lock(path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	stateFile, err := readPromptStateFile(path)
+	if err != nil {
+		stateFile = &promptStateFile{}
+	}
+	if stateFile.Accounts == nil {
+		stateFile.Accounts = make(map[string]PromptState)
+	}
+
+	key := promptStateKey(accountID)
+	state := stateFile.Accounts[key]
+	update(&state)
+	stateFile.Accounts[key] = state
+
+	data, err := json.Marshal(stateFile)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temp file and rename it so a prompt never reads a partial file.
+	tmp, err := os.CreateTemp(filepath.Dir(path), promptStateFileName+".*")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), promptStateFilePerm); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func readPromptStateFile(path string) (*promptStateFile, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &promptStateFile{}, nil
+		}
+		return nil, err
+	}
+
+	stateFile := new(promptStateFile)
+	if err = json.Unmarshal(data, stateFile); err != nil {
+		return nil, fmt.Errorf("failed to parse prompt state: %w", err)
+	}
+	return stateFile, nil
+}
+
+func promptStateKey(accountID string) string {
+	if accountID == "" {
+		return promptStateAccountless
+	}
+	return accountID
+}
+
+// PromptInfo is the data `apono prompt --format` templates are rendered with.
+type PromptInfo struct {
+	Profile   string
+	Sessions  int
+	Pending   int
+	Expiry    string
+	ExpiresAt string
+	Stale     bool
+}
+
+// NewPromptInfo derives the prompt fields from the cached state. The state is
+// stale when it is older than maxAge or the soonest expiry already passed, as
+// the session count is likely to be off in both cases.
+func NewPromptInfo(profile string, state PromptState, now time.Time, maxAge time.Duration) PromptInfo {
+	info := PromptInfo{
+		Profile:  profile,
+		Sessions: state.ActiveSessions,
+		Pending:  state.PendingRequests,
+		Stale:    state.SessionsUpdatedAt == nil || now.Sub(*state.SessionsUpdatedAt) > maxAge,
+	}
+
+	if state.NextExpiry != nil {
+		if state.NextExpiry.After(now) {
+			info.Expiry = CompactDuration(state.NextExpiry.Sub(now))
+			info.ExpiresAt = state.NextExpiry.Local().Format("15:04")
+		} else {
+			info.Stale = true
+		}
+	}
+
+	return info
+}
+
+// CompactDuration formats a duration with its two most significant units,
+// e.g. 2d3h, 1h05m or 42m, to keep prompts short.
+func CompactDuration(d time.Duration) string {
+	if d < time.Minute {
+		return "<1m"
+	}
+
+	minutes := int(d / time.Minute)
+	days, hours := minutes/(24*60), minutes/60%24
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd%dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh%02dm", hours, minutes%60)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPromptStateFileKeepsAccountsApart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", promptStateFileName)
+	now := time.Now()
+
+	if err := updatePromptStateFile(path, "acc-1", func(state *PromptState) {
+		state.ActiveSessions = 2
+		state.SessionsUpdatedAt = &now
+	}); err != nil {
+		t.Fatalf("updatePromptStateFile() error = %v", err)
+	}
+	if err := updatePromptStateFile(path, "acc-2", func(state *PromptState) {
+		state.PendingRequests = 1
+	}); err != nil {
+		t.Fatalf("updatePromptStateFile() error = %v", err)
+	}
+	if err := updatePromptStateFile(path, "acc-1", func(state *PromptState) {
+		state.PendingRequests = 3
+	}); err != nil {
+		t.Fatalf("updatePromptStateFile() error = %v", err)
+	}
+
+	state, err := ReadPromptState(path, "acc-1")
+	if err != nil {
+		t.Fatalf("ReadPromptState() error = %v", err)
+	}
+	if state.ActiveSessions != 2 || state.PendingRequests != 3 {
+		t.Errorf("acc-1 state = %+v, want 2 sessions and 3 pending requests", state)
+	}
+
+	state, err = ReadPromptState(path, "acc-2")
+	if err != nil {
+		t.Fatalf("ReadPromptState() error = %v", err)
+	}
+	if state.ActiveSessions != 0 || state.PendingRequests != 1 {
+		t.Errorf("acc-2 state = %+v, want 0 sessions and 1 pending request", state)
+	}
+
+	state, err = ReadPromptState(filepath.Join(t.TempDir(), "missing.json"), "acc-1")
+	if err != nil || state.ActiveSessions != 0 {
+		t.Errorf("ReadPromptState() of a missing file = %+v, %v, want an empty state", state, err)
+	}
+}
+
+func TestNewPromptInfo(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-5 * time.Minute)
+	old := now.Add(-2 * time.Hour)
+	soon := now.Add(65 * time.Minute)
+	past := now.Add(-time.Minute)
+
+	tests := []struct {
+		name       string
+		state      PromptState
+		wantExpiry string
+		wantStale  bool
+	}{
+		{name: "fresh", state: PromptState{ActiveSessions: 1, SessionsUpdatedAt: &recent, NextExpiry: &soon}, wantExpiry: "1h05m"},
+		{name: "old cache", state: PromptState{ActiveSessions: 1, SessionsUpdatedAt: &old, NextExpiry: &soon}, wantExpiry: "1h05m", wantStale: true},
+		{name: "expired", state: PromptState{ActiveSessions: 1, SessionsUpdatedAt: &recent, NextExpiry: &past}, wantStale: true},
+		{name: "never updated", state: PromptState{}, wantStale: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := NewPromptInfo("default", tt.state, now, time.Hour)
+			if info.Expiry != tt.wantExpiry || info.Stale != tt.wantStale {
+				t.Errorf("NewPromptInfo() = %+v, want expiry %q and stale %v", info, tt.wantExpiry, tt.wantStale)
+			}
+		})
+	}
+}
+
+func TestCompactDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		30 * time.Second:           "<1m",
+		42 * time.Minute:           "42m",
+		65 * time.Minute:           "1h05m",
+		51*time.Hour + time.Minute: "2d3h",
+	}
+
+	for d, want := range tests {
+		if got := CompactDuration(d); got != want {
+			t.Errorf("CompactDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestPromptStateFileConcurrentUpdates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), promptStateFileName)
+
+	const updates = 20
+	var wg sync.WaitGroup
+	for i := 0; i < updates; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := updatePromptStateFile(path, "acc-1", func(state *PromptState) {
+				state.PendingRequests++
+			}); err != nil {
+				t.Errorf("updatePromptStateFile() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	state, err := ReadPromptState(path, "acc-1")
+	if err != nil {
+		t.Fatalf("ReadPromptState() error = %v", err)
+	}
+	if state.PendingRequests != updates {
+		t.Errorf("PendingRequests = %d, want %d", state.PendingRequests, updates)
+	}
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+)
+
+// CreateIntegrationRequestCommand returns the 'apono requests create' command
+// for the given integration access. Options are added with
+// CreateRequestCommandOptions.
+func CreateIntegrationRequestCommand(integration string, resourceType string, resourceIDs []string, permissionIDs []string) string {
+	createCommand := fmt.Sprintf("apono requests create --integration \"%s\" --resource-type \"%s\"", integration, resourceType)
+
+	for _, permissionID := range permissionIDs {
+		createCommand += fmt.Sprintf(" --permissions \"%s\"", permissionID)
+	}
+
+	for _, resourceID := range resourceIDs {
+		createCommand += fmt.Sprintf(" --resources \"%s\"", resourceID)
+	}
+
+	return createCommand
+}
+
+func CreateBundleRequestCommand(bundle string) string {
+	return fmt.Sprintf("apono requests create --bundle \"%s\"", bundle)
+}
+
+func CreateRequestCommandOptions(justification *string, duration *time.Duration, customFields map[string]string, grantee *clientapi.GranteeClientModel) string {
+	var options strings.Builder
+	if duration != nil {
+		fmt.Fprintf(&options, " --duration %s", duration)
+	}
+
+	if justification != nil && *justification != "" {
+		fmt.Fprintf(&options, " --justification \"%s\"", *justification)
+	}
+
+	for id, value := range customFields {
+		fmt.Fprintf(&options, " --custom-field \"%s=%s\"", id, value)
+	}
+
+	if grantee != nil {
+		fmt.Fprintf(&options, " --grantee \"%s\"", GranteeFlagValue(grantee))
+	}
+
+	return options.String()
+}
@@ -0,0 +1,458 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gookit/color"
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+const (
+	TimelineCreatedEvent  = "Created"
+	TimelineApprovalEvent = "Approval"
+	TimelineMFAEvent      = "MFA"
+	TimelineGrantedEvent  = "Granted"
+	TimelineExpiresEvent  = "Expires"
+	TimelineRevokedEvent  = "Revoked"
+
+	MFAStatusPending     = "Pending"
+	MFAStatusNotRequired = "Not required"
+
+	timelineStatusDone    = "Done"
+	timelineStatusPending = "Pending"
+	timelineStatusFailed  = "Failed"
+	timelineStatusSkipped = "Skipped"
+)
+
+// AccessRequestDetails is the enriched view of an access request returned by
+// `apono requests describe`.
+type AccessRequestDetails struct {
+	Request      clientapi.AccessRequestClientModel `json:"request" yaml:"request"`
+	Status       string                             `json:"status" yaml:"status"`
+	MFAStatus    string                             `json:"mfa_status" yaml:"mfa_status"`
+	AccessUnits  []clientapi.AccessUnitClientModel  `json:"access_units" yaml:"access_units"`
+	CustomFields []AccessRequestCustomFieldValue    `json:"custom_fields" yaml:"custom_fields"`
+	Timeline     []AccessRequestTimelineEvent       `json:"timeline" yaml:"timeline"`
+}
+
+type AccessRequestCustomFieldValue struct {
+	ID    string `json:"id" yaml:"id"`
+	Label string `json:"label" yaml:"label"`
+	Value string `json:"value" yaml:"value"`
+}
+
+// AccessRequestTimelineEvent is a single step in the request lifecycle. Time
+// is only set when the API reports when the step happened.
+type AccessRequestTimelineEvent struct {
+	Event   string     `json:"event" yaml:"event"`
+	Status  string     `json:"status" yaml:"status"`
+	Time    *time.Time `json:"time,omitempty" yaml:"time,omitempty"`
+	Details string     `json:"details,omitempty" yaml:"details,omitempty"`
+}
+
+func GetAccessRequestDetails(ctx context.Context, client *aponoapi.AponoClient, requestID string) (*AccessRequestDetails, error) {
+	request, err := GetRequestByID(ctx, client, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	accessUnits, err := ListAccessRequestAccessUnits(ctx, client, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	var customFieldsDefinitions []clientapi.RequestCustomFieldModel
+	if len(request.CustomFields) > 0 {
+		customFieldsDefinitions, err = GetRequestCustomFields(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return BuildAccessRequestDetails(request, accessUnits, customFieldsDefinitions, time.Now()), nil
+}
+
+func BuildAccessRequestDetails(
+	request *clientapi.AccessRequestClientModel,
+	accessUnits []clientapi.AccessUnitClientModel,
+	customFieldsDefinitions []clientapi.RequestCustomFieldModel,
+	now time.Time,
+) *AccessRequestDetails {
+	mfaStatus := MFAStatusNotRequired
+	if IsRequestWaitingForMFA(request) {
+		mfaStatus = MFAStatusPending
+	}
+
+	status := request.Status.Status
+	if IsRequestWaitingForHumanApproval(request) {
+		status = AccessRequestWaitingForApprovalStatus
+	}
+	if IsRequestWaitingForMFA(request) {
+		status = AccessRequestWaitingForMFAStatus
+	}
+
+	if accessUnits == nil {
+		accessUnits = []clientapi.AccessUnitClientModel{}
+	}
+
+	return &AccessRequestDetails{
+		Request:      *request,
+		Status:       status,
+		MFAStatus:    mfaStatus,
+		AccessUnits:  accessUnits,
+		CustomFields: resolveCustomFieldValues(request.CustomFields, customFieldsDefinitions),
+		Timeline:     buildRequestTimeline(request, now),
+	}
+}
+
+func PrintAccessRequestDetails(cmd *cobra.Command, details *AccessRequestDetails, format utils.Format) error {
+	if !format.IsTable() {
+		object, err := accessRequestDetailsObject(details)
+		if err != nil {
+			return err
+		}
+		return utils.PrintObjects(cmd.OutOrStdout(), format, object, generateRequestsTable([]clientapi.AccessRequestClientModel{details.Request}))
+	}
+
+	writer := cmd.OutOrStdout()
+	sections := []func(io.Writer, *AccessRequestDetails) error{
+		printRequestSummarySection,
+		printRequestTimelineSection,
+		printRequestApprovalSection,
+		printRequestAccessUnitsSection,
+		printRequestCustomFieldsSection,
+	}
+	for _, printSection := range sections {
+		if err := printSection(writer, details); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// accessRequestDetailsObject is the structured output of the details: the
+// request as `requests list` prints it, with the timeline, the approvers and
+// the access units as extra keys.
+func accessRequestDetailsObject(details *AccessRequestDetails) (map[string]any, error) {
+	approvers := []clientapi.ChallengeApproverClientModel{}
+	if details.Request.Challenge.IsSet() && details.Request.Challenge.Get() != nil && details.Request.Challenge.Get().Approvers != nil {
+		approvers = details.Request.Challenge.Get().Approvers
+	}
+	extras := struct {
+		Timeline    []AccessRequestTimelineEvent             `json:"timeline"`
+		Approvers   []clientapi.ChallengeApproverClientModel `json:"approvers"`
+		AccessUnits []clientapi.AccessUnitClientModel        `json:"access_units"`
+	}{details.Timeline, approvers, details.AccessUnits}
+
+	object := make(map[string]any)
+	for _, value := range []any{details.Request, extras} {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(data, &object); err != nil {
+			return nil, err
+		}
+	}
+	return object, nil
+}
+
+func printRequestSummarySection(writer io.Writer, details *AccessRequestDetails) error {
+	request := details.Request
+
+	table := uitable.New()
+	table.AddRow("Request ID:", request.Id)
+	table.AddRow("Status:", ColoredStatus(request))
+	table.AddRow("Requester:", fmt.Sprintf("%s <%s>", request.Requestor.Name, request.Requestor.Email))
+	table.AddRow("Grantee:", granteeDisplayName(&request))
+	table.AddRow("Justification:", valueOrNA(utils.FromNullableString(request.Justification)))
+	if request.Bundle.IsSet() && request.Bundle.Get() != nil {
+		table.AddRow("Bundle:", request.Bundle.Get().Name)
+	}
+	table.AddRow("Integrations:", valueOrNA(strings.Join(requestIntegrationNames(&request), ", ")))
+	table.AddRow("Duration:", requestDurationText(&request))
+	if len(request.AccessGroups) > 0 {
+		accessGroupIDs := make([]string, 0, len(request.AccessGroups))
+		for _, accessGroup := range request.AccessGroups {
+			accessGroupIDs = append(accessGroupIDs, accessGroup.Id)
+		}
+		table.AddRow("Access Groups:", strings.Join(accessGroupIDs, ", "))
+	}
+	table.AddRow("MFA:", details.MFAStatus)
+
+	_, err := fmt.Fprintln(writer, table)
+	return err
+}
+
+func printRequestTimelineSection(writer io.Writer, details *AccessRequestDetails) error {
+	table := uitable.New()
+	table.AddRow("EVENT", "STATUS", "TIME", "DETAILS")
+	for _, event := range details.Timeline {
+		eventTime := "-"
+		if event.Time != nil {
+			eventTime = utils.DisplayTime(*event.Time)
+		}
+		table.AddRow(event.Event, coloredTimelineStatus(event.Status), eventTime, event.Details)
+	}
+
+	return printSection(writer, "Timeline", table)
+}
+
+func printRequestApprovalSection(writer io.Writer, details *AccessRequestDetails) error {
+	if !details.Request.Challenge.IsSet() || details.Request.Challenge.Get() == nil {
+		return nil
+	}
+
+	challenge := details.Request.Challenge.Get()
+	if len(challenge.Approvers) == 0 {
+		return nil
+	}
+
+	table := uitable.New()
+	table.AddRow("APPROVER", "TYPE", "STATUS", "TIER")
+	for _, approver := range challenge.Approvers {
+		tier := "-"
+		if approver.Tier.IsSet() && approver.Tier.Get() != nil {
+			tier = fmt.Sprint(*approver.Tier.Get())
+		}
+		table.AddRow(approver.Name, approver.Type, coloredChallengeStatus(approver.Status), tier)
+	}
+
+	title := fmt.Sprintf("Approvers (%s)", logicalRelationText(challenge.LogicalRelation))
+	return printSection(writer, title, table)
+}
+
+func printRequestAccessUnitsSection(writer io.Writer, details *AccessRequestDetails) error {
+	if len(details.AccessUnits) == 0 {
+		return nil
+	}
+
+	return printSection(writer, "Access Units", accessUnitsTable(details.AccessUnits))
+}
+
+func accessUnitsTable(accessUnits []clientapi.AccessUnitClientModel) *uitable.Table {
+	table := uitable.New()
+	table.AddRow("INTEGRATION", "RESOURCE TYPE", "RESOURCE", "PERMISSION", "STATUS")
+	for _, accessUnit := range accessUnits {
+		status := "NA"
+		if accessUnit.Status.IsSet() && accessUnit.Status.Get() != nil {
+			status = accessUnit.Status.Get().Status
+			if message := utils.FromNullableString(accessUnit.Status.Get().Message); message != "" {
+				status = fmt.Sprintf("%s (%s)", status, message)
+			}
+		}
+
+		table.AddRow(
+			accessUnit.Resource.Integration.Name,
+			accessUnit.Resource.Type.Name,
+			accessUnit.Resource.Name,
+			accessUnit.Permission.Name,
+			status,
+		)
+	}
+
+	return table
+}
+
+func printRequestCustomFieldsSection(writer io.Writer, details *AccessRequestDetails) error {
+	if len(details.CustomFields) == 0 {
+		return nil
+	}
+
+	table := uitable.New()
+	table.AddRow("FIELD", "VALUE")
+	for _, field := range details.CustomFields {
+		table.AddRow(field.Label, field.Value)
+	}
+
+	return printSection(writer, "Custom Fields", table)
+}
+
+func printSection(writer io.Writer, title string, table *uitable.Table) error {
+	_, err := fmt.Fprintf(writer, "\n%s\n%s\n", color.Bold.Sprint(title), table)
+	return err
+}
+
+func buildRequestTimeline(request *clientapi.AccessRequestClientModel, now time.Time) []AccessRequestTimelineEvent {
+	creationTime := utils.ConvertUnixTimeToTime(request.CreationTime)
+	timeline := []AccessRequestTimelineEvent{
+		{Event: TimelineCreatedEvent, Status: timelineStatusDone, Time: &creationTime},
+	}
+
+	if approval := approvalTimelineEvent(request); approval != nil {
+		timeline = append(timeline, *approval)
+	}
+
+	if IsRequestWaitingForMFA(request) {
+		timeline = append(timeline, AccessRequestTimelineEvent{Event: TimelineMFAEvent, Status: timelineStatusPending, Details: "waiting for MFA verification"})
+	}
+
+	status := request.Status.Status
+	switch status {
+	case AccessRequestActiveStatus, AccessRequestRevokingStatus, AccessRequestRevokedStatus:
+		timeline = append(timeline, AccessRequestTimelineEvent{Event: TimelineGrantedEvent, Status: timelineStatusDone})
+	case AccessRequestRejectedStatus:
+		timeline = append(timeline, AccessRequestTimelineEvent{Event: TimelineGrantedEvent, Status: timelineStatusSkipped, Details: "request was rejected"})
+	case AccessRequestFailedStatus:
+		timeline = append(timeline, AccessRequestTimelineEvent{Event: TimelineGrantedEvent, Status: timelineStatusFailed, Details: utils.FromNullableString(request.Status.Description)})
+	default:
+		timeline = append(timeline, AccessRequestTimelineEvent{Event: TimelineGrantedEvent, Status: timelineStatusPending})
+	}
+
+	if request.RevocationTime.IsSet() && request.RevocationTime.Get() != nil {
+		revocationTime := utils.ConvertUnixTimeToTime(*request.RevocationTime.Get())
+		event := TimelineRevokedEvent
+		eventStatus := timelineStatusDone
+		if revocationTime.After(now) {
+			event = TimelineExpiresEvent
+			eventStatus = timelineStatusPending
+		}
+		timeline = append(timeline, AccessRequestTimelineEvent{Event: event, Status: eventStatus, Time: &revocationTime})
+	} else if status == AccessRequestActiveStatus {
+		timeline = append(timeline, AccessRequestTimelineEvent{Event: TimelineExpiresEvent, Status: timelineStatusPending, Details: requestDurationText(request)})
+	}
+
+	return timeline
+}
+
+func approvalTimelineEvent(request *clientapi.AccessRequestClientModel) *AccessRequestTimelineEvent {
+	if !request.Challenge.IsSet() || request.Challenge.Get() == nil || len(request.Challenge.Get().Approvers) == 0 {
+		return nil
+	}
+
+	challenge := request.Challenge.Get()
+	approved, rejected := 0, 0
+	for _, approver := range challenge.Approvers {
+		switch approver.Status {
+		case clientapi.CLIENTCHALLENGESTATUS_APPROVED:
+			approved++
+		case clientapi.CLIENTCHALLENGESTATUS_REJECTED:
+			rejected++
+		}
+	}
+
+	event := &AccessRequestTimelineEvent{
+		Event:   TimelineApprovalEvent,
+		Status:  timelineStatusPending,
+		Details: fmt.Sprintf("%d of %d approved (%s)", approved, len(challenge.Approvers), logicalRelationText(challenge.LogicalRelation)),
+	}
+
+	switch {
+	case rejected > 0:
+		event.Status = timelineStatusFailed
+	case challenge.LogicalRelation == clientapi.CLIENTCHALLENGELOGICALRELATION_ALL_OF && approved == len(challenge.Approvers):
+		event.Status = timelineStatusDone
+	case challenge.LogicalRelation == clientapi.CLIENTCHALLENGELOGICALRELATION_ANY_OF && approved > 0:
+		event.Status = timelineStatusDone
+	}
+
+	return event
+}
+
+func resolveCustomFieldValues(values map[string]string, definitions []clientapi.RequestCustomFieldModel) []AccessRequestCustomFieldValue {
+	labels := make(map[string]string)
+	for _, definition := range definitions {
+		labels[definition.Id] = definition.Label
+	}
+
+	result := make([]AccessRequestCustomFieldValue, 0, len(values))
+	for id, value := range values {
+		label, ok := labels[id]
+		if !ok {
+			label = id
+		}
+		result = append(result, AccessRequestCustomFieldValue{ID: id, Label: label, Value: value})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Label < result[j].Label
+	})
+
+	return result
+}
+
+func granteeDisplayName(request *clientapi.AccessRequestClientModel) string {
+	if !request.Grantee.IsSet() || request.Grantee.Get() == nil {
+		return fmt.Sprintf("%s <%s>", request.Requestor.Name, request.Requestor.Email)
+	}
+
+	grantee := request.Grantee.Get()
+	return fmt.Sprintf("%s (%s)", grantee.DisplayName, grantee.Type)
+}
+
+func granteeShortName(request *clientapi.AccessRequestClientModel) string {
+	if !request.Grantee.IsSet() || request.Grantee.Get() == nil {
+		return request.Requestor.Email
+	}
+
+	return request.Grantee.Get().DisplayName
+}
+
+func requestIntegrationNames(request *clientapi.AccessRequestClientModel) []string {
+	var names []string
+	for _, accessGroup := range request.AccessGroups {
+		names = append(names, accessGroup.Integration.Name)
+	}
+
+	return names
+}
+
+func requestDurationText(request *clientapi.AccessRequestClientModel) string {
+	if !request.DurationInSec.IsSet() || request.DurationInSec.Get() == nil {
+		return "NA"
+	}
+
+	return (time.Duration(*request.DurationInSec.Get()) * time.Second).String()
+}
+
+func logicalRelationText(relation clientapi.ClientChallengeLogicalRelation) string {
+	if relation == clientapi.CLIENTCHALLENGELOGICALRELATION_ALL_OF {
+		return "all of"
+	}
+
+	return "any of"
+}
+
+func coloredChallengeStatus(status clientapi.ClientChallengeStatus) string {
+	switch status {
+	case clientapi.CLIENTCHALLENGESTATUS_APPROVED:
+		return color.Green.Sprint(status)
+	case clientapi.CLIENTCHALLENGESTATUS_REJECTED:
+		return color.Red.Sprint(status)
+	default:
+		return color.Yellow.Sprint(status)
+	}
+}
+
+func coloredTimelineStatus(status string) string {
+	switch status {
+	case timelineStatusDone:
+		return color.Green.Sprint(status)
+	case timelineStatusFailed:
+		return color.Red.Sprint(status)
+	case timelineStatusSkipped:
+		return color.Gray.Sprint(status)
+	default:
+		return color.Yellow.Sprint(status)
+	}
+}
+
+func valueOrNA(value string) string {
+	if value == "" {
+		return "NA"
+	}
+
+	return value
+}
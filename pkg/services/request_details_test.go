@@ -0,0 +1,147 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+)
+
+func newTestAccessRequest(status string) *clientapi.AccessRequestClientModel {
+	return &clientapi.AccessRequestClientModel{
+		Id:           "AR-1",
+		Requestor:    clientapi.UserClientModel{Name: "Jane", Email: "jane@example.com"},
+		CreationTime: 1700000000,
+		Status:       clientapi.RequestStatusClientModel{Status: status},
+	}
+}
+
+func timelineEvents(details *AccessRequestDetails) map[string]AccessRequestTimelineEvent {
+	events := make(map[string]AccessRequestTimelineEvent)
+	for _, event := range details.Timeline {
+		events[event.Event] = event
+	}
+	return events
+}
+
+func TestBuildAccessRequestDetails_pendingApproval(t *testing.T) {
+	request := newTestAccessRequest(AccessRequestPendingStatus)
+	request.Challenge = *clientapi.NewNullableAccessRequestClientModelChallenge(&clientapi.AccessRequestClientModelChallenge{
+		LogicalRelation: clientapi.CLIENTCHALLENGELOGICALRELATION_ALL_OF,
+		Approvers: []clientapi.ChallengeApproverClientModel{
+			{Name: "Alice", Status: clientapi.CLIENTCHALLENGESTATUS_APPROVED},
+			{Name: "Bob", Status: clientapi.CLIENTCHALLENGESTATUS_PENDING},
+		},
+	})
+
+	details := BuildAccessRequestDetails(request, nil, nil, time.Now())
+
+	if details.Status != AccessRequestWaitingForApprovalStatus {
+		t.Errorf("Status = %q, want %q", details.Status, AccessRequestWaitingForApprovalStatus)
+	}
+	if details.AccessUnits == nil {
+		t.Error("AccessUnits = nil, want empty slice so structured output prints []")
+	}
+
+	events := timelineEvents(details)
+	approval, ok := events[TimelineApprovalEvent]
+	if !ok {
+		t.Fatal("timeline has no approval event")
+	}
+	if approval.Status != timelineStatusPending {
+		t.Errorf("approval status = %q, want %q", approval.Status, timelineStatusPending)
+	}
+	if approval.Details != "1 of 2 approved (all of)" {
+		t.Errorf("approval details = %q", approval.Details)
+	}
+	if events[TimelineGrantedEvent].Status != timelineStatusPending {
+		t.Errorf("granted status = %q, want %q", events[TimelineGrantedEvent].Status, timelineStatusPending)
+	}
+}
+
+func TestBuildAccessRequestDetails_activeWithExpiry(t *testing.T) {
+	now := time.Unix(1700001000, 0)
+	request := newTestAccessRequest(AccessRequestActiveStatus)
+	request.RevocationTime = *clientapi.NewNullableFloat64(clientapi.PtrFloat64(1700003600))
+
+	details := BuildAccessRequestDetails(request, nil, nil, now)
+	events := timelineEvents(details)
+
+	if details.MFAStatus != MFAStatusNotRequired {
+		t.Errorf("MFAStatus = %q, want %q", details.MFAStatus, MFAStatusNotRequired)
+	}
+	if events[TimelineGrantedEvent].Status != timelineStatusDone {
+		t.Errorf("granted status = %q, want %q", events[TimelineGrantedEvent].Status, timelineStatusDone)
+	}
+
+	expires, ok := events[TimelineExpiresEvent]
+	if !ok {
+		t.Fatal("timeline has no expires event")
+	}
+	if expires.Time == nil || !expires.Time.Equal(time.Unix(1700003600, 0)) {
+		t.Errorf("expires time = %v, want %v", expires.Time, time.Unix(1700003600, 0))
+	}
+	if _, ok = events[TimelineRevokedEvent]; ok {
+		t.Error("timeline has a revoked event for a request that did not expire yet")
+	}
+}
+
+func TestBuildAccessRequestDetails_pendingMFAAndCustomFields(t *testing.T) {
+	request := newTestAccessRequest(AccessRequestPendingMFAStatus)
+	request.CustomFields = map[string]string{"ticket": "OPS-1", "unknown": "x"}
+	definitions := []clientapi.RequestCustomFieldModel{{Id: "ticket", Label: "Ticket"}}
+
+	details := BuildAccessRequestDetails(request, nil, definitions, time.Now())
+
+	if details.MFAStatus != MFAStatusPending {
+		t.Errorf("MFAStatus = %q, want %q", details.MFAStatus, MFAStatusPending)
+	}
+	if _, ok := timelineEvents(details)[TimelineMFAEvent]; !ok {
+		t.Error("timeline has no MFA event")
+	}
+
+	want := []AccessRequestCustomFieldValue{
+		{ID: "ticket", Label: "Ticket", Value: "OPS-1"},
+		{ID: "unknown", Label: "unknown", Value: "x"},
+	}
+	if len(details.CustomFields) != len(want) {
+		t.Fatalf("CustomFields = %+v, want %+v", details.CustomFields, want)
+	}
+	for i := range want {
+		if details.CustomFields[i] != want[i] {
+			t.Errorf("CustomFields[%d] = %+v, want %+v", i, details.CustomFields[i], want[i])
+		}
+	}
+}
+
+func TestAccessRequestDetailsObject(t *testing.T) {
+	request := newTestAccessRequest(AccessRequestPendingStatus)
+	request.Challenge = *clientapi.NewNullableAccessRequestClientModelChallenge(&clientapi.AccessRequestClientModelChallenge{
+		LogicalRelation: clientapi.CLIENTCHALLENGELOGICALRELATION_ALL_OF,
+		Approvers:       []clientapi.ChallengeApproverClientModel{{Name: "Alice", Status: clientapi.CLIENTCHALLENGESTATUS_PENDING}},
+	})
+
+	object, err := accessRequestDetailsObject(BuildAccessRequestDetails(request, nil, nil, time.Now()))
+	if err != nil {
+		t.Fatalf("accessRequestDetailsObject() error = %v", err)
+	}
+
+	if object["id"] != "AR-1" {
+		t.Errorf("id = %v, want the request fields at the top level", object["id"])
+	}
+	if status, ok := object["status"].(map[string]any); !ok || status["status"] != AccessRequestPendingStatus {
+		t.Errorf("status = %v, want the status of the request model", object["status"])
+	}
+	if _, exists := object["request"]; exists {
+		t.Error("the request is nested, want its fields at the top level")
+	}
+	if approvers, ok := object["approvers"].([]any); !ok || len(approvers) != 1 {
+		t.Errorf("approvers = %v, want the approver", object["approvers"])
+	}
+	if accessUnits, ok := object["access_units"].([]any); !ok || len(accessUnits) != 0 {
+		t.Errorf("access_units = %v, want an empty list", object["access_units"])
+	}
+	if timeline, ok := object["timeline"].([]any); !ok || len(timeline) == 0 {
+		t.Errorf("timeline = %v, want the timeline events", object["timeline"])
+	}
+}
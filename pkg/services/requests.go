@@ -16,7 +16,6 @@ import (
 	"github.com/apono-io/apono-cli/pkg/clientapi"
 
 	"github.com/gookit/color"
-	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -45,27 +44,12 @@ const (
 )
 
 func PrintAccessRequests(cmd *cobra.Command, requests []clientapi.AccessRequestClientModel, format utils.Format, printAsArray bool) error {
-	switch format {
-	case utils.TableFormat:
-		table := generateRequestsTable(requests)
-
-		_, err := fmt.Fprintln(cmd.OutOrStdout(), table)
-		return err
-	case utils.JSONFormat:
-		if printAsArray {
-			return utils.PrintObjectsAsJSON(cmd.OutOrStdout(), requests)
-		} else {
-			return utils.PrintObjectsAsJSON(cmd.OutOrStdout(), requests[0])
-		}
-	case utils.YamlFormat:
-		if printAsArray {
-			return utils.PrintObjectsAsYaml(cmd.OutOrStdout(), requests)
-		} else {
-			return utils.PrintObjectsAsYaml(cmd.OutOrStdout(), requests[0])
-		}
-	default:
-		return fmt.Errorf("unsupported output format")
+	var objects any = requests
+	if !printAsArray {
+		objects = requests[0]
 	}
+
+	return utils.PrintObjects(cmd.OutOrStdout(), format, objects, generateRequestsTable(requests))
 }
 
 func PrintAccessRequestMFALink(cmd *cobra.Command, requestID *string) error {
@@ -103,19 +87,22 @@ func PrintAccessRequestMFALink(cmd *cobra.Command, requestID *string) error {
 	return nil
 }
 
-func generateRequestsTable(requests []clientapi.AccessRequestClientModel) *uitable.Table {
-	table := uitable.New()
-	table.AddRow("REQUEST ID", "CREATED", "REVOKED", "INTEGRATIONS", "JUSTIFICATION", "STATUS")
+func generateRequestsTable(requests []clientapi.AccessRequestClientModel) *utils.Table {
+	table := utils.NewTable(
+		utils.Column("REQUEST ID"),
+		utils.Column("CREATED"),
+		utils.Column("REVOKED"),
+		utils.Column("INTEGRATIONS"),
+		utils.Column("JUSTIFICATION"),
+		utils.Column("STATUS"),
+		utils.Column("GRANTEE"),
+		utils.WideColumn("BUNDLE"),
+		utils.WideColumn("DURATION"),
+		utils.WideColumn("RESOURCES"),
+		utils.WideColumn("REQUESTER"),
+	)
 	for _, request := range requests {
-		var requestIntegrations []string
-		for _, accessGroup := range request.AccessGroups {
-			requestIntegrations = append(requestIntegrations, accessGroup.Integration.Name)
-		}
-
-		integrations := strings.Join(requestIntegrations, ", ")
-		if integrations == "" {
-			integrations = "NA"
-		}
+		integrations := valueOrNA(strings.Join(requestIntegrationNames(&request), ", "))
 
 		creationTime := utils.ConvertUnixTimeToTime(request.CreationTime)
 		var revocationTime string
@@ -125,7 +112,26 @@ func generateRequestsTable(requests []clientapi.AccessRequestClientModel) *uitab
 			revocationTime = "NA"
 		}
 
-		table.AddRow(request.Id, utils.DisplayTime(creationTime), revocationTime, integrations, *request.Justification.Get(), ColoredStatus(request))
+		bundle := "NA"
+		if request.Bundle.IsSet() && request.Bundle.Get() != nil {
+			bundle = request.Bundle.Get().Name
+		}
+
+		resources := valueOrNA(strings.Join(request.DistinctResourceNames, ", "))
+
+		table.AddRow(
+			request.Id,
+			utils.DisplayTime(creationTime),
+			revocationTime,
+			integrations,
+			utils.FromNullableString(request.Justification),
+			ColoredStatus(request),
+			granteeShortName(&request),
+			bundle,
+			requestDurationText(&request),
+			resources,
+			request.Requestor.Email,
+		)
 	}
 
 	return table
@@ -176,9 +182,27 @@ func getUserLastRequest(ctx context.Context, client *aponoapi.AponoClient) (*cli
 	return &userLastRequests.Data[0], nil
 }
 
+// ListRequestsByIDs returns the requests of the user with the given IDs,
+// regardless of when they were created.
+func ListRequestsByIDs(ctx context.Context, client *aponoapi.AponoClient, requestIDs []string) ([]clientapi.AccessRequestClientModel, error) {
+	return utils.GetAllPages(ctx, client, func(ctx context.Context, client *aponoapi.AponoClient, skip int32) ([]clientapi.AccessRequestClientModel, *clientapi.PaginationClientInfoModel, error) {
+		resp, _, err := client.ClientAPI.AccessRequestsAPI.ListAccessRequests(ctx).
+			Scope(clientapi.ACCESSREQUESTSSCOPEMODEL_MY_REQUESTS).
+			RequestIds(requestIDs).
+			Skip(skip).
+			Execute()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return resp.Data, &resp.Pagination, nil
+	})
+}
+
 func ListRequests(ctx context.Context, client *aponoapi.AponoClient, daysOffset int64) ([]clientapi.AccessRequestClientModel, error) {
 	var resultRequests []clientapi.AccessRequestClientModel
 
+	complete := true
 	skip := 0
 	for {
 		resp, _, err := client.ClientAPI.AccessRequestsAPI.ListAccessRequests(ctx).
@@ -192,6 +216,8 @@ func ListRequests(ctx context.Context, client *aponoapi.AponoClient, daysOffset
 		for _, request := range resp.Data {
 			if utils.IsDateAfterDaysOffset(utils.ConvertUnixTimeToTime(request.CreationTime), daysOffset) {
 				resultRequests = append(resultRequests, request)
+			} else {
+				complete = false
 			}
 		}
 
@@ -203,6 +229,12 @@ func ListRequests(ctx context.Context, client *aponoapi.AponoClient, daysOffset
 		}
 	}
 
+	// Older pending requests are missing from a result cut by daysOffset, so
+	// only a full listing can refresh the prompt state.
+	if complete {
+		RecordAccessRequestsState(ctx, resultRequests)
+	}
+
 	return resultRequests, nil
 }
 
@@ -281,6 +313,7 @@ func DryRunRequest(ctx context.Context, client *aponoapi.AponoClient, request *c
 		FilterAccessUnitIds:   request.FilterAccessUnitIds,
 		Justification:         request.Justification,
 		DurationInSec:         request.DurationInSec,
+		GranteeId:             request.GranteeId,
 	}
 
 	dryRunResponse, resp, err := client.ClientAPI.AccessRequestsAPI.DryRunCreateUserAccessRequest(ctx).
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/apono-io/apono-cli/pkg/config"
+	"github.com/apono-io/apono-cli/pkg/secrets"
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+type SecretsMigrationResult struct {
+	Backend          string
+	Profiles         []config.ProfileName
+	VaultCredentials int
+	SessionPasswords int
+}
+
+// MigrateSecrets moves the profile tokens to the backend and makes it the
+// configured backend. Unless the backend is plaintext, the base64 encoded
+// vault credentials and session passwords in the cache dir are moved too.
+func MigrateSecrets(backend string) (*SecretsMigrationResult, error) {
+	store, err := config.OpenSecretStore(backend)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SecretsMigrationResult{Backend: backend}
+	err = config.WithLock(func() error {
+		return migrateProfileSecrets(backend, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if store != nil {
+		if err = migrateCachedSecrets(utils.DefaultCacheDir(), store, result); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// migrateProfileSecrets runs under the config lock, so it uses Get and Save.
+func migrateProfileSecrets(backend string, result *SecretsMigrationResult) error {
+	cfg, err := config.Get()
+	if err != nil {
+		return err
+	}
+
+	for name, session := range cfg.Auth.Profiles {
+		previous := session.SecretsBackend
+		if previous == backend || (previous == "" && backend == secrets.BackendPlaintext) {
+			continue
+		}
+
+		if err = config.LoadProfileSecrets(name, &session); err != nil {
+			return err
+		}
+		if err = config.StoreProfileSecrets(name, &session, backend); err != nil {
+			return fmt.Errorf("move secrets of profile %s: %w", name, err)
+		}
+		cfg.Auth.Profiles[name] = session
+		result.Profiles = append(result.Profiles, name)
+
+		// Save before deleting from the previous backend, so a failure never
+		// loses the only copy of a token.
+		if err = config.Save(cfg); err != nil {
+			return err
+		}
+		if previous != "" {
+			_ = config.DeleteProfileSecrets(name, config.SessionConfig{SecretsBackend: previous})
+		}
+	}
+
+	cfg.Secrets.Backend = backend
+	return config.Save(cfg)
+}
+
+func migrateCachedSecrets(cacheDir string, store secrets.Store, result *SecretsMigrationResult) error {
+	entries, err := os.ReadDir(cacheDir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read cache dir: %w", err)
+	}
+
+	for _, entry := range entries {
+		// Other cache files, like the prompt state, have an extension.
+		if !entry.Type().IsRegular() || strings.Contains(entry.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(cacheDir, entry.Name())
+		raw, readErr := os.ReadFile(filepath.Clean(path))
+		if readErr != nil {
+			return fmt.Errorf("read cache file: %w", readErr)
+		}
+		decoded, decodeErr := base64.StdEncoding.DecodeString(strings.TrimSpace(string(raw)))
+		if decodeErr != nil {
+			continue
+		}
+
+		if integrationID, isVault := strings.CutPrefix(entry.Name(), vaultCacheFilePrefix); isVault {
+			err = store.Set(secrets.VaultCredentialsKey(integrationID), string(decoded))
+			result.VaultCredentials++
+		} else {
+			err = store.Set(secrets.SessionPasswordKey(entry.Name()), strings.TrimRight(string(decoded), "\n\r"))
+			result.SessionPasswords++
+		}
+		if err != nil {
+			return fmt.Errorf("move %s: %w", entry.Name(), err)
+		}
+		if err = os.Remove(path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apono-io/apono-cli/pkg/secrets"
+)
+
+type memoryStore map[string]string
+
+func (s memoryStore) Get(key string) (string, error) {
+	value, ok := s[key]
+	if !ok {
+		return "", secrets.ErrNotFound
+	}
+	return value, nil
+}
+
+func (s memoryStore) Set(key, value string) error {
+	s[key] = value
+	return nil
+}
+
+func (s memoryStore) Delete(key string) error {
+	delete(s, key)
+	return nil
+}
+
+func TestMigrateCachedSecrets(t *testing.T) {
+	cacheDir := t.TempDir()
+	files := map[string]string{
+		"vault-i-1":         base64.StdEncoding.EncodeToString([]byte(`{"username":"u"}`)),
+		"sess-1":            base64.StdEncoding.EncodeToString([]byte("hunter2\n")),
+		"prompt-state.json": `{"accounts":{}}`,
+		"not-a-secret":      "not base64!!!",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(cacheDir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	store := memoryStore{}
+	result := &SecretsMigrationResult{}
+	if err := migrateCachedSecrets(cacheDir, store, result); err != nil {
+		t.Fatalf("migrateCachedSecrets() error = %v", err)
+	}
+
+	if result.VaultCredentials != 1 || result.SessionPasswords != 1 {
+		t.Errorf("result = %+v, want 1 vault credential and 1 session password", result)
+	}
+	if store[secrets.VaultCredentialsKey("i-1")] != `{"username":"u"}` {
+		t.Errorf("vault credentials = %q", store[secrets.VaultCredentialsKey("i-1")])
+	}
+	if store[secrets.SessionPasswordKey("sess-1")] != "hunter2" {
+		t.Errorf("session password = %q", store[secrets.SessionPasswordKey("sess-1")])
+	}
+
+	for name, wantExists := range map[string]bool{"vault-i-1": false, "sess-1": false, "prompt-state.json": true, "not-a-secret": true} {
+		_, err := os.Stat(filepath.Join(cacheDir, name))
+		if exists := err == nil; exists != wantExists {
+			t.Errorf("%s exists = %v, want %v", name, exists, wantExists)
+		}
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"os/exec"
 	"runtime"
+	"strings"
+	"time"
 
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
 	"github.com/apono-io/apono-cli/pkg/clientapi"
@@ -15,7 +17,6 @@ import (
 	"github.com/apono-io/apono-cli/pkg/utils"
 
 	"github.com/gookit/color"
-	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 )
 
@@ -30,33 +31,43 @@ const (
 type CustomInstructionMessage = string
 
 func PrintAccessSessions(cmd *cobra.Command, sessions []clientapi.AccessSessionClientModel, format *utils.Format) error {
-	switch *format {
-	case utils.TableFormat:
-		table := generateSessionsTable(sessions)
-
-		_, err := fmt.Fprintln(cmd.OutOrStdout(), table)
-		return err
-	case utils.JSONFormat:
-		return utils.PrintObjectsAsJSON(cmd.OutOrStdout(), sessions)
-	case utils.YamlFormat:
-		return utils.PrintObjectsAsYaml(cmd.OutOrStdout(), sessions)
-	default:
-		return fmt.Errorf("unsupported output format")
-	}
+	return utils.PrintObjects(cmd.OutOrStdout(), *format, sessions, generateSessionsTable(sessions))
 }
 
-func generateSessionsTable(sessions []clientapi.AccessSessionClientModel) *uitable.Table {
-	table := uitable.New()
-	table.AddRow("ID", "NAME", "INTEGRATION NAME", "INTEGRATION TYPE", "TYPE")
+func generateSessionsTable(sessions []clientapi.AccessSessionClientModel) *utils.Table {
+	table := utils.NewTable(
+		utils.Column("ID"),
+		utils.Column("NAME"),
+		utils.Column("INTEGRATION NAME"),
+		utils.Column("INTEGRATION TYPE"),
+		utils.Column("TYPE"),
+		utils.WideColumn("STATUS"),
+		utils.WideColumn("CONNECTION METHODS"),
+		utils.WideColumn("CREDENTIALS"),
+	)
 	for _, session := range sessions {
-		table.AddRow(session.Id, session.Name, session.Integration.Name, session.Integration.Type, session.Type.Name)
+		credentialsStatus := "NA"
+		if session.Credentials.IsSet() && session.Credentials.Get() != nil {
+			credentialsStatus = session.Credentials.Get().Status
+		}
+
+		table.AddRow(
+			session.Id,
+			session.Name,
+			session.Integration.Name,
+			session.Integration.Type,
+			session.Type.Name,
+			session.Status,
+			strings.Join(session.ConnectionMethods, ", "),
+			credentialsStatus,
+		)
 	}
 
 	return table
 }
 
 func ListAccessSessions(ctx context.Context, client *aponoapi.AponoClient, integrationIds []string, bundleIds []string, requestIds []string) ([]clientapi.AccessSessionClientModel, error) {
-	return utils.GetAllPages(ctx, client, func(ctx context.Context, client *aponoapi.AponoClient, skip int32) ([]clientapi.AccessSessionClientModel, *clientapi.PaginationClientInfoModel, error) {
+	sessions, err := utils.GetAllPages(ctx, client, func(ctx context.Context, client *aponoapi.AponoClient, skip int32) ([]clientapi.AccessSessionClientModel, *clientapi.PaginationClientInfoModel, error) {
 		listSessionsRequest := client.ClientAPI.AccessSessionsAPI.ListAccessSessions(ctx).Skip(skip)
 		if integrationIds != nil {
 			listSessionsRequest = listSessionsRequest.IntegrationId(integrationIds)
@@ -75,6 +86,151 @@ func ListAccessSessions(ctx context.Context, client *aponoapi.AponoClient, integ
 
 		return resp.Data, &resp.Pagination, nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	if integrationIds == nil && bundleIds == nil && requestIds == nil {
+		RecordAccessSessionsState(ctx, sessions)
+	}
+
+	return sessions, nil
+}
+
+// ListOtherAccessSessions lists the sessions of the "Other" group. The API
+// cannot filter by it, so these are the sessions of no integration group.
+func ListOtherAccessSessions(ctx context.Context, client *aponoapi.AponoClient) ([]clientapi.AccessSessionClientModel, error) {
+	groups, err := ListAccessSessionsGroups(ctx, client, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	groupedIntegrations := make(map[string]bool)
+	for _, group := range groups {
+		if integration, ok := group.GetIntegrationOk(); ok && integration != nil {
+			groupedIntegrations[integration.Id] = true
+		}
+	}
+
+	sessions, err := ListAccessSessions(ctx, client, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var otherSessions []clientapi.AccessSessionClientModel
+	for _, session := range sessions {
+		if !groupedIntegrations[session.Integration.Id] {
+			otherSessions = append(otherSessions, session)
+		}
+	}
+
+	return otherSessions, nil
+}
+
+// DiffAccessSessions returns the sessions that are only in after, and the
+// sessions that are only in before.
+func DiffAccessSessions(before, after []clientapi.AccessSessionClientModel) (added, removed []clientapi.AccessSessionClientModel) {
+	beforeIDs := make(map[string]bool)
+	for _, session := range before {
+		beforeIDs[session.Id] = true
+	}
+	afterIDs := make(map[string]bool)
+	for _, session := range after {
+		afterIDs[session.Id] = true
+		if !beforeIDs[session.Id] {
+			added = append(added, session)
+		}
+	}
+	for _, session := range before {
+		if !afterIDs[session.Id] {
+			removed = append(removed, session)
+		}
+	}
+
+	return added, removed
+}
+
+func ListAccessSessionsGroups(ctx context.Context, client *aponoapi.AponoClient, integrationIds []string) ([]clientapi.AccessSessionsGroupClientModel, error) {
+	return utils.GetAllPages(ctx, client, func(ctx context.Context, client *aponoapi.AponoClient, skip int32) ([]clientapi.AccessSessionsGroupClientModel, *clientapi.PaginationClientInfoModel, error) {
+		listGroupsRequest := client.ClientAPI.AccessSessionsAPI.ListAccessSessionsGroups(ctx).Skip(skip)
+		if integrationIds != nil {
+			listGroupsRequest = listGroupsRequest.IntegrationId(integrationIds)
+		}
+
+		resp, _, err := listGroupsRequest.Execute()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return resp.Data, &resp.Pagination, nil
+	})
+}
+
+// PrintAccessSessionsGroups prints a section per integration with its
+// sessions and custom access details. Structured formats print the groups
+// as returned by the API, delimited formats print the sessions of all groups.
+func PrintAccessSessionsGroups(cmd *cobra.Command, groups []clientapi.AccessSessionsGroupClientModel, format *utils.Format) error {
+	if !format.IsTable() {
+		var sessions []clientapi.AccessSessionClientModel
+		for _, group := range groups {
+			sessions = append(sessions, group.Sessions...)
+		}
+
+		return utils.PrintObjects(cmd.OutOrStdout(), *format, groups, generateSessionsTable(sessions))
+	}
+
+	for i, group := range groups {
+		if i > 0 {
+			if _, err := fmt.Fprintln(cmd.OutOrStdout()); err != nil {
+				return err
+			}
+		}
+
+		if err := printAccessSessionsGroup(cmd, group, format); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func printAccessSessionsGroup(cmd *cobra.Command, group clientapi.AccessSessionsGroupClientModel, format *utils.Format) error {
+	total := fmt.Sprintf("%d sessions", group.Total)
+	if int(group.Total) > len(group.Sessions) {
+		total = fmt.Sprintf("%d sessions, showing %d", group.Total, len(group.Sessions))
+	}
+
+	_, err := fmt.Fprintf(cmd.OutOrStdout(), "%s (%s)\n", color.Bold.Sprint(AccessSessionsGroupName(group)), total)
+	if err != nil {
+		return err
+	}
+
+	if customAccessDetails := group.GetCustomAccessDetails(); customAccessDetails != "" {
+		_, err = fmt.Fprintf(cmd.OutOrStdout(), "Access details: %s\n", customAccessDetails)
+		if err != nil {
+			return err
+		}
+	}
+
+	return generateSessionsTable(group.Sessions).Print(cmd.OutOrStdout(), format.Kind == utils.WideFormat, format.NoHeaders)
+}
+
+func AccessSessionsGroupName(group clientapi.AccessSessionsGroupClientModel) string {
+	integration, ok := group.GetIntegrationOk()
+	if !ok || integration == nil {
+		return "Other"
+	}
+
+	return fmt.Sprintf("%s/%s", integration.TypeDisplayName, integration.Name)
+}
+
+func PrintCustomAccessDetails(cmd *cobra.Command, customAccessDetails string) error {
+	if customAccessDetails == "" {
+		return nil
+	}
+
+	_, err := fmt.Fprintf(cmd.OutOrStdout(), "\n%s Access details: %s\n", styles.NoticeMsgPrefix, color.Green.Sprint(customAccessDetails))
+	return err
 }
 
 func ExecuteAccessDetails(cobraCmd *cobra.Command, client *aponoapi.AponoClient, session *clientapi.AccessSessionClientModel) error {
@@ -183,3 +339,32 @@ func PrintCustomInstructionMessage(cmd *cobra.Command, customInstructionMessage
 	_, err := fmt.Fprintf(cmd.OutOrStdout(), "\n%s Message from your admin: %s\n", styles.NoticeMsgPrefix, color.Green.Sprint(customInstructionMessage))
 	return err
 }
+
+// WaitForAccessSessionExpiry blocks until the session is no longer among the
+// active access sessions. Failed checks are retried on the next poll.
+func WaitForAccessSessionExpiry(ctx context.Context, client *aponoapi.AponoClient, sessionID string, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		sessions, err := ListAccessSessions(ctx, client, nil, nil, nil)
+		if err == nil && !containsAccessSession(sessions, sessionID) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func containsAccessSession(sessions []clientapi.AccessSessionClientModel, sessionID string) bool {
+	for _, session := range sessions {
+		if session.Id == sessionID {
+			return true
+		}
+	}
+	return false
+}
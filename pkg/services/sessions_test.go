@@ -0,0 +1,127 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apono-io/apono-cli/pkg/aponoapi"
+	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+func TestPrintAccessSessionsGroups(t *testing.T) {
+	group := clientapi.AccessSessionsGroupClientModel{
+		Total:    3,
+		Sessions: []clientapi.AccessSessionClientModel{{Id: "s-1", Name: "prod-db"}},
+	}
+	group.SetIntegration(clientapi.AccessSessionsGroupClientModelIntegration{Id: "i-1", Name: "Prod", TypeDisplayName: "PostgreSQL"})
+	group.SetCustomAccessDetails("Connect through the bastion")
+	other := clientapi.AccessSessionsGroupClientModel{Total: 0}
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+
+	err := PrintAccessSessionsGroups(cmd, []clientapi.AccessSessionsGroupClientModel{group, other}, &utils.Format{Kind: utils.TableFormat})
+	if err != nil {
+		t.Fatalf("PrintAccessSessionsGroups() error = %v", err)
+	}
+
+	for _, want := range []string{"PostgreSQL/Prod", "3 sessions, showing 1", "Access details: Connect through the bastion", "prod-db", "Other", "(0 sessions)"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output does not contain %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestListOtherAccessSessions(t *testing.T) {
+	grouped := clientapi.AccessSessionsGroupClientModel{Total: 1, Sessions: []clientapi.AccessSessionClientModel{}}
+	grouped.SetIntegration(clientapi.AccessSessionsGroupClientModelIntegration{Id: "i-1", Name: "Prod"})
+	groups := clientapi.PaginatedClientResponseModelAccessSessionsGroupClientModel{
+		Data:       []clientapi.AccessSessionsGroupClientModel{grouped, {Total: 2, Sessions: []clientapi.AccessSessionClientModel{}}},
+		Pagination: clientapi.PaginationClientInfoModel{Limit: 100},
+	}
+	sessions := clientapi.PaginatedClientResponseModelAccessSessionClientModel{
+		Data: []clientapi.AccessSessionClientModel{
+			{Id: "s-1", Integration: clientapi.IntegrationClientModel{Id: "i-1"}, ConnectionMethods: []string{}, Launchers: []clientapi.LauncherSummaryClientModel{}},
+			{Id: "s-2", Integration: clientapi.IntegrationClientModel{Id: "i-2"}, ConnectionMethods: []string{}, Launchers: []clientapi.LauncherSummaryClientModel{}},
+			{Id: "s-3", ConnectionMethods: []string{}, Launchers: []clientapi.LauncherSummaryClientModel{}},
+		},
+		Pagination: clientapi.PaginationClientInfoModel{Limit: 100},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/client/v1/access-session-groups":
+			_ = json.NewEncoder(w).Encode(groups)
+		case "/api/client/v1/access-sessions":
+			_ = json.NewEncoder(w).Encode(sessions)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("HOME", t.TempDir())
+
+	client, err := aponoapi.CreateClientWithPersonalToken(server.URL, "test-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other, err := ListOtherAccessSessions(context.Background(), client)
+	if err != nil {
+		t.Fatalf("ListOtherAccessSessions() error = %v", err)
+	}
+	var ids []string
+	for _, session := range other {
+		ids = append(ids, session.Id)
+	}
+	if strings.Join(ids, ",") != "s-2,s-3" {
+		t.Errorf("ListOtherAccessSessions() = %v, want the sessions outside the integration groups", ids)
+	}
+}
+
+func TestDiffAccessSessions(t *testing.T) {
+	sessions := func(ids ...string) []clientapi.AccessSessionClientModel {
+		var result []clientapi.AccessSessionClientModel
+		for _, id := range ids {
+			result = append(result, clientapi.AccessSessionClientModel{Id: id})
+		}
+		return result
+	}
+	ids := func(sessions []clientapi.AccessSessionClientModel) string {
+		var result []string
+		for _, session := range sessions {
+			result = append(result, session.Id)
+		}
+		return strings.Join(result, ",")
+	}
+
+	tests := []struct {
+		name                   string
+		before, after          []clientapi.AccessSessionClientModel
+		wantAdded, wantRemoved string
+	}{
+		{name: "unchanged", before: sessions("s-1", "s-2"), after: sessions("s-2", "s-1")},
+		{name: "granted", before: sessions("s-1"), after: sessions("s-1", "s-2", "s-3"), wantAdded: "s-2,s-3"},
+		{name: "revoked", before: sessions("s-1", "s-2"), after: sessions("s-2"), wantRemoved: "s-1"},
+		{name: "first sync", after: sessions("s-1"), wantAdded: "s-1"},
+		{name: "both", before: sessions("s-1"), after: sessions("s-2"), wantAdded: "s-2", wantRemoved: "s-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed := DiffAccessSessions(tt.before, tt.after)
+			if ids(added) != tt.wantAdded || ids(removed) != tt.wantRemoved {
+				t.Errorf("DiffAccessSessions() = added %q, removed %q, want added %q, removed %q", ids(added), ids(removed), tt.wantAdded, tt.wantRemoved)
+			}
+		})
+	}
+}
@@ -16,6 +16,8 @@ import (
 
 	"github.com/apono-io/apono-cli/pkg/aponoapi"
 	"github.com/apono-io/apono-cli/pkg/clientapi"
+	"github.com/apono-io/apono-cli/pkg/config"
+	"github.com/apono-io/apono-cli/pkg/secrets"
 	"github.com/apono-io/apono-cli/pkg/utils"
 )
 
@@ -23,8 +25,9 @@ const (
 	AponoVaultIntegrationType = "apono-vault"
 	DefaultVaultMount         = "apono-store"
 
-	cacheDirPermission  = 0o700
-	cacheFilePermission = 0o600
+	cacheDirPermission   = 0o700
+	cacheFilePermission  = 0o600
+	vaultCacheFilePrefix = "vault-"
 )
 
 type VaultCredentials struct {
@@ -48,8 +51,16 @@ func saveVaultCredentials(cacheDir string, integrationID string, creds *VaultCre
 		return fmt.Errorf("failed to marshal credentials: %w", err)
 	}
 
+	store, err := config.SecretStore()
+	if err != nil {
+		return err
+	}
+	if store != nil {
+		return store.Set(secrets.VaultCredentialsKey(integrationID), string(data))
+	}
+
 	encoded := base64.StdEncoding.EncodeToString(data)
-	filePath := filepath.Join(cacheDir, "vault-"+integrationID)
+	filePath := filepath.Join(cacheDir, vaultCacheFilePrefix+integrationID)
 
 	if err := os.WriteFile(filePath, []byte(encoded), cacheFilePermission); err != nil {
 		return fmt.Errorf("failed to write cache file: %w", err)
@@ -59,7 +70,26 @@ func saveVaultCredentials(cacheDir string, integrationID string, creds *VaultCre
 }
 
 func loadVaultCredentials(cacheDir string, integrationID string) (*VaultCredentials, error) {
-	filePath := filepath.Join(cacheDir, "vault-"+integrationID)
+	store, err := config.SecretStore()
+	if err != nil {
+		return nil, err
+	}
+	if store != nil {
+		data, getErr := store.Get(secrets.VaultCredentialsKey(integrationID))
+		if getErr == nil {
+			var creds VaultCredentials
+			if err = json.Unmarshal([]byte(data), &creds); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal credentials: %w", err)
+			}
+			return &creds, nil
+		}
+		// Credentials cached before the backend was set are still in the cache dir.
+		if !errors.Is(getErr, secrets.ErrNotFound) {
+			return nil, getErr
+		}
+	}
+
+	filePath := filepath.Join(cacheDir, vaultCacheFilePrefix+integrationID)
 
 	encoded, err := os.ReadFile(filePath) //nolint:gosec // path is built from fixed cache dir
 	if err != nil {
@@ -79,6 +109,13 @@ func loadVaultCredentials(cacheDir string, integrationID string) (*VaultCredenti
 	return &creds, nil
 }
 
+func removeVaultCredentials(cacheDir string, integrationID string) {
+	if store, err := config.SecretStore(); err == nil && store != nil {
+		_ = store.Delete(secrets.VaultCredentialsKey(integrationID))
+	}
+	_ = os.Remove(filepath.Join(cacheDir, vaultCacheFilePrefix+integrationID))
+}
+
 func ParseVaultPath(path string) (mount string, secretPath string, err error) {
 	idx := strings.IndexByte(path, '/')
 	if idx < 0 {
@@ -124,7 +161,7 @@ func ResolveVaultCredentials(ctx context.Context, client *aponoapi.AponoClient,
 	_, loginErr := VaultLogin(ctx, cached.VaultAddress, cached.Username, cached.Password)
 	if loginErr != nil {
 		if IsNotFoundError(loginErr) || IsForbiddenError(loginErr) || IsUnauthorizedError(loginErr) {
-			_ = os.Remove(filepath.Join(cacheDir, "vault-"+integrationID))
+			removeVaultCredentials(cacheDir, integrationID)
 			return nil, fmt.Errorf("vault credentials have expired; %s", resetMsg)
 		}
 
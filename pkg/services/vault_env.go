@@ -0,0 +1,145 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/apono-io/apono-cli/pkg/utils"
+)
+
+const (
+	VaultEnvExportFormat = "export"
+	VaultEnvDotenvFormat = "dotenv"
+	VaultEnvJSONFormat   = "json"
+)
+
+// VaultEnvOptions controls how secret keys are turned into environment
+// variable names. Renames are keyed by the original secret key and bypass
+// both the prefix and the upper-casing.
+type VaultEnvOptions struct {
+	Prefix    string
+	Renames   map[string]string
+	Uppercase bool
+}
+
+// VaultSecretsToEnv flattens the given secrets into environment variables.
+// When two secrets define the same variable, the later secret wins.
+func VaultSecretsToEnv(secrets []map[string]interface{}, opts VaultEnvOptions) (map[string]string, error) {
+	env := make(map[string]string)
+	for _, secret := range secrets {
+		for key, value := range secret {
+			name := VaultEnvVarName(key, opts)
+			if name == "" {
+				return nil, fmt.Errorf("secret key %q cannot be used as an environment variable name", key)
+			}
+
+			text, err := vaultEnvValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert secret key %q: %w", key, err)
+			}
+
+			env[name] = text
+		}
+	}
+
+	return env, nil
+}
+
+func VaultEnvVarName(key string, opts VaultEnvOptions) string {
+	if renamed, ok := opts.Renames[key]; ok {
+		return sanitizeEnvVarName(renamed)
+	}
+
+	name := opts.Prefix + key
+	if opts.Uppercase {
+		name = strings.ToUpper(name)
+	}
+
+	return sanitizeEnvVarName(name)
+}
+
+// ParseVaultEnvRenames parses rename rules in the format 'secret-key=ENV_NAME'.
+func ParseVaultEnvRenames(rules []string) (map[string]string, error) {
+	renames := make(map[string]string)
+	for _, rule := range rules {
+		key, name, found := strings.Cut(rule, "=")
+		if !found || key == "" || name == "" {
+			return nil, fmt.Errorf("invalid rename rule %q, expected format 'secret-key=ENV_NAME'", rule)
+		}
+
+		renames[key] = name
+	}
+
+	return renames, nil
+}
+
+func PrintVaultEnv(writer io.Writer, env map[string]string, format string) error {
+	if format == VaultEnvJSONFormat {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(env)
+	}
+
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		var line string
+		switch format {
+		case VaultEnvExportFormat:
+			line = fmt.Sprintf("export %s=%s", name, utils.ShellQuote(env[name]))
+		case VaultEnvDotenvFormat:
+			line = fmt.Sprintf("%s=%s", name, dotenvQuote(env[name]))
+		default:
+			return fmt.Errorf("unsupported env format %q, valid values are '%s', '%s' or '%s'", format, VaultEnvExportFormat, VaultEnvDotenvFormat, VaultEnvJSONFormat)
+		}
+
+		if _, err := fmt.Fprintln(writer, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func vaultEnvValue(value interface{}) (string, error) {
+	if text, ok := value.(string); ok {
+		return text, nil
+	}
+
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
+}
+
+func sanitizeEnvVarName(name string) string {
+	var builder strings.Builder
+	for i, r := range name {
+		switch {
+		case r == '_' || (r < unicode.MaxASCII && (unicode.IsLetter(r) || (unicode.IsDigit(r) && i > 0))):
+			builder.WriteRune(r)
+		case unicode.IsDigit(r):
+			builder.WriteRune('_')
+			builder.WriteRune(r)
+		default:
+			builder.WriteRune('_')
+		}
+	}
+
+	return builder.String()
+}
+
+func dotenvQuote(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "$", `\$`)
+	return `"` + replacer.Replace(value) + `"`
+}
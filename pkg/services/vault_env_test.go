@@ -0,0 +1,77 @@
+package services
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestVaultSecretsToEnv(t *testing.T) {
+	secrets := []map[string]interface{}{
+		{"username": "admin", "password": "p'ss", "port": float64(5432), "db-host": "db.internal"},
+		{"password": "override", "1st": "x"},
+	}
+	opts := VaultEnvOptions{
+		Prefix:    "APP_",
+		Renames:   map[string]string{"username": "PGUSER"},
+		Uppercase: true,
+	}
+
+	env, err := VaultSecretsToEnv(secrets, opts)
+	if err != nil {
+		t.Fatalf("VaultSecretsToEnv: %v", err)
+	}
+
+	want := map[string]string{
+		"PGUSER":       "admin",
+		"APP_PASSWORD": "override",
+		"APP_PORT":     "5432",
+		"APP_DB_HOST":  "db.internal",
+		"APP_1ST":      "x",
+	}
+	if len(env) != len(want) {
+		t.Fatalf("env = %v, want %v", env, want)
+	}
+	for name, value := range want {
+		if env[name] != value {
+			t.Errorf("env[%q] = %q, want %q", name, env[name], value)
+		}
+	}
+}
+
+func TestVaultEnvVarName_leadingDigit(t *testing.T) {
+	if got := VaultEnvVarName("1password", VaultEnvOptions{Uppercase: true}); got != "_1PASSWORD" {
+		t.Errorf("VaultEnvVarName = %q, want %q", got, "_1PASSWORD")
+	}
+}
+
+func TestParseVaultEnvRenames_invalid(t *testing.T) {
+	for _, rule := range []string{"missing-separator", "=NAME", "key="} {
+		if _, err := ParseVaultEnvRenames([]string{rule}); err == nil {
+			t.Errorf("ParseVaultEnvRenames(%q) succeeded, want error", rule)
+		}
+	}
+}
+
+func TestPrintVaultEnv(t *testing.T) {
+	env := map[string]string{"B": "it's", "A": "line1\nline2 $HOME"}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: VaultEnvExportFormat, want: "export A='line1\nline2 $HOME'\nexport B='it'\\''s'\n"},
+		{format: VaultEnvDotenvFormat, want: "A=\"line1\\nline2 \\$HOME\"\nB=\"it's\"\n"},
+		{format: VaultEnvJSONFormat, want: "{\n  \"A\": \"line1\\nline2 $HOME\",\n  \"B\": \"it's\"\n}\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := PrintVaultEnv(&buf, env, tt.format); err != nil {
+				t.Fatalf("PrintVaultEnv: %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("PrintVaultEnv(%s) = %q, want %q", tt.format, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	VaultFileDotenvFormat = "dotenv"
+	VaultFileJSONFormat   = "json"
+	VaultFileYamlFormat   = "yaml"
+)
+
+// VaultSecretTree maps secret paths, relative to a root path, to the secret
+// data. The empty path refers to the root secret itself.
+type VaultSecretTree map[string]map[string]interface{}
+
+// Paths returns the relative paths of the tree in sorted order.
+func (t VaultSecretTree) Paths() []string {
+	paths := make([]string, 0, len(t))
+	for p := range t {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
+
+func VaultFileFormatFromPath(filePath string) (string, error) {
+	base := strings.ToLower(filepath.Base(filePath))
+	switch {
+	case base == ".env" || strings.HasSuffix(base, ".env") || strings.HasSuffix(base, ".dotenv"):
+		return VaultFileDotenvFormat, nil
+	case strings.HasSuffix(base, ".json"):
+		return VaultFileJSONFormat, nil
+	case strings.HasSuffix(base, ".yaml") || strings.HasSuffix(base, ".yml"):
+		return VaultFileYamlFormat, nil
+	default:
+		return "", fmt.Errorf("cannot detect the format of %s, use the --format flag", filePath)
+	}
+}
+
+// ParseVaultSecretFile parses a dotenv, JSON or YAML file. A JSON or YAML
+// document whose values are all objects is read as a tree of secrets keyed by
+// path, any other document is read as a single secret.
+func ParseVaultSecretFile(content []byte, format string) (VaultSecretTree, error) {
+	var document map[string]interface{}
+	switch format {
+	case VaultFileDotenvFormat:
+		secret, err := parseDotenv(content)
+		if err != nil {
+			return nil, err
+		}
+		return VaultSecretTree{"": secret}, nil
+	case VaultFileJSONFormat:
+		if err := json.Unmarshal(content, &document); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %w", err)
+		}
+	case VaultFileYamlFormat:
+		if err := yaml.Unmarshal(content, &document); err != nil {
+			return nil, fmt.Errorf("invalid YAML: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format %q, valid values are '%s', '%s' or '%s'", format, VaultFileDotenvFormat, VaultFileJSONFormat, VaultFileYamlFormat)
+	}
+
+	if len(document) == 0 {
+		return nil, fmt.Errorf("no secrets found")
+	}
+
+	tree := make(VaultSecretTree)
+	for secretPath, value := range document {
+		secret, isObject := value.(map[string]interface{})
+		if !isObject {
+			return VaultSecretTree{"": document}, nil
+		}
+
+		tree[strings.Trim(secretPath, "/")] = secret
+	}
+
+	return tree, nil
+}
+
+// LoadVaultSecretDir reads every dotenv, JSON and YAML file under dir. The
+// path of each file, without its extension, becomes the secret path.
+func LoadVaultSecretDir(dir string) (VaultSecretTree, error) {
+	tree := make(VaultSecretTree)
+	err := filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		format, formatErr := VaultFileFormatFromPath(filePath)
+		if formatErr != nil {
+			return nil
+		}
+
+		content, err := os.ReadFile(filepath.Clean(filePath))
+		if err != nil {
+			return err
+		}
+
+		fileTree, err := ParseVaultSecretFile(content, format)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", filePath, err)
+		}
+
+		relativePath, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		filePrefix := strings.TrimSuffix(filepath.ToSlash(relativePath), filepath.Ext(relativePath))
+
+		for secretPath, secret := range fileTree {
+			tree[path.Join(filePrefix, secretPath)] = secret
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tree) == 0 {
+		return nil, fmt.Errorf("no dotenv, JSON or YAML files found in %s", dir)
+	}
+
+	return tree, nil
+}
+
+// EncodeVaultSecretTree encodes a tree in the given format. A tree holding
+// only the root secret is encoded as that secret, so it can be imported back
+// to any path.
+func EncodeVaultSecretTree(tree VaultSecretTree, format string) ([]byte, error) {
+	var document interface{} = tree
+	if secret, ok := tree[""]; ok && len(tree) == 1 {
+		document = secret
+	}
+
+	switch format {
+	case VaultFileJSONFormat:
+		content, err := json.MarshalIndent(document, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(content, '\n'), nil
+	case VaultFileYamlFormat:
+		return yaml.Marshal(document)
+	case VaultFileDotenvFormat:
+		secret, ok := tree[""]
+		if !ok || len(tree) != 1 {
+			return nil, fmt.Errorf("the dotenv format holds a single secret, use --output-dir to export %d secrets", len(tree))
+		}
+		return encodeDotenv(secret)
+	default:
+		return nil, fmt.Errorf("unsupported format %q, valid values are '%s', '%s' or '%s'", format, VaultFileDotenvFormat, VaultFileJSONFormat, VaultFileYamlFormat)
+	}
+}
+
+// WriteVaultSecretDir writes one file per secret under dir. The root secret,
+// if present, is written to a file named rootName. Secret paths come from the
+// server, so paths that would leave dir are rejected.
+func WriteVaultSecretDir(dir string, tree VaultSecretTree, format string, rootName string) ([]string, error) {
+	extension := "." + format
+	if format == VaultFileDotenvFormat {
+		extension = ".env"
+	}
+
+	secretPaths := tree.Paths()
+	filePaths := make([]string, len(secretPaths))
+	for i, secretPath := range secretPaths {
+		name := secretPath
+		if name == "" {
+			name = rootName
+		}
+
+		filePaths[i] = filepath.Join(dir, filepath.FromSlash(name)+extension)
+		rel, err := filepath.Rel(dir, filePaths[i])
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return nil, fmt.Errorf("secret path %q is outside of the export directory", secretPath)
+		}
+	}
+
+	var written []string
+	for i, secretPath := range secretPaths {
+		content, err := EncodeVaultSecretTree(VaultSecretTree{"": tree[secretPath]}, format)
+		if err != nil {
+			return nil, err
+		}
+
+		filePath := filePaths[i]
+		if err = os.MkdirAll(filepath.Dir(filePath), cacheDirPermission); err != nil {
+			return nil, err
+		}
+		if err = os.WriteFile(filePath, content, cacheFilePermission); err != nil {
+			return nil, err
+		}
+
+		written = append(written, filePath)
+	}
+
+	return written, nil
+}
+
+func parseDotenv(content []byte) (map[string]interface{}, error) {
+	secret := make(map[string]interface{})
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+		key, value, found := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid dotenv line %d: expected KEY=VALUE", lineNumber)
+		}
+
+		secret[key] = parseDotenvValue(strings.TrimSpace(value))
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("no secrets found")
+	}
+
+	return secret, nil
+}
+
+func parseDotenvValue(value string) string {
+	switch {
+	case len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'':
+		return value[1 : len(value)-1]
+	case len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"':
+		return unescapeDotenv(value[1 : len(value)-1])
+	default:
+		if comment := strings.Index(value, " #"); comment >= 0 {
+			value = strings.TrimSpace(value[:comment])
+		}
+		return value
+	}
+}
+
+func unescapeDotenv(value string) string {
+	var builder strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i == len(value)-1 {
+			builder.WriteByte(value[i])
+			continue
+		}
+
+		i++
+		switch value[i] {
+		case 'n':
+			builder.WriteByte('\n')
+		case 't':
+			builder.WriteByte('\t')
+		case 'r':
+			builder.WriteByte('\r')
+		case '\\', '"', '$':
+			builder.WriteByte(value[i])
+		default:
+			builder.WriteByte('\\')
+			builder.WriteByte(value[i])
+		}
+	}
+
+	return builder.String()
+}
+
+func encodeDotenv(secret map[string]interface{}) ([]byte, error) {
+	keys := make([]string, 0, len(secret))
+	for key := range secret {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for _, key := range keys {
+		value, err := vaultEnvValue(secret[key])
+		if err != nil {
+			return nil, err
+		}
+
+		fmt.Fprintf(&buf, "%s=%s\n", key, dotenvQuote(value))
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseVaultSecretFile_dotenv(t *testing.T) {
+	content := []byte(`# database
+export DB_USER=admin
+DB_PASSWORD="p\"ss\nword \$1"
+DB_HOST='db.internal' 
+DB_PORT=5432 # default port
+`)
+
+	tree, err := ParseVaultSecretFile(content, VaultFileDotenvFormat)
+	if err != nil {
+		t.Fatalf("ParseVaultSecretFile: %v", err)
+	}
+
+	want := VaultSecretTree{"": {
+		"DB_USER":     "admin",
+		"DB_PASSWORD": "p\"ss\nword $1",
+		"DB_HOST":     "db.internal",
+		"DB_PORT":     "5432",
+	}}
+	if !reflect.DeepEqual(tree, want) {
+		t.Errorf("ParseVaultSecretFile = %v, want %v", tree, want)
+	}
+}
+
+func TestParseVaultSecretFile_treeAndSingleSecret(t *testing.T) {
+	tree, err := ParseVaultSecretFile([]byte(`{"app/db": {"user": "a"}, "app/cache": {"url": "redis://"}}`), VaultFileJSONFormat)
+	if err != nil {
+		t.Fatalf("ParseVaultSecretFile(tree): %v", err)
+	}
+	if got := tree.Paths(); !reflect.DeepEqual(got, []string{"app/cache", "app/db"}) {
+		t.Errorf("tree paths = %v", got)
+	}
+
+	single, err := ParseVaultSecretFile([]byte("user: a\nport: 5432\n"), VaultFileYamlFormat)
+	if err != nil {
+		t.Fatalf("ParseVaultSecretFile(single): %v", err)
+	}
+	if got := single.Paths(); !reflect.DeepEqual(got, []string{""}) {
+		t.Errorf("single secret paths = %v, want the root path only", got)
+	}
+}
+
+func TestEncodeVaultSecretTree_roundTrip(t *testing.T) {
+	tree := VaultSecretTree{
+		"db":    {"user": "admin", "password": "s3cr3t"},
+		"cache": {"url": "redis://cache:6379"},
+	}
+
+	for _, format := range []string{VaultFileJSONFormat, VaultFileYamlFormat} {
+		content, err := EncodeVaultSecretTree(tree, format)
+		if err != nil {
+			t.Fatalf("EncodeVaultSecretTree(%s): %v", format, err)
+		}
+
+		decoded, err := ParseVaultSecretFile(content, format)
+		if err != nil {
+			t.Fatalf("ParseVaultSecretFile(%s): %v", format, err)
+		}
+		if !reflect.DeepEqual(decoded, tree) {
+			t.Errorf("%s round trip = %v, want %v", format, decoded, tree)
+		}
+	}
+
+	if _, err := EncodeVaultSecretTree(tree, VaultFileDotenvFormat); err == nil {
+		t.Error("EncodeVaultSecretTree(dotenv) of several secrets succeeded, want error")
+	}
+}
+
+func TestLoadVaultSecretDir(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"app/db.env":       "USER=admin\n",
+		"app/cache.json":   `{"url": "redis://"}`,
+		"shared/tls.yaml":  "cert: abc\n",
+		"shared/README.md": "ignored",
+	}
+	for name, content := range files {
+		filePath := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filePath), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tree, err := LoadVaultSecretDir(dir)
+	if err != nil {
+		t.Fatalf("LoadVaultSecretDir: %v", err)
+	}
+
+	want := VaultSecretTree{
+		"app/db":     {"USER": "admin"},
+		"app/cache":  {"url": "redis://"},
+		"shared/tls": {"cert": "abc"},
+	}
+	if !reflect.DeepEqual(tree, want) {
+		t.Errorf("LoadVaultSecretDir = %v, want %v", tree, want)
+	}
+}
+
+func TestPlanVaultWrites(t *testing.T) {
+	writes := []VaultWrite{
+		{Target: "apono-store/app/new"},
+		{Target: "apono-store/app/existing"},
+	}
+	exists := func(target string) (bool, error) {
+		return target == "apono-store/app/existing", nil
+	}
+
+	tests := []struct {
+		policy  VaultConflictPolicy
+		want    []string
+		wantErr bool
+	}{
+		{policy: VaultConflictSkip, want: []string{VaultPlanCreate, VaultPlanSkip}},
+		{policy: VaultConflictOverwrite, want: []string{VaultPlanCreate, VaultPlanOverwrite}},
+		{policy: VaultConflictFail, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			plan, err := planVaultWrites(writes, tt.policy, exists)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("planVaultWrites succeeded, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("planVaultWrites: %v", err)
+			}
+
+			var actions []string
+			for _, item := range plan {
+				actions = append(actions, item.Action)
+			}
+			if !reflect.DeepEqual(actions, tt.want) {
+				t.Errorf("actions = %v, want %v", actions, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteVaultSecretDir_rejectsPathsOutsideDir(t *testing.T) {
+	tests := []struct {
+		name       string
+		secretPath string
+		wantErr    bool
+	}{
+		{name: "nested", secretPath: "app/db"},
+		{name: "dots in a name", secretPath: "app/..db"},
+		{name: "parent", secretPath: "../escaped", wantErr: true},
+		{name: "nested parent", secretPath: "app/../../escaped", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := t.TempDir()
+			dir := filepath.Join(parent, "export")
+			tree := VaultSecretTree{"safe": {"A": "1"}, tt.secretPath: {"B": "2"}}
+
+			_, err := WriteVaultSecretDir(dir, tree, VaultFileDotenvFormat, "root")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WriteVaultSecretDir() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if _, statErr := os.Stat(filepath.Join(parent, "escaped.env")); !os.IsNotExist(statErr) {
+				t.Errorf("a file was written outside of the export directory")
+			}
+			if _, statErr := os.Stat(filepath.Join(dir, "safe.env")); tt.wantErr != os.IsNotExist(statErr) {
+				t.Errorf("safe.env written = %v, want %v", statErr == nil, !tt.wantErr)
+			}
+		})
+	}
+}
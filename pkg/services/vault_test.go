@@ -4,9 +4,13 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/apono-io/apono-cli/pkg/config"
+	"github.com/apono-io/apono-cli/pkg/secrets"
 )
 
 func TestSaveAndLoadVaultCredentials(t *testing.T) {
+	t.Setenv(config.SecretsBackendEnvVar, secrets.BackendPlaintext)
 	cacheDir := t.TempDir()
 	integrationID := "test-integration-123"
 
@@ -40,6 +44,7 @@ func TestSaveAndLoadVaultCredentials(t *testing.T) {
 }
 
 func TestSaveAndLoadVaultCredentials_WithMountName(t *testing.T) {
+	t.Setenv(config.SecretsBackendEnvVar, secrets.BackendPlaintext)
 	cacheDir := t.TempDir()
 	integrationID := "test-mount"
 
@@ -66,6 +71,7 @@ func TestSaveAndLoadVaultCredentials_WithMountName(t *testing.T) {
 }
 
 func TestLoadVaultCredentials_NotFound(t *testing.T) {
+	t.Setenv(config.SecretsBackendEnvVar, secrets.BackendPlaintext)
 	cacheDir := t.TempDir()
 
 	_, err := loadVaultCredentials(cacheDir, "nonexistent-integration")
@@ -75,6 +81,7 @@ func TestLoadVaultCredentials_NotFound(t *testing.T) {
 }
 
 func TestSaveVaultCredentials_CreatesDirectory(t *testing.T) {
+	t.Setenv(config.SecretsBackendEnvVar, secrets.BackendPlaintext)
 	baseDir := t.TempDir()
 	nestedDir := filepath.Join(baseDir, "nested", "deep", "cache")
 	integrationID := "dir-test"
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/gosuri/uitable"
+)
+
+type VaultConflictPolicy string
+
+const (
+	VaultConflictSkip      VaultConflictPolicy = "skip"
+	VaultConflictOverwrite VaultConflictPolicy = "overwrite"
+	VaultConflictFail      VaultConflictPolicy = "fail"
+
+	VaultPlanCreate    = "create"
+	VaultPlanOverwrite = "overwrite"
+	VaultPlanSkip      = "skip"
+)
+
+// VaultWrite is a secret to be written to Target. Source is informational
+// and holds the file or vault path the data came from.
+type VaultWrite struct {
+	Source string
+	Target string
+	Data   map[string]interface{}
+}
+
+type VaultPlanItem struct {
+	VaultWrite
+	Action string
+}
+
+func ParseVaultConflictPolicy(value string) (VaultConflictPolicy, error) {
+	switch policy := VaultConflictPolicy(value); policy {
+	case VaultConflictSkip, VaultConflictOverwrite, VaultConflictFail:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid conflict policy %q, valid values are '%s', '%s' or '%s'", value, VaultConflictSkip, VaultConflictOverwrite, VaultConflictFail)
+	}
+}
+
+// JoinVaultPath joins a mount-prefixed root path with a path relative to it.
+func JoinVaultPath(root string, relativePath string) string {
+	if relativePath == "" {
+		return strings.TrimRight(root, "/")
+	}
+
+	return path.Join(root, relativePath)
+}
+
+// ParseVaultPrefix is like ParseVaultPath but also accepts a bare mount, for
+// commands that operate on a whole subtree.
+func ParseVaultPrefix(vaultPath string) (mount string, prefix string) {
+	mount, prefix, _ = strings.Cut(strings.Trim(vaultPath, "/"), "/")
+	return mount, prefix
+}
+
+// ReadSubtree reads every secret under prefix. When prefix is a single secret
+// rather than a folder, the tree holds it under the empty path.
+func (vc *VaultClient) ReadSubtree(ctx context.Context, mount, prefix string) (VaultSecretTree, error) {
+	prefix = strings.Trim(prefix, "/")
+
+	var secretPaths []string
+	var err error
+	if prefix == "" {
+		secretPaths, err = vc.ListSecrets(ctx, mount)
+	} else {
+		secretPaths, err = vc.listSecretsRecursive(ctx, mount, prefix+"/")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tree := make(VaultSecretTree)
+	if len(secretPaths) == 0 && prefix != "" {
+		secret, readErr := vc.ReadSecret(ctx, mount, prefix)
+		if readErr != nil {
+			return nil, readErr
+		}
+
+		tree[""] = secret
+		return tree, nil
+	}
+
+	for _, secretPath := range secretPaths {
+		secret, readErr := vc.ReadSecret(ctx, mount, secretPath)
+		if readErr != nil {
+			return nil, readErr
+		}
+
+		relativePath := secretPath
+		if prefix != "" {
+			relativePath = strings.TrimPrefix(secretPath, prefix+"/")
+		}
+		tree[relativePath] = secret
+	}
+
+	return tree, nil
+}
+
+// PlanWrites decides what happens to each write according to the conflict
+// policy. Nothing is written; with the fail policy an existing target aborts
+// the whole plan.
+func (vc *VaultClient) PlanWrites(ctx context.Context, writes []VaultWrite, policy VaultConflictPolicy) ([]VaultPlanItem, error) {
+	return planVaultWrites(writes, policy, func(target string) (bool, error) {
+		mount, secretPath, err := ParseVaultPath(target)
+		if err != nil {
+			return false, err
+		}
+
+		return vc.SecretExists(ctx, mount, secretPath)
+	})
+}
+
+func (vc *VaultClient) ApplyPlan(ctx context.Context, plan []VaultPlanItem) error {
+	for _, item := range plan {
+		if item.Action == VaultPlanSkip {
+			continue
+		}
+
+		mount, secretPath, err := ParseVaultPath(item.Target)
+		if err != nil {
+			return err
+		}
+
+		if err = vc.WriteSecret(ctx, mount, secretPath, item.Data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", item.Target, err)
+		}
+	}
+
+	return nil
+}
+
+func planVaultWrites(writes []VaultWrite, policy VaultConflictPolicy, exists func(target string) (bool, error)) ([]VaultPlanItem, error) {
+	plan := make([]VaultPlanItem, 0, len(writes))
+	for _, write := range writes {
+		targetExists, err := exists(write.Target)
+		if err != nil {
+			return nil, err
+		}
+
+		action := VaultPlanCreate
+		if targetExists {
+			switch policy {
+			case VaultConflictSkip:
+				action = VaultPlanSkip
+			case VaultConflictOverwrite:
+				action = VaultPlanOverwrite
+			default:
+				return nil, fmt.Errorf("secret %s already exists; use --conflict=skip or --conflict=overwrite", write.Target)
+			}
+		}
+
+		plan = append(plan, VaultPlanItem{VaultWrite: write, Action: action})
+	}
+
+	return plan, nil
+}
+
+func PrintVaultPlan(writer io.Writer, plan []VaultPlanItem, dryRun bool) error {
+	table := uitable.New()
+	table.AddRow("ACTION", "SOURCE", "TARGET")
+
+	counts := make(map[string]int)
+	for _, item := range plan {
+		counts[item.Action]++
+		table.AddRow(coloredPlanAction(item.Action), item.Source, item.Target)
+	}
+
+	if _, err := fmt.Fprintln(writer, table); err != nil {
+		return err
+	}
+
+	summary := fmt.Sprintf("\n%d created, %d overwritten, %d skipped", counts[VaultPlanCreate], counts[VaultPlanOverwrite], counts[VaultPlanSkip])
+	if dryRun {
+		summary = fmt.Sprintf("\n%d to create, %d to overwrite, %d to skip (dry run, no changes were made)", counts[VaultPlanCreate], counts[VaultPlanOverwrite], counts[VaultPlanSkip])
+	}
+
+	_, err := fmt.Fprintln(writer, summary)
+	return err
+}
+
+func coloredPlanAction(action string) string {
+	switch action {
+	case VaultPlanCreate:
+		return color.Green.Sprint(action)
+	case VaultPlanOverwrite:
+		return color.Yellow.Sprint(action)
+	default:
+		return color.Gray.Sprint(action)
+	}
+}
@@ -0,0 +1,315 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gookit/color"
+	vclient "github.com/hashicorp/vault-client-go"
+	"github.com/hashicorp/vault-client-go/schema"
+)
+
+const (
+	VaultDiffAdded   = "added"
+	VaultDiffRemoved = "removed"
+	VaultDiffChanged = "changed"
+
+	maskedSecretValue = "********"
+)
+
+type VaultSecretMetadata struct {
+	Path           string               `json:"path" yaml:"path"`
+	CurrentVersion int                  `json:"current_version" yaml:"current_version"`
+	OldestVersion  int                  `json:"oldest_version" yaml:"oldest_version"`
+	MaxVersions    int                  `json:"max_versions" yaml:"max_versions"`
+	CreatedTime    time.Time            `json:"created_time" yaml:"created_time"`
+	UpdatedTime    time.Time            `json:"updated_time" yaml:"updated_time"`
+	Versions       []VaultSecretVersion `json:"versions" yaml:"versions"`
+}
+
+type VaultSecretVersion struct {
+	Version      int        `json:"version" yaml:"version"`
+	CreatedTime  time.Time  `json:"created_time" yaml:"created_time"`
+	DeletionTime *time.Time `json:"deletion_time,omitempty" yaml:"deletion_time,omitempty"`
+	Destroyed    bool       `json:"destroyed" yaml:"destroyed"`
+	Current      bool       `json:"current" yaml:"current"`
+}
+
+type VaultSecretChange struct {
+	Key      string `json:"key" yaml:"key"`
+	Change   string `json:"change" yaml:"change"`
+	OldValue string `json:"old_value,omitempty" yaml:"old_value,omitempty"`
+	NewValue string `json:"new_value,omitempty" yaml:"new_value,omitempty"`
+}
+
+// State describes whether the version data can still be read.
+func (v VaultSecretVersion) State() string {
+	switch {
+	case v.Destroyed:
+		return "destroyed"
+	case v.DeletionTime != nil:
+		return "deleted"
+	default:
+		return "active"
+	}
+}
+
+func (vc *VaultClient) ReadSecretMetadata(ctx context.Context, mount, secretPath string) (*VaultSecretMetadata, error) {
+	resp, err := vc.api.Secrets.KvV2ReadMetadata(ctx, secretPath, vclient.WithMountPath(mount))
+	if err != nil {
+		if IsNotFoundError(err) {
+			return nil, fmt.Errorf("secret %q not found in mount %q", secretPath, mount)
+		}
+
+		return nil, fmt.Errorf("vault read metadata failed: %w", err)
+	}
+
+	if resp == nil {
+		return nil, fmt.Errorf("vault read metadata returned empty response")
+	}
+
+	versions, err := parseVaultVersions(resp.Data.Versions, int(resp.Data.CurrentVersion))
+	if err != nil {
+		return nil, err
+	}
+
+	return &VaultSecretMetadata{
+		Path:           mount + "/" + secretPath,
+		CurrentVersion: int(resp.Data.CurrentVersion),
+		OldestVersion:  int(resp.Data.OldestVersion),
+		MaxVersions:    int(resp.Data.MaxVersions),
+		CreatedTime:    resp.Data.CreatedTime,
+		UpdatedTime:    resp.Data.UpdatedTime,
+		Versions:       versions,
+	}, nil
+}
+
+// ReadSecretVersion reads a specific version of a secret. Version 0 reads
+// the latest version.
+func (vc *VaultClient) ReadSecretVersion(ctx context.Context, mount, secretPath string, version int) (map[string]interface{}, error) {
+	if version == 0 {
+		return vc.ReadSecret(ctx, mount, secretPath)
+	}
+
+	resp, err := vc.api.Secrets.KvV2Read(ctx, secretPath,
+		vclient.WithMountPath(mount),
+		vclient.WithQueryParameters(url.Values{"version": {strconv.Itoa(version)}}),
+	)
+	if err != nil {
+		if IsNotFoundError(err) {
+			return nil, fmt.Errorf("version %d of secret %q not found in mount %q", version, secretPath, mount)
+		}
+
+		return nil, fmt.Errorf("vault read failed: %w", err)
+	}
+
+	if resp == nil || resp.Data.Data == nil {
+		return nil, fmt.Errorf("version %d of secret %q was deleted or destroyed", version, secretPath)
+	}
+
+	return resp.Data.Data, nil
+}
+
+// RollbackSecret writes the data of the given version as a new version. The
+// write is checked against the current version so concurrent updates are not
+// silently overwritten.
+func (vc *VaultClient) RollbackSecret(ctx context.Context, mount, secretPath string, version int) (int, error) {
+	metadata, err := vc.ReadSecretMetadata(ctx, mount, secretPath)
+	if err != nil {
+		return 0, err
+	}
+
+	if version == metadata.CurrentVersion {
+		return 0, fmt.Errorf("version %d is already the current version", version)
+	}
+
+	data, err := vc.ReadSecretVersion(ctx, mount, secretPath, version)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := vc.api.Secrets.KvV2Write(ctx, secretPath, schema.KvV2WriteRequest{
+		Data:    data,
+		Options: map[string]interface{}{"cas": metadata.CurrentVersion},
+	}, vclient.WithMountPath(mount))
+	if err != nil {
+		return 0, fmt.Errorf("vault write failed: %w", err)
+	}
+
+	return int(resp.Data.Version), nil
+}
+
+// SoftDeleteSecret marks versions as deleted, keeping their data so they can
+// be undeleted. Without versions the latest version is deleted.
+func (vc *VaultClient) SoftDeleteSecret(ctx context.Context, mount, secretPath string, versions []int) error {
+	var err error
+	if len(versions) == 0 {
+		_, err = vc.api.Secrets.KvV2Delete(ctx, secretPath, vclient.WithMountPath(mount))
+	} else {
+		_, err = vc.api.Secrets.KvV2DeleteVersions(ctx, secretPath, schema.KvV2DeleteVersionsRequest{
+			Versions: toInt32Versions(versions),
+		}, vclient.WithMountPath(mount))
+	}
+
+	return vaultVersionsOperationError("delete", secretPath, mount, err)
+}
+
+func (vc *VaultClient) UndeleteSecret(ctx context.Context, mount, secretPath string, versions []int) error {
+	_, err := vc.api.Secrets.KvV2UndeleteVersions(ctx, secretPath, schema.KvV2UndeleteVersionsRequest{
+		Versions: toInt32Versions(versions),
+	}, vclient.WithMountPath(mount))
+
+	return vaultVersionsOperationError("undelete", secretPath, mount, err)
+}
+
+// DestroySecret permanently removes the data of the given versions.
+func (vc *VaultClient) DestroySecret(ctx context.Context, mount, secretPath string, versions []int) error {
+	_, err := vc.api.Secrets.KvV2DestroyVersions(ctx, secretPath, schema.KvV2DestroyVersionsRequest{
+		Versions: toInt32Versions(versions),
+	}, vclient.WithMountPath(mount))
+
+	return vaultVersionsOperationError("destroy", secretPath, mount, err)
+}
+
+// DiffVaultSecrets returns the keys that differ between two versions of a
+// secret, sorted by key.
+func DiffVaultSecrets(oldData, newData map[string]interface{}) []VaultSecretChange {
+	var changes []VaultSecretChange
+	for key, oldValue := range oldData {
+		newValue, exists := newData[key]
+		switch {
+		case !exists:
+			changes = append(changes, VaultSecretChange{Key: key, Change: VaultDiffRemoved, OldValue: secretValueText(oldValue)})
+		case !reflect.DeepEqual(oldValue, newValue):
+			changes = append(changes, VaultSecretChange{Key: key, Change: VaultDiffChanged, OldValue: secretValueText(oldValue), NewValue: secretValueText(newValue)})
+		}
+	}
+
+	for key, newValue := range newData {
+		if _, exists := oldData[key]; !exists {
+			changes = append(changes, VaultSecretChange{Key: key, Change: VaultDiffAdded, NewValue: secretValueText(newValue)})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+
+	return changes
+}
+
+// MaskVaultSecretChanges hides the values of the changes, keeping only which
+// keys were added, removed or changed.
+func MaskVaultSecretChanges(changes []VaultSecretChange) []VaultSecretChange {
+	masked := make([]VaultSecretChange, len(changes))
+	for i, change := range changes {
+		masked[i] = change
+		if change.OldValue != "" {
+			masked[i].OldValue = maskedSecretValue
+		}
+		if change.NewValue != "" {
+			masked[i].NewValue = maskedSecretValue
+		}
+	}
+
+	return masked
+}
+
+func PrintVaultSecretDiff(writer io.Writer, changes []VaultSecretChange) error {
+	if len(changes) == 0 {
+		_, err := fmt.Fprintln(writer, "No differences")
+		return err
+	}
+
+	for _, change := range changes {
+		var line string
+		switch change.Change {
+		case VaultDiffAdded:
+			line = color.Green.Sprintf("+ %s: %s", change.Key, change.NewValue)
+		case VaultDiffRemoved:
+			line = color.Red.Sprintf("- %s: %s", change.Key, change.OldValue)
+		default:
+			line = color.Yellow.Sprintf("~ %s: %s -> %s", change.Key, change.OldValue, change.NewValue)
+		}
+
+		if _, err := fmt.Fprintln(writer, line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func parseVaultVersions(rawVersions map[string]interface{}, currentVersion int) ([]VaultSecretVersion, error) {
+	versions := make([]VaultSecretVersion, 0, len(rawVersions))
+	for versionText, rawVersion := range rawVersions {
+		number, err := strconv.Atoi(versionText)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q in secret metadata", versionText)
+		}
+
+		version := VaultSecretVersion{Version: number, Current: number == currentVersion}
+		if fields, ok := rawVersion.(map[string]interface{}); ok {
+			version.CreatedTime = parseVaultTime(fields["created_time"])
+			if deletionTime := parseVaultTime(fields["deletion_time"]); !deletionTime.IsZero() {
+				version.DeletionTime = &deletionTime
+			}
+			version.Destroyed, _ = fields["destroyed"].(bool)
+		}
+
+		versions = append(versions, version)
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Version > versions[j].Version
+	})
+
+	return versions, nil
+}
+
+func parseVaultTime(value interface{}) time.Time {
+	text, _ := value.(string)
+	parsed, err := time.Parse(time.RFC3339Nano, text)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return parsed
+}
+
+func secretValueText(value interface{}) string {
+	text, err := vaultEnvValue(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return text
+}
+
+func toInt32Versions(versions []int) []int32 {
+	result := make([]int32, len(versions))
+	for i, version := range versions {
+		result[i] = int32(version) //nolint:gosec // secret versions are small positive numbers
+	}
+
+	return result
+}
+
+func vaultVersionsOperationError(operation, secretPath, mount string, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case IsNotFoundError(err):
+		return fmt.Errorf("secret %q not found in mount %q", secretPath, mount)
+	case IsForbiddenError(err):
+		return fmt.Errorf("vault %s is not allowed on mount %q: %w", operation, mount, err)
+	default:
+		return fmt.Errorf("vault %s failed: %w", operation, err)
+	}
+}
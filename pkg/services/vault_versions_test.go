@@ -0,0 +1,64 @@
+package services
+
+import (
+	"testing"
+)
+
+func TestDiffVaultSecrets(t *testing.T) {
+	oldData := map[string]interface{}{"user": "admin", "password": "old", "port": float64(5432)}
+	newData := map[string]interface{}{"user": "admin", "password": "new", "host": "db"}
+
+	changes := DiffVaultSecrets(oldData, newData)
+	want := []VaultSecretChange{
+		{Key: "host", Change: VaultDiffAdded, NewValue: "db"},
+		{Key: "password", Change: VaultDiffChanged, OldValue: "old", NewValue: "new"},
+		{Key: "port", Change: VaultDiffRemoved, OldValue: "5432"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("DiffVaultSecrets() = %+v, want %+v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("changes[%d] = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+
+	for _, change := range MaskVaultSecretChanges(changes) {
+		if change.OldValue == "old" || change.NewValue == "new" || change.NewValue == "db" || change.OldValue == "5432" {
+			t.Errorf("masked change %+v still holds a value", change)
+		}
+	}
+}
+
+func TestParseVaultVersions(t *testing.T) {
+	raw := map[string]interface{}{
+		"1": map[string]interface{}{"created_time": "2024-01-01T10:00:00.123Z", "deletion_time": "", "destroyed": false},
+		"2": map[string]interface{}{"created_time": "2024-01-02T10:00:00Z", "deletion_time": "2024-01-03T10:00:00Z", "destroyed": false},
+		"3": map[string]interface{}{"created_time": "2024-01-04T10:00:00Z", "deletion_time": "", "destroyed": true},
+	}
+
+	versions, err := parseVaultVersions(raw, 3)
+	if err != nil {
+		t.Fatalf("parseVaultVersions() error = %v", err)
+	}
+
+	wantStates := []string{"destroyed", "deleted", "active"}
+	for i, version := range versions {
+		if version.Version != 3-i {
+			t.Errorf("versions[%d].Version = %d, want %d", i, version.Version, 3-i)
+		}
+		if version.State() != wantStates[i] {
+			t.Errorf("versions[%d].State() = %q, want %q", i, version.State(), wantStates[i])
+		}
+		if version.CreatedTime.IsZero() {
+			t.Errorf("versions[%d].CreatedTime was not parsed", i)
+		}
+	}
+	if !versions[0].Current || versions[1].Current {
+		t.Error("only version 3 should be current")
+	}
+
+	if _, err = parseVaultVersions(map[string]interface{}{"x": nil}, 1); err == nil {
+		t.Error("parseVaultVersions() with an invalid version should fail")
+	}
+}
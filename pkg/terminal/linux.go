@@ -0,0 +1,91 @@
+package terminal
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	binBash = "/bin/bash"
+
+	terminalEnvVar = "TERMINAL"
+
+	// Emulators like konsole or kitty stay in the foreground until their window
+	// is closed, so they are detached to let the launcher return right away.
+	linuxDetachedLaunchTemplate = "nohup %s >/dev/null 2>&1 &"
+)
+
+// linuxEmulator describes how to run a command in a new window of a terminal
+// emulator. Args are placed between the binary and the command to run.
+type linuxEmulator struct {
+	name string
+	args []string
+}
+
+// linuxEmulators is ordered by preference; x-terminal-emulator is the Debian
+// alternatives fallback and comes last.
+var linuxEmulators = []linuxEmulator{
+	{name: "gnome-terminal", args: []string{"--"}},
+	{name: "konsole", args: []string{"-e"}},
+	{name: "kitty"},
+	{name: "alacritty", args: []string{"-e"}},
+	{name: "wezterm", args: []string{"start", "--"}},
+	{name: "x-terminal-emulator", args: []string{"-e"}},
+}
+
+func buildLinuxLaunchCommand(command string) (string, error) {
+	emulator, err := findLinuxEmulator(exec.LookPath)
+	if err != nil {
+		return "", err
+	}
+
+	scriptPath, err := writeLaunchScript(bashLaunchScriptTemplate, command)
+	if err != nil {
+		return "", fmt.Errorf("write launch script: %w", err)
+	}
+
+	return buildLinuxEmulatorLaunchCommand(emulator, bashPath(), scriptPath), nil
+}
+
+// findLinuxEmulator returns the emulator set in $TERMINAL when it is one we
+// know how to drive, otherwise the first known emulator on $PATH.
+func findLinuxEmulator(lookPath func(string) (string, error)) (linuxEmulator, error) {
+	if preferred := os.Getenv(terminalEnvVar); preferred != "" {
+		for _, emulator := range linuxEmulators {
+			if emulator.name != filepath.Base(preferred) {
+				continue
+			}
+			if _, err := lookPath(emulator.name); err == nil {
+				return emulator, nil
+			}
+		}
+	}
+
+	for _, emulator := range linuxEmulators {
+		if _, err := lookPath(emulator.name); err == nil {
+			return emulator, nil
+		}
+	}
+
+	names := make([]string, len(linuxEmulators))
+	for i, emulator := range linuxEmulators {
+		names[i] = emulator.name
+	}
+	return linuxEmulator{}, fmt.Errorf("no supported terminal emulator found, install one of: %s", strings.Join(names, ", "))
+}
+
+func buildLinuxEmulatorLaunchCommand(emulator linuxEmulator, shell, scriptPath string) string {
+	parts := append([]string{emulator.name}, emulator.args...)
+	parts = append(parts, shell, "-il", scriptPath)
+	return fmt.Sprintf(linuxDetachedLaunchTemplate, strings.Join(parts, " "))
+}
+
+func bashPath() string {
+	if path, err := exec.LookPath("bash"); err == nil {
+		return path
+	}
+	return binBash
+}
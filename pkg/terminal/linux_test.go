@@ -0,0 +1,85 @@
+package terminal
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func fakeLookPath(installed ...string) func(string) (string, error) {
+	return func(name string) (string, error) {
+		for _, bin := range installed {
+			if bin == name {
+				return "/usr/bin/" + name, nil
+			}
+		}
+		return "", errors.New("not found")
+	}
+}
+
+func TestFindLinuxEmulator_prefersListOrder(t *testing.T) {
+	t.Setenv(terminalEnvVar, "")
+
+	emulator, err := findLinuxEmulator(fakeLookPath("x-terminal-emulator", "kitty", "konsole"))
+	if err != nil {
+		t.Fatalf("findLinuxEmulator: %v", err)
+	}
+	if emulator.name != "konsole" {
+		t.Errorf("emulator = %q, want konsole", emulator.name)
+	}
+}
+
+func TestFindLinuxEmulator_honorsTerminalEnv(t *testing.T) {
+	t.Setenv(terminalEnvVar, "/usr/bin/alacritty")
+
+	emulator, err := findLinuxEmulator(fakeLookPath("gnome-terminal", "alacritty"))
+	if err != nil {
+		t.Fatalf("findLinuxEmulator: %v", err)
+	}
+	if emulator.name != "alacritty" {
+		t.Errorf("emulator = %q, want alacritty", emulator.name)
+	}
+}
+
+func TestFindLinuxEmulator_ignoresUnknownTerminalEnv(t *testing.T) {
+	t.Setenv(terminalEnvVar, "xterm")
+
+	emulator, err := findLinuxEmulator(fakeLookPath("wezterm"))
+	if err != nil {
+		t.Fatalf("findLinuxEmulator: %v", err)
+	}
+	if emulator.name != "wezterm" {
+		t.Errorf("emulator = %q, want wezterm", emulator.name)
+	}
+}
+
+func TestFindLinuxEmulator_noneInstalled(t *testing.T) {
+	t.Setenv(terminalEnvVar, "")
+
+	_, err := findLinuxEmulator(fakeLookPath())
+	if err == nil || !strings.Contains(err.Error(), "gnome-terminal") {
+		t.Errorf("expected error listing supported emulators, got %v", err)
+	}
+}
+
+func TestBuildLinuxEmulatorLaunchCommand_format(t *testing.T) {
+	tests := map[string]string{
+		"gnome-terminal":      "gnome-terminal -- /bin/bash -il /tmp/foo.sh",
+		"konsole":             "konsole -e /bin/bash -il /tmp/foo.sh",
+		"kitty":               "kitty /bin/bash -il /tmp/foo.sh",
+		"alacritty":           "alacritty -e /bin/bash -il /tmp/foo.sh",
+		"wezterm":             "wezterm start -- /bin/bash -il /tmp/foo.sh",
+		"x-terminal-emulator": "x-terminal-emulator -e /bin/bash -il /tmp/foo.sh",
+	}
+	for _, emulator := range linuxEmulators {
+		want, ok := tests[emulator.name]
+		if !ok {
+			t.Errorf("missing expectation for %q", emulator.name)
+			continue
+		}
+		got := buildLinuxEmulatorLaunchCommand(emulator, binBash, "/tmp/foo.sh")
+		if got != "nohup "+want+" >/dev/null 2>&1 &" {
+			t.Errorf("%s: got %q, want %q detached", emulator.name, got, want)
+		}
+	}
+}
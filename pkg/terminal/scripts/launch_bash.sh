@@ -0,0 +1,4 @@
+#!/bin/bash
+rm -- "$0"
+__APONO_COMMAND__
+exec bash -l
@@ -6,9 +6,12 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	"github.com/mattn/go-isatty"
+
+	"github.com/apono-io/apono-cli/pkg/utils"
 )
 
 const (
@@ -26,6 +29,9 @@ const (
 //go:embed scripts/launch.sh
 var launchScriptTemplate string
 
+//go:embed scripts/launch_bash.sh
+var bashLaunchScriptTemplate string
+
 func IsRunning(in io.Reader) bool {
 	f, ok := in.(*os.File)
 	if !ok {
@@ -34,8 +40,15 @@ func IsRunning(in io.Reader) bool {
 	return isatty.IsTerminal(f.Fd())
 }
 
+// BuildLaunchCommand returns a shell command that opens a new terminal window
+// running the given command. macOS uses iTerm or Terminal.app, Linux uses the
+// first terminal emulator found on $PATH.
 func BuildLaunchCommand(command string) (string, error) {
-	scriptPath, err := writeLaunchScript(command)
+	if runtime.GOOS == utils.LinuxOS {
+		return buildLinuxLaunchCommand(command)
+	}
+
+	scriptPath, err := writeLaunchScript(launchScriptTemplate, command)
 	if err != nil {
 		return "", fmt.Errorf("write launch script: %w", err)
 	}
@@ -45,18 +58,24 @@ func BuildLaunchCommand(command string) (string, error) {
 	return buildTerminalAppLaunchCommand(scriptPath), nil
 }
 
-func writeLaunchScriptTo(w io.Writer, command string) error {
-	body := strings.ReplaceAll(launchScriptTemplate, launchCommandPlaceholder, command)
+// WriteBashLaunchScript writes a self-deleting bash script that runs the
+// command and then keeps a login shell open, and returns its path.
+func WriteBashLaunchScript(command string) (string, error) {
+	return writeLaunchScript(bashLaunchScriptTemplate, command)
+}
+
+func writeLaunchScriptTo(w io.Writer, template, command string) error {
+	body := strings.ReplaceAll(template, launchCommandPlaceholder, command)
 	_, err := io.WriteString(w, body)
 	return err
 }
 
-func writeLaunchScript(command string) (string, error) {
+func writeLaunchScript(template, command string) (string, error) {
 	f, err := os.CreateTemp("", "apono-launch-*.sh")
 	if err != nil {
 		return "", err
 	}
-	if err := writeLaunchScriptTo(f, command); err != nil {
+	if err := writeLaunchScriptTo(f, template, command); err != nil {
 		_ = f.Close()
 		_ = os.Remove(f.Name())
 		return "", err
@@ -27,7 +27,7 @@ func TestIsRunning_nonFile_returnsFalse(t *testing.T) {
 
 func TestWriteLaunchScriptTo_includesShebangSelfDeleteAndKeepAlive(t *testing.T) {
 	var buf bytes.Buffer
-	if err := writeLaunchScriptTo(&buf, `echo hi`); err != nil {
+	if err := writeLaunchScriptTo(&buf, launchScriptTemplate, `echo hi`); err != nil {
 		t.Fatalf("writeLaunchScriptTo: %v", err)
 	}
 	got := buf.String()
@@ -46,7 +46,7 @@ func TestWriteLaunchScriptTo_includesShebangSelfDeleteAndKeepAlive(t *testing.T)
 func TestWriteLaunchScriptTo_passesArbitraryCommandVerbatim(t *testing.T) {
 	command := `psql "host=foo user=bar password='quux'" -c "select * from t;"`
 	var buf bytes.Buffer
-	if err := writeLaunchScriptTo(&buf, command); err != nil {
+	if err := writeLaunchScriptTo(&buf, launchScriptTemplate, command); err != nil {
 		t.Fatalf("writeLaunchScriptTo: %v", err)
 	}
 	if !strings.Contains(buf.String(), command) {
@@ -55,7 +55,7 @@ func TestWriteLaunchScriptTo_passesArbitraryCommandVerbatim(t *testing.T) {
 }
 
 func TestWriteLaunchScript_returnsExistingPath(t *testing.T) {
-	path, err := writeLaunchScript(`true`)
+	path, err := writeLaunchScript(launchScriptTemplate, `true`)
 	if err != nil {
 		t.Fatalf("writeLaunchScript: %v", err)
 	}
@@ -83,3 +83,21 @@ func TestBuildITermLaunchCommand_format(t *testing.T) {
 		}
 	}
 }
+
+func TestWriteLaunchScriptTo_bashTemplate(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeLaunchScriptTo(&buf, bashLaunchScriptTemplate, `echo hi`); err != nil {
+		t.Fatalf("writeLaunchScriptTo: %v", err)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		"#!/bin/bash",
+		`rm -- "$0"`,
+		"echo hi",
+		"exec bash -l",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("script body missing %q, got:\n%s", want, got)
+		}
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	"github.com/apono-io/apono-cli/pkg/logshipping"
+	"github.com/apono-io/apono-cli/pkg/utils"
 )
 
 const (
@@ -27,8 +29,17 @@ type LogLine struct {
 }
 
 // HandlerLogPath returns the file the apono:// handler script writes its trace
-// to — the same directory that holds the handler bundle.
+// to — the same directory that holds the handler bundle, or the handler script
+// on Linux.
 func HandlerLogPath() (string, error) {
+	if runtime.GOOS == utils.LinuxOS {
+		dataHome, err := xdgDataHome()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dataHome, xdgDataParentDir, handlerLogFileName), nil
+	}
+
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
@@ -3,6 +3,7 @@ package urihandler
 import (
 	"context"
 	_ "embed"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -33,6 +34,8 @@ const (
 	handlerScriptPerm   os.FileMode = 0o600
 )
 
+var errUnsupportedPlatform = errors.New("access-handler is only supported on macOS and Linux")
+
 const lsregisterPath = "/System/Library/Frameworks/CoreServices.framework/Frameworks/LaunchServices.framework/Support/lsregister"
 
 const urlTypesValue = `<dict><key>CFBundleURLName</key><string>` + urlSchemeName +
@@ -50,8 +53,9 @@ var infoPlistEntries = []struct {
 	{"CFBundleURLTypes", "-array", urlTypesValue},
 }
 
-// EnsureRegistered builds and registers the apono:// handler bundle when missing.
-// No-op on non-macOS, in non-interactive contexts, or when a bundle is already on disk.
+// EnsureRegistered builds and registers the apono:// handler when missing.
+// No-op on unsupported platforms, in non-interactive contexts, on Linux without
+// a graphical session, or when a handler is already on disk.
 func EnsureRegistered(in io.Reader, out io.Writer) error {
 	if !urlHandlerSupported() {
 		return nil
@@ -59,22 +63,30 @@ func EnsureRegistered(in io.Reader, out io.Writer) error {
 	if !terminal.IsRunning(in) {
 		return nil
 	}
-	if bundleExists() {
+	if runtime.GOOS == utils.LinuxOS && !xdgSessionAvailable() {
+		return nil
+	}
+	if handlerExists() {
 		return nil
 	}
 	return Register(out)
 }
 
-// Register builds the apono:// handler bundle and registers it with LaunchServices.
+// Register builds the apono:// handler and registers it with LaunchServices
+// on macOS, or as an XDG desktop entry on Linux.
 func Register(out io.Writer) error {
 	if !urlHandlerSupported() {
-		return fmt.Errorf("access-handler is only supported on macOS")
+		return errUnsupportedPlatform
 	}
 
 	if _, err := fmt.Fprintln(out, "\nInstalling the apono:// URL handler. Required to open sessions launched from the Apono portal and Slack."); err != nil {
 		return err
 	}
 
+	if runtime.GOOS == utils.LinuxOS {
+		return registerXDG(out)
+	}
+
 	bundleDir, err := bundlePath()
 	if err != nil {
 		return err
@@ -219,7 +231,14 @@ func registerWithLaunchServices(bundleDir string) error {
 }
 
 func urlHandlerSupported() bool {
-	return runtime.GOOS == utils.DarwinOS
+	return runtime.GOOS == utils.DarwinOS || runtime.GOOS == utils.LinuxOS
+}
+
+func handlerExists() bool {
+	if runtime.GOOS == utils.LinuxOS {
+		return xdgHandlerExists()
+	}
+	return bundleExists()
 }
 
 func bundleExists() bool {
@@ -3,11 +3,8 @@ package urihandler
 import (
 	"bytes"
 	"path/filepath"
-	"runtime"
 	"strings"
 	"testing"
-
-	"github.com/apono-io/apono-cli/pkg/utils"
 )
 
 func TestHandlerShellTemplate_invokesPATHResolvedApono(t *testing.T) {
@@ -45,22 +42,22 @@ func TestHandlerShellTemplate_writesTraceAndFailureContext(t *testing.T) {
 	}
 }
 
-func TestRegister_rejectsNonDarwin(t *testing.T) {
-	if runtime.GOOS == utils.DarwinOS {
-		t.Skip("non-darwin guard test")
+func TestRegister_rejectsUnsupportedPlatforms(t *testing.T) {
+	if urlHandlerSupported() {
+		t.Skip("unsupported platform guard test")
 	}
 
-	if err := Register(&bytes.Buffer{}); err == nil || !strings.Contains(err.Error(), "macOS") {
-		t.Errorf("expected non-darwin to error mentioning macOS, got %v", err)
+	if err := Register(&bytes.Buffer{}); err == nil || !strings.Contains(err.Error(), "macOS and Linux") {
+		t.Errorf("expected unsupported platform to error mentioning macOS and Linux, got %v", err)
 	}
 }
 
-func TestUnregister_rejectsNonDarwin(t *testing.T) {
-	if runtime.GOOS == utils.DarwinOS {
-		t.Skip("non-darwin guard test")
+func TestUnregister_rejectsUnsupportedPlatforms(t *testing.T) {
+	if urlHandlerSupported() {
+		t.Skip("unsupported platform guard test")
 	}
 
-	if err := Unregister(&bytes.Buffer{}); err == nil || !strings.Contains(err.Error(), "macOS") {
-		t.Errorf("expected non-darwin to error mentioning macOS, got %v", err)
+	if err := Unregister(&bytes.Buffer{}); err == nil || !strings.Contains(err.Error(), "macOS and Linux") {
+		t.Errorf("expected unsupported platform to error mentioning macOS and Linux, got %v", err)
 	}
 }
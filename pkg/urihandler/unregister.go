@@ -6,11 +6,17 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"runtime"
+
+	"github.com/apono-io/apono-cli/pkg/utils"
 )
 
 func Unregister(out io.Writer) error {
 	if !urlHandlerSupported() {
-		return fmt.Errorf("access-handler is only supported on macOS")
+		return errUnsupportedPlatform
+	}
+	if runtime.GOOS == utils.LinuxOS {
+		return unregisterXDG(out)
 	}
 
 	bundleDir, err := bundlePath()
@@ -0,0 +1,162 @@
+package urihandler
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+//go:embed scripts/handler_linux.sh
+var xdgHandlerShellTemplate string
+
+const (
+	xdgDesktopFileName = "apono-connect.desktop"
+	xdgHandlerFileName = "handler.sh"
+	xdgSchemeMimeType  = "x-scheme-handler/" + urlScheme
+	xdgDataHomeEnvVar  = "XDG_DATA_HOME"
+	xdgDataParentDir   = "apono-cli"
+	xdgApplicationsDir = "applications"
+
+	xdgHandlerScriptPerm os.FileMode = 0o700
+	xdgDesktopFilePerm   os.FileMode = 0o600
+)
+
+// The desktop file runs the handler through a login shell so the user's
+// profile puts apono on PATH, the same as the macOS bundle does with zsh -l.
+const xdgDesktopEntryTemplate = `[Desktop Entry]
+Type=Application
+Name=` + bundleDisplayName + `
+Exec=/bin/bash -l %s %%u
+NoDisplay=true
+Terminal=false
+MimeType=` + xdgSchemeMimeType + `;
+`
+
+func registerXDG(out io.Writer) error {
+	if _, err := exec.LookPath("xdg-mime"); err != nil {
+		return fmt.Errorf("xdg-mime not found on PATH, install xdg-utils to register the apono:// handler")
+	}
+
+	handlerPath, desktopPath, err := xdgHandlerPaths()
+	if err != nil {
+		return err
+	}
+
+	if err = writeXDGFiles(handlerPath, desktopPath); err != nil {
+		return err
+	}
+
+	if mimeOut, mimeErr := exec.CommandContext(context.Background(), "xdg-mime", "default", xdgDesktopFileName, xdgSchemeMimeType).CombinedOutput(); mimeErr != nil {
+		return fmt.Errorf("xdg-mime default: %w: %s", mimeErr, string(mimeOut))
+	}
+	updateDesktopDatabase(filepath.Dir(desktopPath))
+
+	_, err = fmt.Fprintf(out, "Registered apono:// handler at %s\n", desktopPath)
+	return err
+}
+
+func unregisterXDG(out io.Writer) error {
+	handlerPath, desktopPath, err := xdgHandlerPaths()
+	if err != nil {
+		return err
+	}
+
+	if _, statErr := os.Stat(desktopPath); os.IsNotExist(statErr) {
+		_, err = fmt.Fprintln(out, "Protocol handler is not registered")
+		return err
+	}
+
+	for _, path := range []string{desktopPath, handlerPath} {
+		if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove %s: %w", path, err)
+		}
+	}
+	updateDesktopDatabase(filepath.Dir(desktopPath))
+
+	_, err = fmt.Fprintln(out, "Unregistered apono:// handler")
+	return err
+}
+
+func writeXDGFiles(handlerPath, desktopPath string) error {
+	for _, dir := range []string{filepath.Dir(handlerPath), filepath.Dir(desktopPath)} {
+		if err := os.MkdirAll(dir, bundleParentDirPerm); err != nil {
+			return fmt.Errorf("mkdir %s: %w", dir, err)
+		}
+	}
+
+	if err := os.WriteFile(handlerPath, []byte(xdgHandlerShellTemplate), xdgHandlerScriptPerm); err != nil {
+		return fmt.Errorf("write %s: %w", xdgHandlerFileName, err)
+	}
+	if err := os.WriteFile(desktopPath, []byte(buildXDGDesktopEntry(handlerPath)), xdgDesktopFilePerm); err != nil {
+		return fmt.Errorf("write %s: %w", xdgDesktopFileName, err)
+	}
+	return nil
+}
+
+func buildXDGDesktopEntry(handlerPath string) string {
+	return fmt.Sprintf(xdgDesktopEntryTemplate, quoteDesktopExecArg(handlerPath))
+}
+
+// quoteDesktopExecArg quotes an Exec= argument as described by the desktop
+// entry spec: ", `, $ and \ are escaped inside the quotes, the backslashes
+// are escaped again as the value is a string, and % is doubled for field codes.
+func quoteDesktopExecArg(arg string) string {
+	replacer := strings.NewReplacer(`\`, `\\\\`, `"`, `\\"`, "`", "\\\\`", `$`, `\\$`, `%`, `%%`)
+	return `"` + replacer.Replace(arg) + `"`
+}
+
+// updateDesktopDatabase refreshes the mime cache of the applications dir when
+// the tool is available. Desktops that read the .desktop files directly work
+// without it, so failures are ignored.
+func updateDesktopDatabase(applicationsDir string) {
+	if _, err := exec.LookPath("update-desktop-database"); err != nil {
+		return
+	}
+	_ = exec.CommandContext(context.Background(), "update-desktop-database", applicationsDir).Run()
+}
+
+func xdgHandlerPaths() (handlerPath, desktopPath string, err error) {
+	dataHome, err := xdgDataHome()
+	if err != nil {
+		return "", "", err
+	}
+	return filepath.Join(dataHome, xdgDataParentDir, xdgHandlerFileName),
+		filepath.Join(dataHome, xdgApplicationsDir, xdgDesktopFileName), nil
+}
+
+// xdgDataHome mirrors the ${XDG_DATA_HOME:-$HOME/.local/share} lookup of the
+// handler script, so both agree on where the trace log lives.
+func xdgDataHome() (string, error) {
+	if dataHome := os.Getenv(xdgDataHomeEnvVar); dataHome != "" {
+		return dataHome, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("home dir: %w", err)
+	}
+	return filepath.Join(home, ".local", "share"), nil
+}
+
+func xdgHandlerExists() bool {
+	_, desktopPath, err := xdgHandlerPaths()
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(desktopPath)
+	return err == nil
+}
+
+// xdgSessionAvailable reports whether there is a graphical session with
+// xdg-utils to register against, so SSH sessions on servers are left alone.
+func xdgSessionAvailable() bool {
+	if os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == "" {
+		return false
+	}
+	_, err := exec.LookPath("xdg-mime")
+	return err == nil
+}
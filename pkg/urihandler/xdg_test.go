@@ -0,0 +1,135 @@
+package urihandler
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestXDGHandlerShellTemplate_matchesMacOSHandler(t *testing.T) {
+	wantSubstrings := []string{
+		"#!/bin/bash",
+		filepath.Join(xdgDataParentDir, handlerLogFileName), // log path agrees with HandlerLogPath
+		"log INFO",
+		"command -v apono",
+		"trap",
+		"describe_path",
+		"level=ERROR",
+		"level=WARN",
+		`export _APONO_ACCOUNT_ID_="$account"`,
+		`exec apono access use "$session" --client "$client"`,
+	}
+	for _, want := range wantSubstrings {
+		if !strings.Contains(xdgHandlerShellTemplate, want) {
+			t.Errorf("expected handler_linux.sh to contain %q, got:\n%s", want, xdgHandlerShellTemplate)
+		}
+	}
+}
+
+func TestBuildXDGDesktopEntry_registersSchemeHandler(t *testing.T) {
+	got := buildXDGDesktopEntry("/home/me/.local/share/apono-cli/handler.sh")
+	for _, want := range []string{
+		"[Desktop Entry]",
+		"Type=Application",
+		"MimeType=x-scheme-handler/apono;",
+		`Exec=/bin/bash -l "/home/me/.local/share/apono-cli/handler.sh" %u`,
+		"NoDisplay=true",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("desktop entry missing %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestQuoteDesktopExecArg_escapesReservedCharacters(t *testing.T) {
+	got := quoteDesktopExecArg(`/home/a "b"/$c/100%`)
+	want := `"/home/a \\"b\\"/\\$c/100%%"`
+	if got != want {
+		t.Errorf("quoteDesktopExecArg() = %s, want %s", got, want)
+	}
+}
+
+func TestWriteXDGFiles_writesExecutableHandlerAndDesktopEntry(t *testing.T) {
+	dataHome := t.TempDir()
+	t.Setenv(xdgDataHomeEnvVar, dataHome)
+
+	handlerPath, desktopPath, err := xdgHandlerPaths()
+	if err != nil {
+		t.Fatalf("xdgHandlerPaths: %v", err)
+	}
+	if desktopPath != filepath.Join(dataHome, "applications", xdgDesktopFileName) {
+		t.Errorf("desktop path = %q, want it under $XDG_DATA_HOME/applications", desktopPath)
+	}
+
+	if err = writeXDGFiles(handlerPath, desktopPath); err != nil {
+		t.Fatalf("writeXDGFiles: %v", err)
+	}
+
+	info, err := os.Stat(handlerPath)
+	if err != nil {
+		t.Fatalf("stat handler: %v", err)
+	}
+	if info.Mode().Perm()&0o100 == 0 {
+		t.Errorf("handler mode = %v, want executable", info.Mode())
+	}
+	if !xdgHandlerExists() {
+		t.Errorf("xdgHandlerExists() = false after writing files")
+	}
+}
+
+func TestXDGHandlerScript_launchesAponoAndWritesTrace(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash not available")
+	}
+
+	dataHome := t.TempDir()
+	binDir := t.TempDir()
+	argsPath := filepath.Join(t.TempDir(), "args")
+	fakeApono := "#!/bin/sh\necho \"$_APONO_ACCOUNT_ID_ $*\" > " + argsPath + "\n"
+	if err := os.WriteFile(filepath.Join(binDir, "apono"), []byte(fakeApono), 0o700); err != nil {
+		t.Fatalf("write fake apono: %v", err)
+	}
+
+	handlerPath, desktopPath := filepath.Join(dataHome, xdgDataParentDir, xdgHandlerFileName), filepath.Join(dataHome, "applications", xdgDesktopFileName)
+	if err := writeXDGFiles(handlerPath, desktopPath); err != nil {
+		t.Fatalf("writeXDGFiles: %v", err)
+	}
+
+	run := func(uri string) error {
+		cmd := exec.Command("bash", handlerPath, uri)
+		cmd.Env = []string{"HOME=" + t.TempDir(), "XDG_DATA_HOME=" + dataHome, "PATH=" + binDir + ":/usr/bin:/bin"}
+		return cmd.Run()
+	}
+
+	if err := run("apono://connect?session=s1&account=a1&client=psql"); err != nil {
+		t.Fatalf("handler failed: %v", err)
+	}
+	args, err := os.ReadFile(argsPath)
+	if err != nil {
+		t.Fatalf("fake apono was not invoked: %v", err)
+	}
+	if got := strings.TrimSpace(string(args)); got != "a1 access use s1 --client psql" {
+		t.Errorf("apono invoked with %q", got)
+	}
+
+	var exitErr *exec.ExitError
+	if err = run("apono://other"); !errors.As(err, &exitErr) || exitErr.ExitCode() != 64 {
+		t.Errorf("expected exit code 64 for invalid URI, got %v", err)
+	}
+
+	lines, err := DrainLog(filepath.Join(dataHome, xdgDataParentDir, handlerLogFileName))
+	if err != nil {
+		t.Fatalf("DrainLog: %v", err)
+	}
+	var sawParsed, sawInvalid bool
+	for _, line := range lines {
+		sawParsed = sawParsed || (line.Level == "INFO" && strings.Contains(line.Message, "session=s1 account=a1 client=psql"))
+		sawInvalid = sawInvalid || (line.Level == "ERROR" && strings.Contains(line.Message, "invalid launch URL code=64"))
+	}
+	if !sawParsed || !sawInvalid {
+		t.Errorf("trace log missing parsed or failure lines, got %+v", lines)
+	}
+}
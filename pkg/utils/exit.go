@@ -0,0 +1,13 @@
+package utils
+
+import "fmt"
+
+// ExitCodeError is returned by commands that wrap a child process, so the CLI
+// exits with the same code as the child instead of printing an error.
+type ExitCodeError struct {
+	Code int
+}
+
+func (e *ExitCodeError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
@@ -1,8 +1,19 @@
 package utils
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const AllProfilesFlagName = "all-profiles"
 
 func IsFlagSet(cmd *cobra.Command, flagName string) bool {
 	flag := cmd.Flag(flagName)
 	return flag != nil && flag.Changed
 }
+
+// AddAllProfilesFlag adds --all-profiles to read commands that can list the
+// results of every configured profile side by side.
+func AddAllProfilesFlag(flags *pflag.FlagSet, value *bool) {
+	flags.BoolVar(value, AllProfilesFlagName, false, "Query every configured profile concurrently and merge the results with an ACCOUNT column")
+}
@@ -4,23 +4,123 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/pflag"
-	"github.com/thediveo/enumflag"
 	"gopkg.in/yaml.v3"
 )
 
-type Format enumflag.Flag
+type FormatKind int
 
 const (
-	TableFormat Format = iota
+	TableFormat FormatKind = iota
 	JSONFormat
 	YamlFormat
+	WideFormat
+	CSVFormat
+	TSVFormat
+	JSONPathFormat
+	JSONPathFileFormat
+	GoTemplateFormat
+	GoTemplateFileFormat
 )
 
+const noHeadersFlagName = "no-headers"
+
+// Format is the value of the --output flag. Template based formats carry
+// their template (or template file path) in Param, e.g. -o jsonpath='{.id}'.
+type Format struct {
+	Kind      FormatKind
+	Param     string
+	NoHeaders bool
+}
+
 func AddFormatFlag(flags *pflag.FlagSet, formatPtr *Format) {
-	enumValue := enumflag.New(formatPtr, "format", formatIds, enumflag.EnumCaseSensitive)
-	flags.VarP(enumValue, "output", "o", "Output format. Valid values are 'table', 'yaml', or 'json'")
+	flags.VarP(formatPtr, "output", "o", "Output format. Valid values are 'table', 'wide', 'yaml', 'json', 'csv', 'tsv', 'jsonpath=<template>', 'jsonpath-file=<path>', 'go-template=<template>' or 'go-template-file=<path>'")
+	flags.BoolVar(&formatPtr.NoHeaders, noHeadersFlagName, false, "Don't print headers when using the 'table', 'wide', 'csv' or 'tsv' output formats")
+}
+
+func (f *Format) String() string {
+	name := formatNames[f.Kind]
+	if f.Param != "" {
+		return name + "=" + f.Param
+	}
+
+	return name
+}
+
+func (f *Format) Set(value string) error {
+	name, param, hasParam := strings.Cut(value, "=")
+	for kind, kindName := range formatNames {
+		if kindName != name {
+			continue
+		}
+
+		if isTemplateFormat(kind) && (!hasParam || param == "") {
+			return fmt.Errorf("output format %q requires a template, for example: %s='...'", name, name)
+		}
+		if !isTemplateFormat(kind) && hasParam {
+			return fmt.Errorf("output format %q does not accept a parameter", name)
+		}
+
+		f.Kind = kind
+		f.Param = param
+		return nil
+	}
+
+	return fmt.Errorf("unsupported output format %q", value)
+}
+
+func (f *Format) Type() string {
+	return "format"
+}
+
+// IsTable reports whether the format is meant to be read by a human in the
+// terminal, as opposed to being parsed by a script.
+func (f Format) IsTable() bool {
+	return f.Kind == TableFormat || f.Kind == WideFormat
+}
+
+// PrintObjects renders objects in the requested format. The table is used for
+// the tabular formats and may be nil for commands that have no table view.
+func PrintObjects(writer io.Writer, format Format, objects any, table *Table) error {
+	switch format.Kind {
+	case JSONFormat:
+		return PrintObjectsAsJSON(writer, objects)
+	case YamlFormat:
+		return PrintObjectsAsYaml(writer, objects)
+	case JSONPathFormat, JSONPathFileFormat:
+		template, err := format.template()
+		if err != nil {
+			return err
+		}
+		return PrintObjectsAsJSONPath(writer, objects, template)
+	case GoTemplateFormat, GoTemplateFileFormat:
+		template, err := format.template()
+		if err != nil {
+			return err
+		}
+		return PrintObjectsAsGoTemplate(writer, objects, template)
+	}
+
+	if table == nil {
+		return fmt.Errorf("unsupported output format")
+	}
+
+	switch format.Kind {
+	case TableFormat:
+		return table.Print(writer, false, format.NoHeaders)
+	case WideFormat:
+		return table.Print(writer, true, format.NoHeaders)
+	case CSVFormat:
+		return table.PrintDelimited(writer, ',', format.NoHeaders)
+	case TSVFormat:
+		return table.PrintDelimited(writer, '\t', format.NoHeaders)
+	default:
+		return fmt.Errorf("unsupported output format")
+	}
 }
 
 func PrintObjectsAsJSON(writer io.Writer, objects any) error {
@@ -38,8 +138,53 @@ func PrintObjectsAsYaml(writer io.Writer, objects any) error {
 	return err
 }
 
-var formatIds = map[Format][]string{
-	TableFormat: {"table"},
-	JSONFormat:  {"json"},
-	YamlFormat:  {"yaml"},
+func (f Format) template() (string, error) {
+	if f.Kind != JSONPathFileFormat && f.Kind != GoTemplateFileFormat {
+		return f.Param, nil
+	}
+
+	content, err := os.ReadFile(filepath.Clean(f.Param))
+	if err != nil {
+		return "", fmt.Errorf("failed to read template file %s: %w", f.Param, err)
+	}
+
+	return string(content), nil
+}
+
+// toGenericObject converts objects to the generic representation produced by
+// their JSON encoding, so templates reference the same field names as -o json.
+func toGenericObject(objects any) (any, error) {
+	bytes, err := json.Marshal(objects)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic any
+	if err = json.Unmarshal(bytes, &generic); err != nil {
+		return nil, err
+	}
+
+	return generic, nil
+}
+
+func isTemplateFormat(kind FormatKind) bool {
+	switch kind {
+	case JSONPathFormat, JSONPathFileFormat, GoTemplateFormat, GoTemplateFileFormat:
+		return true
+	default:
+		return false
+	}
+}
+
+var formatNames = map[FormatKind]string{
+	TableFormat:          "table",
+	JSONFormat:           "json",
+	YamlFormat:           "yaml",
+	WideFormat:           "wide",
+	CSVFormat:            "csv",
+	TSVFormat:            "tsv",
+	JSONPathFormat:       "jsonpath",
+	JSONPathFileFormat:   "jsonpath-file",
+	GoTemplateFormat:     "go-template",
+	GoTemplateFileFormat: "go-template-file",
 }
@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type testRequest struct {
+	ID       string            `json:"id"`
+	Status   string            `json:"status"`
+	Duration int               `json:"duration"`
+	Labels   map[string]string `json:"labels,omitempty"`
+}
+
+var testRequests = []testRequest{
+	{ID: "req-1", Status: "Active", Duration: 3600, Labels: map[string]string{"team": "data"}},
+	{ID: "req-2", Status: "Pending", Duration: 60},
+	{ID: "req-3", Status: "Active", Duration: 7200},
+}
+
+func TestFormatSet(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Format
+		wantErr bool
+	}{
+		{value: "table", want: Format{Kind: TableFormat}},
+		{value: "wide", want: Format{Kind: WideFormat}},
+		{value: "csv", want: Format{Kind: CSVFormat}},
+		{value: "jsonpath={.id}", want: Format{Kind: JSONPathFormat, Param: "{.id}"}},
+		{value: "go-template={{.id}}={{.status}}", want: Format{Kind: GoTemplateFormat, Param: "{{.id}}={{.status}}"}},
+		{value: "go-template-file=/tmp/tmpl", want: Format{Kind: GoTemplateFileFormat, Param: "/tmp/tmpl"}},
+		{value: "jsonpath", wantErr: true},
+		{value: "json=x", wantErr: true},
+		{value: "xml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			var got Format
+			err := got.Set(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Set(%q) succeeded, want error", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Set(%q): %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("Set(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintObjects_goTemplateFile(t *testing.T) {
+	templatePath := filepath.Join(t.TempDir(), "requests.tmpl")
+	if err := os.WriteFile(templatePath, []byte(`{{range .}}{{.id}}:{{.status}};{{end}}`), 0o600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+
+	var buf bytes.Buffer
+	format := Format{Kind: GoTemplateFileFormat, Param: templatePath}
+	if err := PrintObjects(&buf, format, testRequests, nil); err != nil {
+		t.Fatalf("PrintObjects: %v", err)
+	}
+
+	want := "req-1:Active;req-2:Pending;req-3:Active;"
+	if got := buf.String(); got != want {
+		t.Errorf("PrintObjects = %q, want %q", got, want)
+	}
+}
+
+func TestPrintObjects_tabularFormats(t *testing.T) {
+	table := NewTable(Column("ID"), Column("STATUS"), WideColumn("DURATION"))
+	for _, request := range testRequests[:2] {
+		table.AddRow(request.ID, request.Status, request.Duration)
+	}
+
+	tests := []struct {
+		name   string
+		format Format
+		want   string
+	}{
+		{name: "csv", format: Format{Kind: CSVFormat}, want: "ID,STATUS,DURATION\nreq-1,Active,3600\nreq-2,Pending,60\n"},
+		{name: "tsv without headers", format: Format{Kind: TSVFormat, NoHeaders: true}, want: "req-1\tActive\t3600\nreq-2\tPending\t60\n"},
+		{name: "table hides wide columns", format: Format{Kind: TableFormat}, want: "ID   \tSTATUS \nreq-1\tActive \nreq-2\tPending\n"},
+		{name: "wide", format: Format{Kind: WideFormat, NoHeaders: true}, want: "req-1\tActive \t3600\nreq-2\tPending\t60  \n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := PrintObjects(&buf, tt.format, testRequests, table); err != nil {
+				t.Fatalf("PrintObjects: %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("PrintObjects = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintObjects_tableFormatWithoutTable(t *testing.T) {
+	var buf bytes.Buffer
+	if err := PrintObjects(&buf, Format{Kind: CSVFormat}, testRequests, nil); err == nil {
+		t.Error("PrintObjects(csv, nil table) succeeded, want error")
+	}
+}
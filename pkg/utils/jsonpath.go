@@ -0,0 +1,551 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// This file implements the subset of the kubectl JSONPath template syntax
+// scripts need, and nothing more:
+//
+//	{.a.b} {['a']}       fields, from the current object, $ or @
+//	{[1]} {[-1]}         array index, negative from the end
+//	{[0:2]}              array slice
+//	{[*]} {.*}           all items of an array or values of an object
+//	{[?(@.a)]}           items that have a field
+//	{[?(@.a == "x")]}    items compared with ==, !=, <, <=, > or >= to a
+//	                     string, number or boolean
+//	{"\t"}               quoted literal text
+//	{range [*]}..{end}   the body once per item
+//
+// Values are joined with spaces and missing keys produce no output. Other
+// kubectl constructs, such as .. recursive descent, are rejected.
+
+type jsonPathNode struct {
+	text    string
+	path    *jsonPath
+	isRange bool
+	body    []jsonPathNode
+}
+
+type jsonPath struct {
+	fromRoot bool
+	steps    []jsonPathStep
+}
+
+// jsonPathStep returns the values a step selects from a node.
+type jsonPathStep func(node any, root any) []any
+
+type jsonPathFilter struct {
+	path     *jsonPath
+	operator string
+	value    any
+}
+
+// Longer operators first, so <= is not read as <.
+var jsonPathOperators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+func PrintObjectsAsJSONPath(writer io.Writer, objects any, text string) error {
+	nodes, _, err := parseJSONPathNodes(text, false)
+	if err != nil {
+		return fmt.Errorf("failed to parse jsonpath template: %w", err)
+	}
+
+	data, err := toGenericObject(objects)
+	if err != nil {
+		return err
+	}
+
+	var output strings.Builder
+	if err = executeJSONPathNodes(&output, nodes, data, data); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(writer, output.String())
+	return err
+}
+
+// parseJSONPathNodes parses text up to its end, or up to the {end} of a range
+// body, and returns the text after it.
+func parseJSONPathNodes(text string, inRange bool) ([]jsonPathNode, string, error) {
+	var nodes []jsonPathNode
+	for text != "" {
+		open := strings.IndexByte(text, '{')
+		if open < 0 {
+			nodes = append(nodes, jsonPathNode{text: text})
+			break
+		}
+		if open > 0 {
+			nodes = append(nodes, jsonPathNode{text: text[:open]})
+		}
+
+		end := findClosing(text, open, '{', '}')
+		if end < 0 {
+			return nil, "", fmt.Errorf("unclosed action in %q", text[open:])
+		}
+
+		action := strings.TrimSpace(text[open+1 : end])
+		text = text[end+1:]
+
+		switch {
+		case action == "end":
+			if !inRange {
+				return nil, "", fmt.Errorf("unexpected {end}")
+			}
+			return nodes, text, nil
+		case strings.HasPrefix(action, "range "):
+			path, err := parseJSONPath(strings.TrimSpace(strings.TrimPrefix(action, "range ")))
+			if err != nil {
+				return nil, "", err
+			}
+
+			var body []jsonPathNode
+			if body, text, err = parseJSONPathNodes(text, true); err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, jsonPathNode{path: path, isRange: true, body: body})
+		case isQuoted(action):
+			literal, err := unquoteJSONPathLiteral(action)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, jsonPathNode{text: literal})
+		default:
+			path, err := parseJSONPath(action)
+			if err != nil {
+				return nil, "", err
+			}
+			nodes = append(nodes, jsonPathNode{path: path})
+		}
+	}
+
+	if inRange {
+		return nil, "", fmt.Errorf("missing {end} for {range}")
+	}
+
+	return nodes, "", nil
+}
+
+func parseJSONPath(expr string) (*jsonPath, error) {
+	path := &jsonPath{fromRoot: strings.HasPrefix(expr, "$")}
+	rest := strings.TrimPrefix(strings.TrimPrefix(expr, "$"), "@")
+
+	for rest != "" && rest != "." {
+		var step jsonPathStep
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			return nil, fmt.Errorf("recursive descent is not supported in %q", expr)
+		case rest[0] == '[':
+			end := findClosing(rest, 0, '[', ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed bracket in %q", expr)
+			}
+
+			var err error
+			if step, err = parseJSONPathBracket(strings.TrimSpace(rest[1:end])); err != nil {
+				return nil, err
+			}
+			rest = rest[end+1:]
+		case strings.HasPrefix(rest, ".["):
+			// A dot may lead a bracket, as in {.[*].id}.
+			rest = rest[1:]
+			continue
+		default:
+			rest = strings.TrimPrefix(rest, ".")
+			length := strings.IndexAny(rest, ".[")
+			if length < 0 {
+				length = len(rest)
+			}
+
+			name := rest[:length]
+			rest = rest[length:]
+			switch name {
+			case "":
+				return nil, fmt.Errorf("empty field name in %q", expr)
+			case "*":
+				step = jsonPathWildcard
+			default:
+				step = jsonPathField(name)
+			}
+		}
+
+		path.steps = append(path.steps, step)
+	}
+
+	return path, nil
+}
+
+func parseJSONPathBracket(content string) (jsonPathStep, error) {
+	switch {
+	case content == "*":
+		return jsonPathWildcard, nil
+	case strings.HasPrefix(content, "?(") && strings.HasSuffix(content, ")"):
+		filter, err := parseJSONPathFilter(strings.TrimSpace(content[2 : len(content)-1]))
+		if err != nil {
+			return nil, err
+		}
+		return filter.step, nil
+	case isQuoted(content):
+		name, err := unquoteJSONPathLiteral(content)
+		if err != nil {
+			return nil, err
+		}
+		return jsonPathField(name), nil
+	case strings.Contains(content, ":"):
+		startText, endText, _ := strings.Cut(content, ":")
+		start, err := parseOptionalInt(startText)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseOptionalInt(endText)
+		if err != nil {
+			return nil, err
+		}
+		return jsonPathSlice(start, end), nil
+	default:
+		index, err := strconv.Atoi(content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid array index %q", content)
+		}
+		return jsonPathIndex(index), nil
+	}
+}
+
+func parseJSONPathFilter(expr string) (*jsonPathFilter, error) {
+	for _, operator := range jsonPathOperators {
+		left, right, found := cutOutsideQuotes(expr, operator)
+		if !found {
+			continue
+		}
+
+		path, err := parseJSONPath(strings.TrimSpace(left))
+		if err != nil {
+			return nil, err
+		}
+		value, err := parseJSONPathValue(strings.TrimSpace(right))
+		if err != nil {
+			return nil, err
+		}
+
+		return &jsonPathFilter{path: path, operator: operator, value: value}, nil
+	}
+
+	path, err := parseJSONPath(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jsonPathFilter{path: path}, nil
+}
+
+func parseJSONPathValue(text string) (any, error) {
+	switch {
+	case isQuoted(text):
+		return unquoteJSONPathLiteral(text)
+	case text == "true" || text == "false":
+		return text == "true", nil
+	default:
+		number, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter value %q", text)
+		}
+		return number, nil
+	}
+}
+
+func executeJSONPathNodes(output *strings.Builder, nodes []jsonPathNode, root any, current any) error {
+	for _, node := range nodes {
+		switch {
+		case node.isRange:
+			items := node.path.evaluate(root, current)
+			if len(items) == 1 {
+				if array, ok := items[0].([]any); ok {
+					items = array
+				}
+			}
+
+			for _, item := range items {
+				if err := executeJSONPathNodes(output, node.body, root, item); err != nil {
+					return err
+				}
+			}
+		case node.path != nil:
+			values := node.path.evaluate(root, current)
+			texts := make([]string, len(values))
+			for i, value := range values {
+				text, err := jsonPathValueText(value)
+				if err != nil {
+					return err
+				}
+				texts[i] = text
+			}
+
+			output.WriteString(strings.Join(texts, " "))
+		default:
+			output.WriteString(node.text)
+		}
+	}
+
+	return nil
+}
+
+func (p *jsonPath) evaluate(root any, current any) []any {
+	nodes := []any{current}
+	if p.fromRoot {
+		nodes = []any{root}
+	}
+
+	for _, step := range p.steps {
+		var next []any
+		for _, node := range nodes {
+			next = append(next, step(node, root)...)
+		}
+		nodes = next
+	}
+
+	return nodes
+}
+
+func jsonPathField(name string) jsonPathStep {
+	return func(node any, _ any) []any {
+		if object, ok := node.(map[string]any); ok {
+			if value, exists := object[name]; exists {
+				return []any{value}
+			}
+		}
+		return nil
+	}
+}
+
+func jsonPathIndex(index int) jsonPathStep {
+	return func(node any, _ any) []any {
+		array, ok := node.([]any)
+		if !ok {
+			return nil
+		}
+		i := index
+		if i < 0 {
+			i += len(array)
+		}
+		if i < 0 || i >= len(array) {
+			return nil
+		}
+		return []any{array[i]}
+	}
+}
+
+func jsonPathSlice(start, end *int) jsonPathStep {
+	return func(node any, _ any) []any {
+		array, ok := node.([]any)
+		if !ok {
+			return nil
+		}
+		from := sliceBound(start, 0, len(array))
+		to := sliceBound(end, len(array), len(array))
+		if from >= to {
+			return nil
+		}
+		return array[from:to]
+	}
+}
+
+// jsonPathWildcard returns the items of an array, or the values of an object
+// sorted by key.
+func jsonPathWildcard(node any, _ any) []any {
+	switch value := node.(type) {
+	case []any:
+		return value
+	case map[string]any:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		children := make([]any, len(keys))
+		for i, key := range keys {
+			children[i] = value[key]
+		}
+		return children
+	default:
+		return nil
+	}
+}
+
+func (f *jsonPathFilter) step(node any, root any) []any {
+	var result []any
+	for _, child := range jsonPathWildcard(node, root) {
+		if f.matches(root, child) {
+			result = append(result, child)
+		}
+	}
+	return result
+}
+
+func (f *jsonPathFilter) matches(root any, node any) bool {
+	values := f.path.evaluate(root, node)
+	if f.operator == "" {
+		return len(values) > 0
+	}
+
+	for _, value := range values {
+		if compareJSONPathValues(value, f.operator, f.value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func compareJSONPathValues(left any, operator string, right any) bool {
+	switch operator {
+	case "==":
+		return left == right
+	case "!=":
+		return left != right
+	}
+
+	switch leftValue := left.(type) {
+	case float64:
+		rightValue, ok := right.(float64)
+		return ok && compareOrdered(leftValue, operator, rightValue)
+	case string:
+		rightValue, ok := right.(string)
+		return ok && compareOrdered(leftValue, operator, rightValue)
+	default:
+		return false
+	}
+}
+
+func compareOrdered[T float64 | string](left T, operator string, right T) bool {
+	switch operator {
+	case "<":
+		return left < right
+	case ">":
+		return left > right
+	case "<=":
+		return left <= right
+	case ">=":
+		return left >= right
+	default:
+		return false
+	}
+}
+
+func jsonPathValueText(value any) (string, error) {
+	switch typed := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return typed, nil
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(typed), nil
+	default:
+		bytes, err := json.Marshal(typed)
+		return string(bytes), err
+	}
+}
+
+func sliceBound(bound *int, defaultValue int, length int) int {
+	if bound == nil {
+		return defaultValue
+	}
+
+	value := *bound
+	if value < 0 {
+		value += length
+	}
+
+	return max(0, min(value, length))
+}
+
+func parseOptionalInt(text string) (*int, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.Atoi(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid slice bound %q", text)
+	}
+
+	return &value, nil
+}
+
+func isQuoted(text string) bool {
+	if len(text) < 2 {
+		return false
+	}
+
+	first, last := text[0], text[len(text)-1]
+	return (first == '"' || first == '\'') && first == last
+}
+
+func unquoteJSONPathLiteral(text string) (string, error) {
+	inner := text[1 : len(text)-1]
+	if text[0] == '\'' {
+		inner = strings.ReplaceAll(inner, `"`, `\"`)
+	}
+
+	literal, err := strconv.Unquote(`"` + inner + `"`)
+	if err != nil {
+		return "", fmt.Errorf("invalid literal %s", text)
+	}
+
+	return literal, nil
+}
+
+// findClosing returns the index of the bracket closing the one at position
+// open, ignoring brackets inside quoted strings, or -1 when there is none.
+func findClosing(text string, open int, opening byte, closing byte) int {
+	depth := 0
+	var quote byte
+	for i := open; i < len(text); i++ {
+		char := text[i]
+		switch {
+		case quote != 0:
+			if char == '\\' {
+				i++
+			} else if char == quote {
+				quote = 0
+			}
+		case char == '"' || char == '\'':
+			quote = char
+		case char == opening:
+			depth++
+		case char == closing:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+func cutOutsideQuotes(text string, separator string) (string, string, bool) {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		char := text[i]
+		switch {
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			}
+		case char == '"' || char == '\'':
+			quote = char
+		case strings.HasPrefix(text[i:], separator):
+			return text[:i], text[i+len(separator):], true
+		}
+	}
+
+	return text, "", false
+}
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPrintObjectsAsJSONPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{name: "plain text", template: "requests", want: "requests"},
+		{name: "current object", template: "{.}", want: `[{"duration":3600,"id":"req-1","labels":{"team":"data"},"status":"Active"},{"duration":60,"id":"req-2","status":"Pending"},{"duration":7200,"id":"req-3","status":"Active"}]`},
+		{name: "field", template: "{[0].id}", want: "req-1"},
+		{name: "nested field", template: "{[0].labels.team}", want: "data"},
+		{name: "quoted field", template: "{[0]['labels'][\"team\"]}", want: "data"},
+		{name: "root", template: "{$[2].id}", want: "req-3"},
+		{name: "current", template: "{@[2].id}", want: "req-3"},
+		{name: "index", template: "{[1].status}", want: "Pending"},
+		{name: "negative index", template: "{[-1].id}", want: "req-3"},
+		{name: "index out of range", template: "{[5].id}", want: ""},
+		{name: "slice", template: "{[0:2].id}", want: "req-1 req-2"},
+		{name: "open slice", template: "{[1:].id}", want: "req-2 req-3"},
+		{name: "negative slice", template: "{[:-2].id}", want: "req-1"},
+		{name: "wildcard", template: "{[*].id}", want: "req-1 req-2 req-3"},
+		{name: "dot before bracket", template: "{.[*].id}", want: "req-1 req-2 req-3"},
+		{name: "object wildcard", template: "{[0].labels.*}", want: "data"},
+		{name: "filter exists", template: "{[?(@.labels)].id}", want: "req-1"},
+		{name: "filter equals", template: `{[?(@.status=="Active")].id}`, want: "req-1 req-3"},
+		{name: "filter single quotes", template: `{[?(@.status=='Pending')].id}`, want: "req-2"},
+		{name: "filter not equals", template: `{[?(@.status!="Active")].id}`, want: "req-2"},
+		{name: "filter less than", template: "{[?(@.duration < 3600)].id}", want: "req-2"},
+		{name: "filter at most", template: "{[?(@.duration <= 3600)].id}", want: "req-1 req-2"},
+		{name: "filter greater than", template: "{[?(@.duration > 3600)].id}", want: "req-3"},
+		{name: "filter at least", template: "{[?(@.duration >= 3600)].id}", want: "req-1 req-3"},
+		{name: "filter string order", template: `{[?(@.id > "req-1")].id}`, want: "req-2 req-3"},
+		{name: "filter type mismatch", template: `{[?(@.duration > "1")].id}`, want: ""},
+		{name: "literal", template: `{[0].id}{"\t"}{'x'}{"\n"}`, want: "req-1\tx\n"},
+		{name: "range", template: `{range [*]}{.id}:{.duration};{end}`, want: "req-1:3600;req-2:60;req-3:7200;"},
+		{name: "range over filter", template: `{range [?(@.status=="Active")]}{.id}{"\n"}{end}`, want: "req-1\nreq-3\n"},
+		{name: "nested range", template: `{range [0:1]}{range .labels.*}{.}{end}{end}`, want: "data"},
+		{name: "missing key", template: "{[0].missing}", want: ""},
+		{name: "number", template: "{[0].duration}", want: "3600"},
+		{name: "object value", template: "{[0].labels}", want: `{"team":"data"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := PrintObjectsAsJSONPath(&buf, testRequests, tt.template); err != nil {
+				t.Fatalf("PrintObjectsAsJSONPath(%q): %v", tt.template, err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("PrintObjectsAsJSONPath(%q) = %q, want %q", tt.template, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintObjectsAsJSONPath_invalidTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{name: "unclosed action", template: "{.id"},
+		{name: "missing end", template: "{range [*]}{.id}"},
+		{name: "unexpected end", template: "{end}"},
+		{name: "invalid index", template: "{[abc]}"},
+		{name: "invalid slice bound", template: "{[1:x]}"},
+		{name: "unclosed bracket", template: "{[0}"},
+		{name: "empty field", template: "{[0]..}"},
+		{name: "recursive descent", template: "{..team}"},
+		{name: "invalid filter value", template: "{[?(@.status == Active)]}"},
+		{name: "invalid literal", template: `{"\q"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := PrintObjectsAsJSONPath(&buf, testRequests, tt.template); err == nil {
+				t.Errorf("PrintObjectsAsJSONPath(%q) succeeded with %q, want error", tt.template, buf.String())
+			}
+		})
+	}
+}
@@ -1,3 +1,14 @@
 package utils
 
-const DarwinOS = "darwin"
+import "runtime"
+
+const (
+	DarwinOS = "darwin"
+	LinuxOS  = "linux"
+)
+
+// SupportsClientLaunchers reports whether access sessions can be opened in
+// local clients (GUI apps, TUIs or a new terminal window) on this OS.
+func SupportsClientLaunchers() bool {
+	return runtime.GOOS == DarwinOS || runtime.GOOS == LinuxOS
+}
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"strings"
+
 	"github.com/google/uuid"
 
 	"github.com/apono-io/apono-cli/pkg/clientapi"
@@ -26,3 +28,8 @@ func FromNullableString(s clientapi.NullableString) string {
 	}
 	return *s.Get()
 }
+
+// ShellQuote quotes a value as a single POSIX shell word.
+func ShellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,125 @@
+package utils
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/gookit/color"
+	"github.com/gosuri/uitable"
+)
+
+type TableColumn struct {
+	Header string
+	Wide   bool
+}
+
+// Table holds the tabular view of a command output. Wide columns are only
+// printed with -o wide and with the delimited formats.
+type Table struct {
+	columns []TableColumn
+	rows    [][]string
+}
+
+func Column(header string) TableColumn {
+	return TableColumn{Header: header}
+}
+
+func WideColumn(header string) TableColumn {
+	return TableColumn{Header: header, Wide: true}
+}
+
+func NewTable(columns ...TableColumn) *Table {
+	return &Table{columns: columns}
+}
+
+func (t *Table) AddRow(cells ...any) {
+	row := make([]string, len(t.columns))
+	for i := range row {
+		if i < len(cells) {
+			row[i] = fmt.Sprint(cells[i])
+		}
+	}
+
+	t.rows = append(t.rows, row)
+}
+
+// PrependColumn adds a column before the existing ones, with one value per
+// row that was already added.
+func (t *Table) PrependColumn(column TableColumn, values []string) {
+	t.columns = append([]TableColumn{column}, t.columns...)
+	for i, row := range t.rows {
+		value := ""
+		if i < len(values) {
+			value = values[i]
+		}
+		t.rows[i] = append([]string{value}, row...)
+	}
+}
+
+func (t *Table) Print(writer io.Writer, wide bool, noHeaders bool) error {
+	table := uitable.New()
+	if !noHeaders {
+		table.AddRow(toAnySlice(t.visibleCells(t.headers(), wide))...)
+	}
+	for _, row := range t.rows {
+		table.AddRow(toAnySlice(t.visibleCells(row, wide))...)
+	}
+
+	_, err := fmt.Fprintln(writer, table)
+	return err
+}
+
+func (t *Table) PrintDelimited(writer io.Writer, delimiter rune, noHeaders bool) error {
+	csvWriter := csv.NewWriter(writer)
+	csvWriter.Comma = delimiter
+
+	if !noHeaders {
+		if err := csvWriter.Write(t.headers()); err != nil {
+			return err
+		}
+	}
+	for _, row := range t.rows {
+		plainRow := make([]string, len(row))
+		for i, cell := range row {
+			plainRow[i] = color.ClearCode(cell)
+		}
+
+		if err := csvWriter.Write(plainRow); err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func (t *Table) headers() []string {
+	headers := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		headers[i] = column.Header
+	}
+
+	return headers
+}
+
+func (t *Table) visibleCells(row []string, wide bool) []string {
+	var cells []string
+	for i, column := range t.columns {
+		if column.Wide && !wide {
+			continue
+		}
+		cells = append(cells, row[i])
+	}
+
+	return cells
+}
+
+func toAnySlice(values []string) []any {
+	result := make([]any, len(values))
+	for i, value := range values {
+		result[i] = value
+	}
+
+	return result
+}
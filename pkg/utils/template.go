@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+var templateFuncs = template.FuncMap{
+	"json": func(value any) (string, error) {
+		bytes, err := json.Marshal(value)
+		return string(bytes), err
+	},
+	"join": func(separator string, values []any) string {
+		parts := make([]string, len(values))
+		for i, value := range values {
+			parts[i] = fmt.Sprint(value)
+		}
+		return strings.Join(parts, separator)
+	},
+	"time": func(unixTime float64) string {
+		return DisplayTime(ConvertUnixTimeToTime(unixTime))
+	},
+}
+
+func PrintObjectsAsGoTemplate(writer io.Writer, objects any, text string) error {
+	tmpl, err := template.New("output").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return fmt.Errorf("failed to parse go-template: %w", err)
+	}
+
+	data, err := toGenericObject(objects)
+	if err != nil {
+		return err
+	}
+
+	if err = tmpl.Execute(writer, data); err != nil {
+		return fmt.Errorf("failed to execute go-template: %w", err)
+	}
+
+	return nil
+}